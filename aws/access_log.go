@@ -0,0 +1,262 @@
+package aws
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+// Access log tables read log files written to S3 by ELB and CloudFront. The
+// log files to read are chosen from the object keys, which include the time
+// the file was written, so a narrow timestamp range means fewer files to read.
+
+// Time range used if the query has no timestamp quals
+const accessLogDefaultLookback = time.Hour
+
+// Some log lines, e.g. long URLs or user agents, exceed bufio's default limit
+const accessLogMaxLineSize = 1024 * 1024
+
+func accessLogKeyColumns() []*plugin.KeyColumn {
+	return []*plugin.KeyColumn{
+		{Name: "timestamp", Operators: []string{">", ">=", "=", "<", "<="}, Require: plugin.Optional},
+	}
+}
+
+// accessLogTimeRange returns the time range to read log files for, based on
+// the timestamp quals. Without a lower bound, the last hour before the upper
+// bound is read.
+func accessLogTimeRange(d *plugin.QueryData) (time.Time, time.Time) {
+	var start, end time.Time
+	if d.Quals["timestamp"] != nil {
+		for _, q := range d.Quals["timestamp"].Quals {
+			ts := q.Value.GetTimestampValue().AsTime()
+			switch q.Operator {
+			case "=":
+				start, end = ts, ts
+			case ">=", ">":
+				start = ts
+			case "<", "<=":
+				end = ts
+			}
+		}
+	}
+	if end.IsZero() {
+		end = time.Now()
+	}
+	if start.IsZero() {
+		start = end.Add(-accessLogDefaultLookback)
+	}
+	return start.UTC(), end.UTC()
+}
+
+// accessLogDays returns the start of each UTC day in the time range, as log
+// files are partitioned by day.
+func accessLogDays(start time.Time, end time.Time) []time.Time {
+	var days []time.Time
+	day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+	for !day.After(end) {
+		days = append(days, day)
+		day = day.AddDate(0, 0, 1)
+	}
+	return days
+}
+
+// accessLogLineParser parses a single line of a log file, returning nil if the
+// line does not hold a log entry (e.g. a header or blank line).
+type accessLogLineParser func(line string) interface{}
+
+// streamAccessLogFiles lists the objects under each prefix, and streams the
+// entries of each log file accepted by includeKey. newParser is called once
+// per log file, since some formats (e.g. CloudFront) describe their fields in
+// a file header.
+func streamAccessLogFiles(ctx context.Context, d *plugin.QueryData, svc *s3.Client, bucket string, prefixes []string, includeKey func(key string) bool, newParser func(key string) accessLogLineParser) error {
+	for _, prefix := range prefixes {
+		paginator := s3.NewListObjectsV2Paginator(svc, &s3.ListObjectsV2Input{
+			Bucket: aws.String(bucket),
+			Prefix: aws.String(prefix),
+		})
+
+		for paginator.HasMorePages() {
+			// apply rate limiting
+			d.WaitForListRateLimit(ctx)
+
+			output, err := paginator.NextPage(ctx)
+			if err != nil {
+				plugin.Logger(ctx).Error("streamAccessLogFiles", "list_objects_error", err, "bucket", bucket, "prefix", prefix)
+				return err
+			}
+
+			for _, object := range output.Contents {
+				key := aws.ToString(object.Key)
+				if !includeKey(key) {
+					continue
+				}
+				done, err := streamAccessLogFile(ctx, d, svc, bucket, key, newParser(key))
+				if err != nil {
+					return err
+				}
+				if done {
+					return nil
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// streamAccessLogFile streams the entries of a single, optionally gzipped, log
+// file. It returns true once no more rows are required by the query.
+func streamAccessLogFile(ctx context.Context, d *plugin.QueryData, svc *s3.Client, bucket string, key string, parse accessLogLineParser) (bool, error) {
+	object, err := svc.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("streamAccessLogFile", "get_object_error", err, "bucket", bucket, "key", key)
+		return false, err
+	}
+	defer object.Body.Close()
+
+	var reader io.Reader = object.Body
+	if strings.HasSuffix(key, ".gz") {
+		gz, err := gzip.NewReader(object.Body)
+		if err != nil {
+			plugin.Logger(ctx).Error("streamAccessLogFile", "gzip_error", err, "bucket", bucket, "key", key)
+			return false, err
+		}
+		defer gz.Close()
+		reader = gz
+	}
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), accessLogMaxLineSize)
+	for scanner.Scan() {
+		entry := parse(scanner.Text())
+		if entry == nil {
+			continue
+		}
+		d.StreamListItem(ctx, entry)
+
+		// Context may get cancelled due to manual cancellation or if the limit has been reached
+		if d.RowsRemaining(ctx) == 0 {
+			return true, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		plugin.Logger(ctx).Error("streamAccessLogFile", "read_error", err, "bucket", bucket, "key", key)
+		return false, err
+	}
+
+	return false, nil
+}
+
+// splitAccessLogLine splits a space delimited log line into fields. Fields
+// enclosed in double quotes may contain spaces and backslash escaped quotes.
+func splitAccessLogLine(line string) []string {
+	var fields []string
+	var field strings.Builder
+	inField, inQuotes, escaped := false, false, false
+
+	for _, r := range line {
+		switch {
+		case escaped:
+			field.WriteRune(r)
+			escaped = false
+		case inQuotes && r == '\\':
+			escaped = true
+		case r == '"':
+			inQuotes = !inQuotes
+			inField = true
+		case r == ' ' && !inQuotes:
+			if inField {
+				fields = append(fields, field.String())
+				field.Reset()
+				inField = false
+			}
+		default:
+			field.WriteRune(r)
+			inField = true
+		}
+	}
+	if inField {
+		fields = append(fields, field.String())
+	}
+	return fields
+}
+
+// accessLogFields gives safe, typed access to the fields of a log line. Access
+// logs use "-" for values that are not available, which are returned as nil.
+type accessLogFields []string
+
+func (f accessLogFields) raw(i int) string {
+	if i < 0 || i >= len(f) {
+		return "-"
+	}
+	return f[i]
+}
+
+func (f accessLogFields) String(i int) *string {
+	v := f.raw(i)
+	if v == "-" || v == "" {
+		return nil
+	}
+	return aws.String(v)
+}
+
+func (f accessLogFields) Int(i int) *int64 {
+	v, err := strconv.ParseInt(f.raw(i), 10, 64)
+	if err != nil {
+		return nil
+	}
+	return aws.Int64(v)
+}
+
+func (f accessLogFields) Float(i int) *float64 {
+	v, err := strconv.ParseFloat(f.raw(i), 64)
+	if err != nil {
+		return nil
+	}
+	return aws.Float64(v)
+}
+
+func (f accessLogFields) Time(i int, layout string) *time.Time {
+	v, err := time.Parse(layout, f.raw(i))
+	if err != nil {
+		return nil
+	}
+	return aws.Time(v)
+}
+
+func (f accessLogFields) List(i int, sep string) []string {
+	v := f.String(i)
+	if v == nil {
+		return nil
+	}
+	return strings.Split(*v, sep)
+}
+
+// splitHostPort splits an "ip:port" field. IPv6 addresses contain colons, so
+// the port is taken from after the last one.
+func splitHostPort(v *string) (*string, *int64) {
+	if v == nil {
+		return nil, nil
+	}
+	i := strings.LastIndex(*v, ":")
+	if i < 0 {
+		return v, nil
+	}
+	host := strings.Trim((*v)[:i], "[]")
+	port, err := strconv.ParseInt((*v)[i+1:], 10, 64)
+	if err != nil {
+		return aws.String(host), nil
+	}
+	return aws.String(host), aws.Int64(port)
+}
@@ -0,0 +1,101 @@
+package aws
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+func TestSplitAccessLogLine(t *testing.T) {
+	line := `https 2018-07-02T22:23:00.186641Z app/my-loadbalancer/50dc6c495c0c9188 "GET http://www.example.com:80/ HTTP/1.1" "say \"hi\"" - ""`
+	expected := []string{
+		"https",
+		"2018-07-02T22:23:00.186641Z",
+		"app/my-loadbalancer/50dc6c495c0c9188",
+		"GET http://www.example.com:80/ HTTP/1.1",
+		`say "hi"`,
+		"-",
+		"",
+	}
+	fields := splitAccessLogLine(line)
+	if !reflect.DeepEqual(fields, expected) {
+		t.Errorf("splitAccessLogLine() = %q, expected %q", fields, expected)
+	}
+}
+
+func TestParseAlbAccessLogLine(t *testing.T) {
+	line := `https 2018-07-02T22:23:00.186641Z app/my-loadbalancer/50dc6c495c0c9188 192.168.131.39:2817 10.0.0.1:80 0.086 0.048 0.037 200 200 0 57 "GET https://www.example.com:443/ HTTP/1.1" "curl/7.46.0" ECDHE-RSA-AES128-GCM-SHA256 TLSv1.2 arn:aws:elasticloadbalancing:us-east-2:123456789012:targetgroup/my-targets/73e2d6bc24d8a067 "Root=1-58337281-1d84f3d73c47ec4e58577259" "www.example.com" "arn:aws:acm:us-east-2:123456789012:certificate/12345678-1234-1234-1234-123456789012" 1 2018-07-02T22:22:48.364000Z "authenticate,forward" "-" "-" "10.0.0.1:80" "200" "-" "-" TID_1234abcd5678ef90`
+	entry := parseAlbAccessLogLine("key.log.gz", line).(*albAccessLogEntry)
+
+	if aws.ToString(entry.ClientIp) != "192.168.131.39" || aws.ToInt64(entry.ClientPort) != 2817 {
+		t.Errorf("unexpected client %v:%v", aws.ToString(entry.ClientIp), aws.ToInt64(entry.ClientPort))
+	}
+	if aws.ToString(entry.RequestVerb) != "GET" || aws.ToString(entry.RequestUrl) != "https://www.example.com:443/" || aws.ToString(entry.RequestProtocol) != "HTTP/1.1" {
+		t.Errorf("unexpected request %v %v %v", aws.ToString(entry.RequestVerb), aws.ToString(entry.RequestUrl), aws.ToString(entry.RequestProtocol))
+	}
+	if aws.ToInt64(entry.ElbStatusCode) != 200 || aws.ToFloat64(entry.TargetProcessingTime) != 0.048 {
+		t.Errorf("unexpected status %v or target processing time %v", aws.ToInt64(entry.ElbStatusCode), aws.ToFloat64(entry.TargetProcessingTime))
+	}
+	if !reflect.DeepEqual(entry.ActionsExecuted, []string{"authenticate", "forward"}) {
+		t.Errorf("unexpected actions executed %q", entry.ActionsExecuted)
+	}
+	if entry.RedirectUrl != nil || entry.Classification != nil {
+		t.Errorf("expected unavailable values to be nil")
+	}
+	expectedTime := time.Date(2018, 7, 2, 22, 23, 0, 186641000, time.UTC)
+	if entry.Timestamp == nil || !entry.Timestamp.Equal(expectedTime) {
+		t.Errorf("unexpected timestamp %v", entry.Timestamp)
+	}
+	if aws.ToString(entry.ConnTraceId) != "TID_1234abcd5678ef90" {
+		t.Errorf("unexpected conn trace id %v", aws.ToString(entry.ConnTraceId))
+	}
+}
+
+func TestParseCloudFrontAccessLogLine(t *testing.T) {
+	fields := []string{"date", "time", "x-edge-location", "sc-bytes", "c-ip", "cs-method", "cs(Host)", "cs-uri-stem", "sc-status", "cs(Referer)", "cs(User-Agent)"}
+	line := "2019-12-04\t21:02:31\tLAX1\t392\t192.0.2.100\tGET\td111111abcdef8.cloudfront.net\t/index.html\t200\t-\tMozilla/5.0%20(Windows%20NT%2010.0)"
+	entry := parseCloudFrontAccessLogLine("key.gz", fields, line).(*cloudFrontAccessLogEntry)
+
+	expectedTime := time.Date(2019, 12, 4, 21, 2, 31, 0, time.UTC)
+	if entry.Timestamp == nil || !entry.Timestamp.Equal(expectedTime) {
+		t.Errorf("unexpected timestamp %v", entry.Timestamp)
+	}
+	if aws.ToInt64(entry.Status) != 200 || aws.ToInt64(entry.Bytes) != 392 {
+		t.Errorf("unexpected status %v or bytes %v", aws.ToInt64(entry.Status), aws.ToInt64(entry.Bytes))
+	}
+	if aws.ToString(entry.UserAgent) != "Mozilla/5.0 (Windows NT 10.0)" {
+		t.Errorf("unexpected user agent %v", aws.ToString(entry.UserAgent))
+	}
+	if entry.Referrer != nil || entry.RangeEnd != nil {
+		t.Errorf("expected unavailable and missing values to be nil")
+	}
+}
+
+func TestCloudFrontAccessLogPrefixes(t *testing.T) {
+	start := time.Date(2019, 12, 4, 22, 30, 0, 0, time.UTC)
+	end := time.Date(2019, 12, 4, 23, 30, 0, 0, time.UTC)
+	prefixes := cloudFrontAccessLogPrefixes("logs/", "EMLARXS9EXAMPLE", start, end)
+	expected := []string{
+		"logs/EMLARXS9EXAMPLE.2019-12-04-",
+		"logs/EMLARXS9EXAMPLE.2019-12-05-",
+	}
+	if !reflect.DeepEqual(prefixes, expected) {
+		t.Errorf("cloudFrontAccessLogPrefixes() = %q, expected %q", prefixes, expected)
+	}
+
+	end = time.Date(2019, 12, 4, 21, 30, 0, 0, time.UTC)
+	prefixes = cloudFrontAccessLogPrefixes("", "EMLARXS9EXAMPLE", start.Add(-2*time.Hour), end)
+	expected = []string{"EMLARXS9EXAMPLE.2019-12-04-"}
+	if !reflect.DeepEqual(prefixes, expected) {
+		t.Errorf("cloudFrontAccessLogPrefixes() = %q, expected %q", prefixes, expected)
+	}
+}
+
+func TestElbAccessLogLoadBalancerId(t *testing.T) {
+	id := elbAccessLogLoadBalancerId("loadbalancer/net/my-network-lb/c6e77e28c25b2234")
+	if id != "net.my-network-lb.c6e77e28c25b2234" {
+		t.Errorf("unexpected load balancer id %v", id)
+	}
+}
@@ -3,7 +3,9 @@ package aws
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -107,6 +109,19 @@ type CWMetricRow struct {
 	Unit *string
 }
 
+// Key columns of the per-resource metric tables, to query the metric data of a
+// time range other than the default for the granularity of the table
+func cwMetricKeyColumns() []*plugin.KeyColumn {
+	return []*plugin.KeyColumn{
+		{
+			Name:       "timestamp",
+			Operators:  []string{">", ">=", "=", "<", "<="},
+			Require:    plugin.Optional,
+			CacheMatch: "exact",
+		},
+	}
+}
+
 func getCWStartDateForGranularity(granularity string) time.Time {
 	switch strings.ToUpper(granularity) {
 	case "DAILY":
@@ -133,35 +148,41 @@ func getCWPeriodForGranularity(granularity string) int32 {
 	return 300
 }
 
+// Statistics returned by the per-resource metric tables, in the order of the
+// metric data queries built for each resource
+var cwMetricRowStatistics = []types.Statistic{
+	types.StatisticAverage,
+	types.StatisticSampleCount,
+	types.StatisticSum,
+	types.StatisticMinimum,
+	types.StatisticMaximum,
+}
+
 func listCWMetricStatistics(ctx context.Context, d *plugin.QueryData, granularity string, namespace string, metricName string, dimensionName string, dimensionValue string) (*cloudwatch.GetMetricStatisticsOutput, error) {
-	// Create Session
-	svc, err := CloudWatchClient(ctx, d)
-	if err != nil {
-		plugin.Logger(ctx).Error("listCWMetricStatistics", "connection_error", err)
-		return nil, err
+	startTime, endTime := getCWTimeRange(d, getCWStartDateForGranularity(granularity))
+
+	// Data older than a retention tier is only available at a coarser period
+	period := max(getCWPeriodForGranularity(granularity), getCWMinPeriodForStartTime(startTime))
+
+	// The time range is widened to whole minutes, so that the metric data of
+	// all resources in a query is fetched with the same time range, and can be
+	// batched. Postgres filters out data points outside of the timestamp quals.
+	startTime = startTime.Truncate(time.Minute)
+	if !endTime.Equal(endTime.Truncate(time.Minute)) {
+		endTime = endTime.Truncate(time.Minute).Add(time.Minute)
 	}
 
-	endTime := time.Now()
-	startTime := getCWStartDateForGranularity(granularity)
-	period := getCWPeriodForGranularity(granularity)
+	// A single timestamp is the start of a period
+	if !endTime.After(startTime) {
+		endTime = startTime.Add(time.Duration(period) * time.Second)
+	}
 
-	params := &cloudwatch.GetMetricStatisticsInput{
+	metric := &types.Metric{
 		Namespace:  aws.String(namespace),
 		MetricName: aws.String(metricName),
-		StartTime:  aws.Time(startTime),
-		EndTime:    aws.Time(endTime),
-		Period:     aws.Int32(period),
-		Statistics: []types.Statistic{
-			types.StatisticAverage,
-			types.StatisticSampleCount,
-			types.StatisticSum,
-			types.StatisticMinimum,
-			types.StatisticMaximum,
-		},
 	}
-
 	if dimensionName != "" && dimensionValue != "" {
-		params.Dimensions = []types.Dimension{
+		metric.Dimensions = []types.Dimension{
 			{
 				Name:  aws.String(dimensionName),
 				Value: aws.String(dimensionValue),
@@ -169,26 +190,75 @@ func listCWMetricStatistics(ctx context.Context, d *plugin.QueryData, granularit
 		}
 	}
 
-	stats, err := svc.GetMetricStatistics(ctx, params)
+	queries := make([]types.MetricDataQuery, 0, len(cwMetricRowStatistics))
+	for _, statistic := range cwMetricRowStatistics {
+		queries = append(queries, types.MetricDataQuery{
+			MetricStat: &types.MetricStat{
+				Metric: metric,
+				Period: aws.Int32(period),
+				Stat:   aws.String(string(statistic)),
+			},
+		})
+	}
+
+	results, err := cwMetricDataBatcher.fetch(ctx, d, queries, startTime, endTime)
 	if err != nil {
 		plugin.Logger(ctx).Error("listCWMetricStatistics", "api_error", err)
 		return nil, err
 	}
 
-	for _, datapoint := range stats.Datapoints {
-		d.StreamLeafListItem(ctx, &CWMetricRow{
-			DimensionValue: aws.String(dimensionValue),
-			DimensionName:  aws.String(dimensionName),
-			Namespace:      aws.String(namespace),
-			MetricName:     aws.String(metricName),
-			Average:        datapoint.Average,
-			Maximum:        datapoint.Maximum,
-			Minimum:        datapoint.Minimum,
-			Timestamp:      datapoint.Timestamp,
-			SampleCount:    datapoint.SampleCount,
-			Sum:            datapoint.Sum,
-			Unit:           aws.String(fmt.Sprint(datapoint.Unit)),
-		})
+	// Each statistic is returned as a separate series, which are merged into a
+	// row per timestamp
+	rows := map[time.Time]*CWMetricRow{}
+	var timestamps []time.Time
+	for i, statistic := range cwMetricRowStatistics {
+		result := results[i]
+		if result == nil {
+			continue
+		}
+		for j, timestamp := range result.Timestamps {
+			row, ok := rows[timestamp]
+			if !ok {
+				row = &CWMetricRow{
+					DimensionValue: aws.String(dimensionValue),
+					DimensionName:  aws.String(dimensionName),
+					Namespace:      aws.String(namespace),
+					MetricName:     aws.String(metricName),
+					Timestamp:      aws.Time(timestamp),
+				}
+				rows[timestamp] = row
+				timestamps = append(timestamps, timestamp)
+			}
+			value := aws.Float64(result.Values[j])
+			switch statistic {
+			case types.StatisticAverage:
+				row.Average = value
+			case types.StatisticSampleCount:
+				row.SampleCount = value
+			case types.StatisticSum:
+				row.Sum = value
+			case types.StatisticMinimum:
+				row.Minimum = value
+			case types.StatisticMaximum:
+				row.Maximum = value
+			}
+		}
+	}
+	sort.Slice(timestamps, func(i, j int) bool { return timestamps[i].Before(timestamps[j]) })
+
+	if len(timestamps) > 0 {
+		unit, err := cwMetricUnits.get(ctx, d, metric, timestamps[0], period)
+		if err != nil {
+			plugin.Logger(ctx).Error("listCWMetricStatistics", "unit_error", err)
+			return nil, err
+		}
+		for _, row := range rows {
+			row.Unit = unit
+		}
+	}
+
+	for _, timestamp := range timestamps {
+		d.StreamLeafListItem(ctx, rows[timestamp])
 
 		if d.RowsRemaining(ctx) == 0 {
 			return nil, nil
@@ -197,3 +267,324 @@ func listCWMetricStatistics(ctx context.Context, d *plugin.QueryData, granularit
 
 	return nil, nil
 }
+
+// getCWTimeRange returns the time range of a query from its timestamp quals.
+// Without a lower bound, the range starts at defaultStartTime, and without an
+// upper bound, it ends now.
+func getCWTimeRange(d *plugin.QueryData, defaultStartTime time.Time) (time.Time, time.Time) {
+	var startTime, endTime time.Time
+	if d.Quals["timestamp"] != nil {
+		for _, q := range d.Quals["timestamp"].Quals {
+			timestamp := q.Value.GetTimestampValue().AsTime()
+			switch q.Operator {
+			case "=":
+				startTime, endTime = timestamp, timestamp
+			case ">=", ">":
+				startTime = timestamp
+			case "<", "<=":
+				endTime = timestamp
+			}
+		}
+	}
+	if startTime.IsZero() {
+		startTime = defaultStartTime
+	}
+	if endTime.IsZero() {
+		endTime = time.Now()
+	}
+	return startTime, endTime
+}
+
+// GetMetricData accepts at most 500 metric data queries per request
+const cwMaxMetricDataQueries = 500
+
+// getCWMinPeriodForStartTime returns the smallest period CloudWatch can return
+// data for, given the start time of a query. CloudWatch rolls data up as it
+// ages, keeping 1 minute data points for 15 days, 5 minute data points for
+// 63 days and 1 hour data points for 455 days.
+func getCWMinPeriodForStartTime(startTime time.Time) int32 {
+	age := time.Since(startTime)
+	switch {
+	case age <= 15*24*time.Hour:
+		return 60
+	case age <= 63*24*time.Hour:
+		return 300
+	}
+	return 3600
+}
+
+// getCWMetricData runs the metric data queries, splitting them into requests
+// of at most 500 queries and following the pagination of each. The data points
+// of each query are returned in a single result, keyed by query id.
+func getCWMetricData(ctx context.Context, d *plugin.QueryData, queries []types.MetricDataQuery, startTime time.Time, endTime time.Time) (map[string]*types.MetricDataResult, error) {
+	svc, err := CloudWatchClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("getCWMetricData", "connection_error", err)
+		return nil, err
+	}
+
+	results := map[string]*types.MetricDataResult{}
+	for i := 0; i < len(queries); i += cwMaxMetricDataQueries {
+		batch := queries[i:min(i+cwMaxMetricDataQueries, len(queries))]
+
+		params := &cloudwatch.GetMetricDataInput{
+			MetricDataQueries: batch,
+			StartTime:         aws.Time(startTime),
+			EndTime:           aws.Time(endTime),
+			ScanBy:            types.ScanByTimestampAscending,
+		}
+
+		paginator := cloudwatch.NewGetMetricDataPaginator(svc, params, func(o *cloudwatch.GetMetricDataPaginatorOptions) {
+			o.StopOnDuplicateToken = true
+		})
+		for paginator.HasMorePages() {
+			// apply rate limiting
+			d.WaitForListRateLimit(ctx)
+
+			output, err := paginator.NextPage(ctx)
+			if err != nil {
+				plugin.Logger(ctx).Error("getCWMetricData", "api_error", err)
+				return nil, err
+			}
+
+			for _, result := range output.MetricDataResults {
+				id := aws.ToString(result.Id)
+				if existing, ok := results[id]; ok {
+					existing.Timestamps = append(existing.Timestamps, result.Timestamps...)
+					existing.Values = append(existing.Values, result.Values...)
+					existing.StatusCode = result.StatusCode
+					existing.Messages = append(existing.Messages, result.Messages...)
+					continue
+				}
+				result := result
+				results[id] = &result
+			}
+		}
+	}
+
+	return results, nil
+}
+
+// Time to wait for more metric data queries before a batch is fetched. Child
+// list calls for the parent rows of a query run concurrently, so their
+// queries arrive within a short time of each other.
+const cwMetricDataBatchWait = 100 * time.Millisecond
+
+var cwMetricDataBatcher = newCWMetricDataBatcher(getCWMetricData)
+
+// cwMetricDataBatcherImpl combines the metric data queries of concurrent calls
+// into GetMetricData requests of up to 500 queries. This turns a request per
+// resource into a request per 100 resources for the per-resource metric
+// tables, which fetch 5 statistics per resource.
+type cwMetricDataBatcherImpl struct {
+	mu      sync.Mutex
+	batches map[string]*cwMetricDataBatch
+
+	// getMetricData runs the queries of a batch
+	getMetricData func(ctx context.Context, d *plugin.QueryData, queries []types.MetricDataQuery, startTime time.Time, endTime time.Time) (map[string]*types.MetricDataResult, error)
+}
+
+type cwMetricDataBatch struct {
+	key       string
+	ctx       context.Context
+	cancel    context.CancelFunc
+	d         *plugin.QueryData
+	startTime time.Time
+	endTime   time.Time
+	queries   []types.MetricDataQuery
+	waiters   int
+	fetchOnce sync.Once
+	done      chan struct{}
+	results   map[string]*types.MetricDataResult
+	err       error
+}
+
+func newCWMetricDataBatcher(getMetricData func(ctx context.Context, d *plugin.QueryData, queries []types.MetricDataQuery, startTime time.Time, endTime time.Time) (map[string]*types.MetricDataResult, error)) *cwMetricDataBatcherImpl {
+	return &cwMetricDataBatcherImpl{
+		batches:       map[string]*cwMetricDataBatch{},
+		getMetricData: getMetricData,
+	}
+}
+
+// fetch adds the queries to the open batch for the query, region and time
+// range, and waits for the batch to be fetched. The results are returned in
+// the order of the queries, with nil for queries without data.
+func (b *cwMetricDataBatcherImpl) fetch(ctx context.Context, d *plugin.QueryData, queries []types.MetricDataQuery, startTime time.Time, endTime time.Time) ([]*types.MetricDataResult, error) {
+	// Batches are never shared between queries, so cancelling one query can't
+	// fail another
+	key := fmt.Sprintf("%p/%s/%d/%d", d.QueryContext, d.EqualsQualString(matrixKeyRegion), startTime.Unix(), endTime.Unix())
+
+	b.mu.Lock()
+	batch := b.batches[key]
+	if batch != nil && len(batch.queries)+len(queries) > cwMaxMetricDataQueries {
+		delete(b.batches, key)
+		go b.fetchBatch(batch)
+		batch = nil
+	}
+	if batch == nil {
+		// The batch is fetched with a context detached from the call that
+		// opened it, as other calls share its results. It is only cancelled
+		// once every call waiting for it has been cancelled. The query data is
+		// only used for the client and rate limiting, which are the same for
+		// every call sharing the batch.
+		batchCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		batch = &cwMetricDataBatch{
+			key:       key,
+			ctx:       batchCtx,
+			cancel:    cancel,
+			d:         d,
+			startTime: startTime,
+			endTime:   endTime,
+			done:      make(chan struct{}),
+		}
+		b.batches[key] = batch
+		time.AfterFunc(cwMetricDataBatchWait, func() {
+			b.mu.Lock()
+			if b.batches[key] == batch {
+				delete(b.batches, key)
+			}
+			b.mu.Unlock()
+			b.fetchBatch(batch)
+		})
+	}
+
+	ids := make([]string, len(queries))
+	for i, query := range queries {
+		ids[i] = fmt.Sprintf("q%d", len(batch.queries))
+		query.Id = aws.String(ids[i])
+		batch.queries = append(batch.queries, query)
+	}
+	batch.waiters++
+	if len(batch.queries) == cwMaxMetricDataQueries {
+		delete(b.batches, key)
+		go b.fetchBatch(batch)
+	}
+	b.mu.Unlock()
+
+	select {
+	case <-batch.done:
+	case <-ctx.Done():
+		b.mu.Lock()
+		batch.waiters--
+		if batch.waiters == 0 {
+			// No call is left to use the results
+			if b.batches[key] == batch {
+				delete(b.batches, key)
+			}
+			batch.cancel()
+		}
+		b.mu.Unlock()
+		return nil, ctx.Err()
+	}
+	if batch.err != nil {
+		return nil, batch.err
+	}
+
+	results := make([]*types.MetricDataResult, len(ids))
+	for i, id := range ids {
+		results[i] = batch.results[id]
+	}
+	return results, nil
+}
+
+func (b *cwMetricDataBatcherImpl) fetchBatch(batch *cwMetricDataBatch) {
+	batch.fetchOnce.Do(func() {
+		defer close(batch.done)
+		defer batch.cancel()
+		batch.results, batch.err = b.getMetricData(batch.ctx, batch.d, batch.queries, batch.startTime, batch.endTime)
+	})
+}
+
+// GetMetricData does not return the unit of data points, so the unit of each
+// metric is looked up once with GetMetricStatistics, and cached by namespace
+// and metric name.
+var cwMetricUnits = &cwMetricUnitCache{entries: map[string]*cwMetricUnitEntry{}, getMetricUnit: getCWMetricUnit}
+
+type cwMetricUnitCache struct {
+	mu      sync.Mutex
+	entries map[string]*cwMetricUnitEntry
+
+	// getMetricUnit looks up the unit of a metric
+	getMetricUnit func(ctx context.Context, d *plugin.QueryData, metric *types.Metric, timestamp time.Time, period int32) (*string, error)
+}
+
+// cwMetricUnitEntry is the cached unit of a single metric. Its lock is held
+// during the lookup, so lookups of other metrics don't wait for it.
+type cwMetricUnitEntry struct {
+	mu   sync.Mutex
+	unit *string
+
+	// noUnit is set when the last lookup, made by noUnitQuery, found no unit.
+	// The unit is not looked up again for the rest of that query, but a later
+	// query tries again.
+	noUnit      bool
+	noUnitQuery string
+}
+
+// get returns the unit of a metric, given the start of a period the metric has
+// data points for. Calls for the same metric wait for the first lookup, so a
+// query over many resources only looks the unit up once.
+func (c *cwMetricUnitCache) get(ctx context.Context, d *plugin.QueryData, metric *types.Metric, timestamp time.Time, period int32) (*string, error) {
+	key := aws.ToString(metric.Namespace) + "/" + aws.ToString(metric.MetricName)
+
+	c.mu.Lock()
+	entry := c.entries[key]
+	if entry == nil {
+		entry = &cwMetricUnitEntry{}
+		c.entries[key] = entry
+	}
+	c.mu.Unlock()
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+	if entry.unit != nil {
+		return entry.unit, nil
+	}
+	var query string
+	if d != nil {
+		query = fmt.Sprintf("%p", d.QueryContext)
+	}
+	if entry.noUnit && entry.noUnitQuery == query {
+		return nil, nil
+	}
+
+	unit, err := c.getMetricUnit(ctx, d, metric, timestamp, period)
+	if err != nil {
+		return nil, err
+	}
+	entry.unit, entry.noUnit, entry.noUnitQuery = unit, unit == nil, query
+	return unit, nil
+}
+
+// getCWMetricUnit returns the unit of the data point of a metric for a single
+// period
+func getCWMetricUnit(ctx context.Context, d *plugin.QueryData, metric *types.Metric, timestamp time.Time, period int32) (*string, error) {
+	svc, err := CloudWatchClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("getCWMetricUnit", "connection_error", err)
+		return nil, err
+	}
+
+	params := &cloudwatch.GetMetricStatisticsInput{
+		Namespace:  metric.Namespace,
+		MetricName: metric.MetricName,
+		Dimensions: metric.Dimensions,
+		StartTime:  aws.Time(timestamp),
+		EndTime:    aws.Time(timestamp.Add(time.Duration(period) * time.Second)),
+		Period:     aws.Int32(period),
+		Statistics: []types.Statistic{types.StatisticSampleCount},
+	}
+
+	output, err := svc.GetMetricStatistics(ctx, params)
+	if err != nil {
+		plugin.Logger(ctx).Error("getCWMetricUnit", "api_error", err)
+		return nil, err
+	}
+
+	for _, datapoint := range output.Datapoints {
+		if datapoint.Unit != "" {
+			return aws.String(string(datapoint.Unit)), nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,329 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+// cwMetricDataStub records the batches it is asked to fetch, and returns a
+// single data point per query, with the query's expression as its label
+type cwMetricDataStub struct {
+	mu      sync.Mutex
+	batches [][]types.MetricDataQuery
+	ctxs    []context.Context
+	err     error
+	wait    chan struct{}
+}
+
+func (s *cwMetricDataStub) getMetricData(ctx context.Context, _ *plugin.QueryData, queries []types.MetricDataQuery, _ time.Time, _ time.Time) (map[string]*types.MetricDataResult, error) {
+	s.mu.Lock()
+	s.batches = append(s.batches, queries)
+	s.ctxs = append(s.ctxs, ctx)
+	s.mu.Unlock()
+
+	if s.wait != nil {
+		<-s.wait
+	}
+	if s.err != nil {
+		return nil, s.err
+	}
+	results := map[string]*types.MetricDataResult{}
+	for _, query := range queries {
+		results[aws.ToString(query.Id)] = &types.MetricDataResult{
+			Id:    query.Id,
+			Label: query.Expression,
+		}
+	}
+	return results, nil
+}
+
+func testCWMetricDataQueries(caller int, count int) []types.MetricDataQuery {
+	queries := make([]types.MetricDataQuery, count)
+	for i := range queries {
+		queries[i] = types.MetricDataQuery{Expression: aws.String(fmt.Sprintf("%d/%d", caller, i))}
+	}
+	return queries
+}
+
+func testCWQueryData() *plugin.QueryData {
+	return &plugin.QueryData{QueryContext: &plugin.QueryContext{}}
+}
+
+// fetchConcurrently runs a fetch per caller at the same time, with the number
+// of queries given for each caller
+func fetchConcurrently(b *cwMetricDataBatcherImpl, d *plugin.QueryData, counts []int) ([][]*types.MetricDataResult, []error) {
+	start, end := time.Unix(0, 0), time.Unix(3600, 0)
+	results := make([][]*types.MetricDataResult, len(counts))
+	errs := make([]error, len(counts))
+	var wg sync.WaitGroup
+	for caller, count := range counts {
+		wg.Add(1)
+		go func(caller int, count int) {
+			defer wg.Done()
+			results[caller], errs[caller] = b.fetch(context.Background(), d, testCWMetricDataQueries(caller, count), start, end)
+		}(caller, count)
+	}
+	wg.Wait()
+	return results, errs
+}
+
+func TestCWMetricDataBatcherMapsResultsToCallers(t *testing.T) {
+	stub := &cwMetricDataStub{}
+	b := newCWMetricDataBatcher(stub.getMetricData)
+
+	results, errs := fetchConcurrently(b, testCWQueryData(), []int{5, 5, 5})
+
+	if len(stub.batches) != 1 || len(stub.batches[0]) != 15 {
+		t.Fatalf("expected a single batch of 15 queries, got %d batches", len(stub.batches))
+	}
+	for caller := range results {
+		if errs[caller] != nil {
+			t.Fatalf("caller %d: unexpected error %v", caller, errs[caller])
+		}
+		for i, result := range results[caller] {
+			expected := fmt.Sprintf("%d/%d", caller, i)
+			if result == nil || aws.ToString(result.Label) != expected {
+				t.Errorf("caller %d: result %d is not for query %s", caller, i, expected)
+			}
+		}
+	}
+}
+
+func TestCWMetricDataBatcherSplitsBatches(t *testing.T) {
+	stub := &cwMetricDataStub{}
+	b := newCWMetricDataBatcher(stub.getMetricData)
+
+	_, errs := fetchConcurrently(b, testCWQueryData(), []int{200, 200, 200, 100})
+	for _, err := range errs {
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	}
+
+	total := 0
+	for _, batch := range stub.batches {
+		if len(batch) > cwMaxMetricDataQueries {
+			t.Errorf("batch of %d queries exceeds %d", len(batch), cwMaxMetricDataQueries)
+		}
+		total += len(batch)
+	}
+	if total != 700 || len(stub.batches) < 2 {
+		t.Errorf("expected 700 queries over at least 2 batches, got %d over %d", total, len(stub.batches))
+	}
+}
+
+func TestCWMetricDataBatcherFlushesFullBatch(t *testing.T) {
+	stub := &cwMetricDataStub{}
+	b := newCWMetricDataBatcher(stub.getMetricData)
+
+	// A full batch is fetched without waiting for the timer
+	started := time.Now()
+	_, errs := fetchConcurrently(b, testCWQueryData(), []int{cwMaxMetricDataQueries})
+	if errs[0] != nil {
+		t.Fatalf("unexpected error %v", errs[0])
+	}
+	if elapsed := time.Since(started); elapsed >= cwMetricDataBatchWait {
+		t.Errorf("full batch took %v, expected it to be fetched immediately", elapsed)
+	}
+}
+
+func TestCWMetricDataBatcherFlushesOnTimer(t *testing.T) {
+	stub := &cwMetricDataStub{}
+	b := newCWMetricDataBatcher(stub.getMetricData)
+
+	started := time.Now()
+	results, errs := fetchConcurrently(b, testCWQueryData(), []int{5})
+	if errs[0] != nil || len(results[0]) != 5 {
+		t.Fatalf("unexpected results %v, error %v", results[0], errs[0])
+	}
+	if elapsed := time.Since(started); elapsed < cwMetricDataBatchWait {
+		t.Errorf("batch took %v, expected it to wait %v for more queries", elapsed, cwMetricDataBatchWait)
+	}
+}
+
+func TestCWMetricDataBatcherFansOutErrors(t *testing.T) {
+	stub := &cwMetricDataStub{err: errors.New("ThrottlingException")}
+	b := newCWMetricDataBatcher(stub.getMetricData)
+
+	_, errs := fetchConcurrently(b, testCWQueryData(), []int{5, 5})
+	for caller, err := range errs {
+		if err != stub.err {
+			t.Errorf("caller %d: expected the batch error, got %v", caller, err)
+		}
+	}
+}
+
+func TestCWMetricDataBatcherSeparatesQueries(t *testing.T) {
+	stub := &cwMetricDataStub{}
+	b := newCWMetricDataBatcher(stub.getMetricData)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fetchConcurrently(b, testCWQueryData(), []int{5})
+		}()
+	}
+	wg.Wait()
+
+	if len(stub.batches) != 2 {
+		t.Errorf("expected a batch per query, got %d", len(stub.batches))
+	}
+}
+
+func TestCWMetricDataBatcherOutlivesCancelledCaller(t *testing.T) {
+	stub := &cwMetricDataStub{wait: make(chan struct{})}
+	b := newCWMetricDataBatcher(stub.getMetricData)
+	d := testCWQueryData()
+	start, end := time.Unix(0, 0), time.Unix(3600, 0)
+
+	// The first caller opens the batch, and is cancelled while it is fetched
+	firstCtx, cancelFirst := context.WithCancel(context.Background())
+	firstErr := make(chan error)
+	go func() {
+		_, err := b.fetch(firstCtx, d, testCWMetricDataQueries(0, 5), start, end)
+		firstErr <- err
+	}()
+	secondResults := make(chan []*types.MetricDataResult)
+	secondErr := make(chan error)
+	go func() {
+		results, err := b.fetch(context.Background(), d, testCWMetricDataQueries(1, 5), start, end)
+		secondResults <- results
+		secondErr <- err
+	}()
+
+	for {
+		stub.mu.Lock()
+		started := len(stub.batches) > 0
+		stub.mu.Unlock()
+		if started {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancelFirst()
+	if err := <-firstErr; err != context.Canceled {
+		t.Errorf("expected the cancelled caller to get %v, got %v", context.Canceled, err)
+	}
+	if stub.ctxs[0].Err() != nil {
+		t.Errorf("batch was cancelled with the caller that opened it")
+	}
+
+	close(stub.wait)
+	results := <-secondResults
+	if err := <-secondErr; err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(results) != 5 || aws.ToString(results[0].Label) != "1/0" {
+		t.Errorf("unexpected results for the remaining caller")
+	}
+}
+
+func TestCWMetricDataBatcherCancelledWithLastCaller(t *testing.T) {
+	stub := &cwMetricDataStub{wait: make(chan struct{})}
+	defer close(stub.wait)
+	b := newCWMetricDataBatcher(stub.getMetricData)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error)
+	go func() {
+		_, err := b.fetch(ctx, testCWQueryData(), testCWMetricDataQueries(0, cwMaxMetricDataQueries), time.Unix(0, 0), time.Unix(3600, 0))
+		errs <- err
+	}()
+
+	for {
+		stub.mu.Lock()
+		started := len(stub.batches) > 0
+		stub.mu.Unlock()
+		if started {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+	<-errs
+	if stub.ctxs[0].Err() == nil {
+		t.Errorf("expected the batch to be cancelled once no caller is waiting for it")
+	}
+}
+
+func TestCWMetricUnitCache(t *testing.T) {
+	var mu sync.Mutex
+	lookups := 0
+	release := make(chan struct{})
+	cache := &cwMetricUnitCache{
+		entries: map[string]*cwMetricUnitEntry{},
+		getMetricUnit: func(_ context.Context, _ *plugin.QueryData, metric *types.Metric, _ time.Time, _ int32) (*string, error) {
+			if aws.ToString(metric.MetricName) == "Slow" {
+				<-release
+				return aws.String("Count"), nil
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			lookups++
+			if aws.ToString(metric.MetricName) == "NoData" {
+				return nil, nil
+			}
+			return aws.String("Percent"), nil
+		},
+	}
+	metric := &types.Metric{Namespace: aws.String("AWS/EC2"), MetricName: aws.String("CPUUtilization")}
+
+	// A slow lookup doesn't block lookups of other metrics
+	slow := &types.Metric{Namespace: aws.String("AWS/EC2"), MetricName: aws.String("Slow")}
+	slowDone := make(chan struct{})
+	go func() {
+		defer close(slowDone)
+		if unit, err := cache.get(context.Background(), nil, slow, time.Unix(0, 0), 300); err != nil || aws.ToString(unit) != "Count" {
+			t.Errorf("unexpected unit %v, error %v", aws.ToString(unit), err)
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unit, err := cache.get(context.Background(), nil, metric, time.Unix(0, 0), 300)
+			if err != nil || aws.ToString(unit) != "Percent" {
+				t.Errorf("unexpected unit %v, error %v", aws.ToString(unit), err)
+			}
+		}()
+	}
+	wg.Wait()
+	if lookups != 1 {
+		t.Errorf("expected a single lookup, got %d", lookups)
+	}
+
+	close(release)
+	<-slowDone
+
+	// Units that are not found are not looked up again in the same query
+	noData := &types.Metric{Namespace: aws.String("AWS/EC2"), MetricName: aws.String("NoData")}
+	for i := 0; i < 2; i++ {
+		if unit, _ := cache.get(context.Background(), nil, noData, time.Unix(0, 0), 300); unit != nil {
+			t.Errorf("expected no unit, got %v", aws.ToString(unit))
+		}
+	}
+	if lookups != 2 {
+		t.Errorf("expected metrics without a unit to be looked up once per query, got %d lookups", lookups)
+	}
+
+	// A later query looks the unit up again
+	d := &plugin.QueryData{QueryContext: &plugin.QueryContext{}}
+	if unit, _ := cache.get(context.Background(), d, noData, time.Unix(0, 0), 300); unit != nil {
+		t.Errorf("expected no unit, got %v", aws.ToString(unit))
+	}
+	if lookups != 3 {
+		t.Errorf("expected a later query to look the unit up again, got %d lookups", lookups)
+	}
+}
@@ -0,0 +1,41 @@
+package aws
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/computeoptimizer/types"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TRANSFORM FUNCTIONS
+
+func computeOptimizerTurbotTags(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	tags, ok := d.Value.([]types.Tag)
+	if !ok || len(tags) == 0 {
+		return nil, nil
+	}
+
+	// Mapping the resource tags inside turbotTags
+	turbotTagsMap := map[string]string{}
+	for _, tag := range tags {
+		turbotTagsMap[*tag.Key] = *tag.Value
+	}
+	return turbotTagsMap, nil
+}
+
+// computeOptimizerUnqualifiedArn removes the version or alias of a Lambda
+// function ARN, e.g. arn:aws:lambda:us-east-1:123456789012:function:name:$LATEST
+func computeOptimizerUnqualifiedArn(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	arn, ok := d.Value.(*string)
+	if !ok || arn == nil {
+		return nil, nil
+	}
+
+	parts := strings.Split(*arn, ":")
+	if len(parts) > 7 {
+		parts = parts[:7]
+	}
+	return strings.Join(parts, ":"), nil
+}
@@ -2,12 +2,15 @@ package aws
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/costexplorer"
 	"github.com/aws/aws-sdk-go-v2/service/costexplorer/types"
 	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/turbot/go-kit/helpers"
 
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
@@ -129,7 +132,10 @@ func costExplorerColumns(columns []*plugin.Column) []*plugin.Column {
 
 //// LIST FUNCTION
 
-func streamCostAndUsage(ctx context.Context, d *plugin.QueryData, params *costexplorer.GetCostAndUsageInput) (interface{}, error) {
+// streamCostAndUsage streams a row per time period and group. Cost Explorer
+// groups by at most two keys per request, so for each group definition in
+// extraGroupBy, a request is made per value of the group, filtered to the value.
+func streamCostAndUsage(ctx context.Context, d *plugin.QueryData, params *costexplorer.GetCostAndUsageInput, extraGroupBy ...types.GroupDefinition) (interface{}, error) {
 
 	// Create session
 	svc, err := CostExplorerClient(ctx, d)
@@ -137,20 +143,91 @@ func streamCostAndUsage(ctx context.Context, d *plugin.QueryData, params *costex
 		plugin.Logger(ctx).Error("streamCostAndUsage", "client_error", err)
 		return nil, err
 	}
+
+	groupBy := append(append([]types.GroupDefinition{}, params.GroupBy...), extraGroupBy...)
+	budget := &ceRequestBudget{remaining: ceMaxGroupValueRequests}
+	_, err = streamCostAndUsageGroups(ctx, d, svc, params, groupBy, extraGroupBy, nil, budget)
+	return nil, err
+}
+
+// Each Cost Explorer request is billed, so the requests made for the values of
+// group definitions past the first two are capped per query, i.e. at $1.
+const ceMaxGroupValueRequests = 100
+
+// ceRequestBudget counts down the requests a query may make for the values of
+// its group definitions
+type ceRequestBudget struct {
+	remaining int
+}
+
+// use reserves n requests, returning an error if they exceed the budget
+func (b *ceRequestBudget) use(n int, group types.GroupDefinition) error {
+	if n > b.remaining {
+		return fmt.Errorf("grouping by %s %s needs more than %d Cost Explorer requests, which are billed per request: add a filter to reduce the number of values", strings.ToLower(string(group.Type)), aws.ToString(group.Key), ceMaxGroupValueRequests)
+	}
+	b.remaining -= n
+	return nil
+}
+
+// streamCostAndUsageGroups streams the rows for each value of the first group
+// definition in extraGroupBy, or the rows of params if there are none. It
+// returns true once no more rows are required by the query.
+func streamCostAndUsageGroups(ctx context.Context, d *plugin.QueryData, svc *costexplorer.Client, params *costexplorer.GetCostAndUsageInput, groupBy []types.GroupDefinition, extraGroupBy []types.GroupDefinition, extraKeys []string, budget *ceRequestBudget) (bool, error) {
+	if len(extraGroupBy) == 0 {
+		return streamCostAndUsagePages(ctx, d, svc, params, groupBy, extraKeys)
+	}
+
+	group := extraGroupBy[0]
+	if err := budget.use(1, group); err != nil {
+		plugin.Logger(ctx).Error("streamCostAndUsageGroups", "request_limit_error", err)
+		return false, err
+	}
+	values, err := getCEGroupValues(ctx, svc, params, group)
+	if err != nil {
+		plugin.Logger(ctx).Error("streamCostAndUsageGroups", "api_error", err)
+		return false, err
+	}
+
+	// The cost and usage of each value of the last group definition is a
+	// request, so they are reserved before any is made. Other values are
+	// reserved by the lookup of the values of the next group definition.
+	if len(extraGroupBy) == 1 {
+		if err := budget.use(len(values), group); err != nil {
+			plugin.Logger(ctx).Error("streamCostAndUsageGroups", "request_limit_error", err)
+			return false, err
+		}
+	}
+
+	for _, value := range values {
+		groupParams := *params
+		groupParams.NextPageToken = nil
+		groupParams.Filter = ceGroupValueFilter(params.Filter, group, value)
+
+		keys := append(append([]string{}, extraKeys...), ceGroupKey(group, value))
+		done, err := streamCostAndUsageGroups(ctx, d, svc, &groupParams, groupBy, extraGroupBy[1:], keys, budget)
+		if err != nil || done {
+			return done, err
+		}
+	}
+
+	return false, nil
+}
+
+func streamCostAndUsagePages(ctx context.Context, d *plugin.QueryData, svc *costexplorer.Client, params *costexplorer.GetCostAndUsageInput, groupBy []types.GroupDefinition, extraKeys []string) (bool, error) {
 	// List call
 	for {
 		output, err := svc.GetCostAndUsage(ctx, params)
 		if err != nil {
 			plugin.Logger(ctx).Error("streamCostAndUsage", "api_error", err)
-			return nil, err
+			return false, err
 		}
 
 		// stream the results...
-		for _, row := range buildCEMetricRows(ctx, output, d.EqualsQuals) {
+		for _, row := range buildCEMetricRows(ctx, output, groupBy, extraKeys) {
 			d.StreamListItem(ctx, row)
 
 			if d.RowsRemaining(ctx) == 0 {
-				return nil, nil
+				return true, nil
 			}
 		}
 
@@ -161,10 +238,13 @@ func streamCostAndUsage(ctx context.Context, d *plugin.QueryData, params *costex
 		params.NextPageToken = output.NextPageToken
 	}
 
-	return nil, nil
+	return false, nil
 }
 
-func buildCEMetricRows(ctx context.Context, costUsageData *costexplorer.GetCostAndUsageOutput, _ map[string]*proto.QualValue) []CEMetricRow {
+// buildCEMetricRows builds a row per time period and group of a response.
+// extraKeys are the values of the group definitions after the ones of the
+// request, that the request was filtered to.
+func buildCEMetricRows(ctx context.Context, costUsageData *costexplorer.GetCostAndUsageOutput, groupBy []types.GroupDefinition, extraKeys []string) []CEMetricRow {
 	logger := plugin.Logger(ctx)
 	logger.Trace("buildCEMetricRows")
 	var rows []CEMetricRow
@@ -178,6 +258,10 @@ func buildCEMetricRows(ctx context.Context, costUsageData *costexplorer.GetCostA
 			row.Estimated = result.Estimated
 			row.PeriodStart = result.TimePeriod.Start
 			row.PeriodEnd = result.TimePeriod.End
+			// Only the group values the request was filtered to are known
+			if len(groupBy) == len(extraKeys) {
+				row.setRowKeys(groupBy, extraKeys)
+			}
 			row.setRowMetrics(result.Total)
 			rows = append(rows, row)
 		}
@@ -188,13 +272,7 @@ func buildCEMetricRows(ctx context.Context, costUsageData *costexplorer.GetCostA
 			row.Estimated = result.Estimated
 			row.PeriodStart = result.TimePeriod.Start
 			row.PeriodEnd = result.TimePeriod.End
-
-			if len(group.Keys) > 0 {
-				row.Dimension1 = aws.String(group.Keys[0])
-				if len(group.Keys) > 1 {
-					row.Dimension2 = aws.String(group.Keys[1])
-				}
-			}
+			row.setRowKeys(groupBy, append(append([]string{}, group.Keys...), extraKeys...))
 			row.setRowMetrics(group.Metrics)
 			rows = append(rows, row)
 		}
@@ -214,6 +292,11 @@ type CEMetricRow struct {
 	Dimension2 *string
 	//Tag *string
 
+	// The keys of the group, in the order of the group definitions, and the
+	// values of the group by group definition key
+	Keys   []string
+	Groups map[string]string
+
 	BlendedCostAmount      *string
 	UnblendedCostAmount    *string
 	NetUnblendedCostAmount *string
@@ -231,6 +314,25 @@ type CEMetricRow struct {
 	NormalizedUsageUnit  *string
 }
 
+func (row *CEMetricRow) setRowKeys(groupBy []types.GroupDefinition, keys []string) {
+	if len(keys) == 0 {
+		return
+	}
+	row.Keys = keys
+	row.Dimension1 = aws.String(keys[0])
+	if len(keys) > 1 {
+		row.Dimension2 = aws.String(keys[1])
+	}
+
+	row.Groups = map[string]string{}
+	for i, group := range groupBy {
+		if i < len(keys) {
+			// Tag and cost category keys are returned as <key>$<value>
+			row.Groups[aws.ToString(group.Key)] = strings.TrimPrefix(keys[i], aws.ToString(group.Key)+"$")
+		}
+	}
+}
+
 func (row *CEMetricRow) setRowMetrics(metrics map[string]types.MetricValue) {
 
 	row.BlendedCostAmount = metrics["BlendedCost"].Amount
@@ -268,6 +370,106 @@ func getCEStartDateForGranularity(granularity string) time.Time {
 	return time.Now().AddDate(0, 0, -13)
 }
 
+// Key columns of tables with a period_start and period_end range
+func ceTimePeriodKeyColumns() []*plugin.KeyColumn {
+	return []*plugin.KeyColumn{
+		{Name: "period_start", Operators: []string{">", ">=", "="}, Require: plugin.Optional, CacheMatch: "exact"},
+		{Name: "period_end", Operators: []string{"<", "<=", "="}, Require: plugin.Optional, CacheMatch: "exact"},
+	}
+}
+
+// ceAddPeriod returns the end of the period of a granularity starting at t
+func ceAddPeriod(t time.Time, granularity string) time.Time {
+	switch granularity {
+	case "HOURLY":
+		return t.Add(time.Hour)
+	case "MONTHLY":
+		return t.AddDate(0, 1, 0)
+	}
+	return t.AddDate(0, 0, 1)
+}
+
+// getCETimePeriod returns the time period of the period_start and period_end
+// quals. Without quals, the time period is the default for the granularity.
+//
+// The end of a Cost Explorer time period is exclusive, so for period_end <= X
+// and period_end = X, and for period_start = X, the time period ends a period
+// after X. The time period may hold more periods than the quals match, which
+// Postgres filters out.
+func getCETimePeriod(d *plugin.QueryData, granularity string) *types.DateInterval {
+	timeFormat := "2006-01-02"
+	if granularity == "HOURLY" {
+		timeFormat = "2006-01-02T15:04:05Z"
+	}
+
+	var startTime, endTime time.Time
+	setEndTime := func(t time.Time) {
+		if endTime.IsZero() || t.Before(endTime) {
+			endTime = t
+		}
+	}
+	if d.Quals["period_start"] != nil {
+		for _, q := range d.Quals["period_start"].Quals {
+			timestamp := q.Value.GetTimestampValue().AsTime()
+			switch q.Operator {
+			case "=":
+				startTime = timestamp
+				setEndTime(ceAddPeriod(timestamp, granularity))
+			case ">", ">=":
+				startTime = timestamp
+			}
+		}
+	}
+	if d.Quals["period_end"] != nil {
+		for _, q := range d.Quals["period_end"].Quals {
+			timestamp := q.Value.GetTimestampValue().AsTime()
+			switch q.Operator {
+			case "<":
+				setEndTime(timestamp)
+			case "<=", "=":
+				setEndTime(ceAddPeriod(timestamp, granularity))
+			}
+		}
+	}
+	if startTime.IsZero() {
+		startTime = getCEStartDateForGranularity(granularity)
+	}
+	if endTime.IsZero() {
+		endTime = time.Now()
+	}
+
+	return &types.DateInterval{
+		Start: aws.String(startTime.UTC().Format(timeFormat)),
+		End:   aws.String(endTime.UTC().Format(timeFormat)),
+	}
+}
+
+// getCEQualValues returns the values of an = or IN qual on a string column, or
+// defaultValues without a qual
+func getCEQualValues(d *plugin.QueryData, column string, defaultValues ...string) []string {
+	switch value := getQualsValueByColumn(d.Quals, column, "string").(type) {
+	case string:
+		return []string{value}
+	case []*string:
+		return aws.ToStringSlice(value)
+	}
+	return defaultValues
+}
+
+// ceServiceFilter returns a filter on the SERVICE dimension for a service, or
+// nil for an empty service
+func ceServiceFilter(service string) *types.Expression {
+	if service == "" {
+		return nil
+	}
+	return &types.Expression{
+		Dimensions: &types.DimensionValues{
+			Key:    types.DimensionService,
+			Values: []string{service},
+		},
+	}
+}
+
 type CEQuals struct {
 	// Quals stuff
 	SearchStartTime *timestamp.Timestamp
@@ -293,3 +495,98 @@ func hydrateCostAndUsageQuals(ctx context.Context, d *plugin.QueryData, _ *plugi
 		TagKey2:         d.EqualsQuals["tag_key_2"].GetStringValue(),
 	}, nil
 }
+
+// getCEGroupValues returns the values of a group definition in the time period
+// and filter of params. Usage without a tag or cost category is included as an
+// empty value.
+func getCEGroupValues(ctx context.Context, svc *costexplorer.Client, params *costexplorer.GetCostAndUsageInput, group types.GroupDefinition) ([]string, error) {
+	var values []string
+	var nextPageToken *string
+	for {
+		switch group.Type {
+		case types.GroupDefinitionTypeTag:
+			output, err := svc.GetTags(ctx, &costexplorer.GetTagsInput{
+				TimePeriod:    params.TimePeriod,
+				Filter:        params.Filter,
+				TagKey:        group.Key,
+				NextPageToken: nextPageToken,
+			})
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, output.Tags...)
+			nextPageToken = output.NextPageToken
+		case types.GroupDefinitionTypeCostCategory:
+			output, err := svc.GetCostCategories(ctx, &costexplorer.GetCostCategoriesInput{
+				TimePeriod:       params.TimePeriod,
+				Filter:           params.Filter,
+				CostCategoryName: group.Key,
+				NextPageToken:    nextPageToken,
+			})
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, output.CostCategoryValues...)
+			nextPageToken = output.NextPageToken
+		default:
+			output, err := svc.GetDimensionValues(ctx, &costexplorer.GetDimensionValuesInput{
+				TimePeriod:    params.TimePeriod,
+				Filter:        params.Filter,
+				Dimension:     types.Dimension(aws.ToString(group.Key)),
+				Context:       types.ContextCostAndUsage,
+				NextPageToken: nextPageToken,
+			})
+			if err != nil {
+				return nil, err
+			}
+			for _, value := range output.DimensionValues {
+				values = append(values, aws.ToString(value.Value))
+			}
+			nextPageToken = output.NextPageToken
+		}
+		if nextPageToken == nil {
+			break
+		}
+	}
+
+	if group.Type == types.GroupDefinitionTypeTag || group.Type == types.GroupDefinitionTypeCostCategory {
+		if !helpers.StringSliceContains(values, "") {
+			values = append(values, "")
+		}
+	}
+	return values, nil
+}
+
+// ceGroupKey returns the key of a group value, as returned by GetCostAndUsage
+func ceGroupKey(group types.GroupDefinition, value string) string {
+	if group.Type == types.GroupDefinitionTypeDimension {
+		return value
+	}
+	return aws.ToString(group.Key) + "$" + value
+}
+
+// ceGroupValueFilter returns filter, restricted to a value of a group
+// definition. An empty tag or cost category value matches usage without it.
+func ceGroupValueFilter(filter *types.Expression, group types.GroupDefinition, value string) *types.Expression {
+	values := []string{value}
+	var matchOptions []types.MatchOption
+	if value == "" && group.Type != types.GroupDefinitionTypeDimension {
+		values = nil
+		matchOptions = []types.MatchOption{types.MatchOptionAbsent}
+	}
+
+	expression := &types.Expression{}
+	switch group.Type {
+	case types.GroupDefinitionTypeTag:
+		expression.Tags = &types.TagValues{Key: group.Key, Values: values, MatchOptions: matchOptions}
+	case types.GroupDefinitionTypeCostCategory:
+		expression.CostCategories = &types.CostCategoryValues{Key: group.Key, Values: values, MatchOptions: matchOptions}
+	default:
+		expression.Dimensions = &types.DimensionValues{Key: types.Dimension(aws.ToString(group.Key)), Values: values}
+	}
+
+	if filter == nil {
+		return expression
+	}
+	return &types.Expression{And: []types.Expression{*filter, *expression}}
+}
@@ -0,0 +1,115 @@
+package aws
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer/types"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/quals"
+)
+
+func testCETimestampQual(column string, operator string, value string) *quals.Qual {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		panic(err)
+	}
+	return &quals.Qual{
+		Column:   column,
+		Operator: operator,
+		Value:    &proto.QualValue{Value: &proto.QualValue_TimestampValue{TimestampValue: &timestamp.Timestamp{Seconds: t.Unix()}}},
+	}
+}
+
+func TestGetCETimePeriod(t *testing.T) {
+	tests := []struct {
+		granularity string
+		quals       []*quals.Qual
+		start       string
+		end         string
+	}{
+		{
+			"DAILY",
+			[]*quals.Qual{
+				testCETimestampQual("period_start", ">=", "2024-01-01T00:00:00Z"),
+				testCETimestampQual("period_end", "<", "2024-04-01T00:00:00Z"),
+			},
+			"2024-01-01", "2024-04-01",
+		},
+		{
+			"DAILY",
+			[]*quals.Qual{
+				testCETimestampQual("period_start", ">", "2024-01-01T00:00:00Z"),
+				testCETimestampQual("period_end", "<=", "2024-03-31T00:00:00Z"),
+			},
+			"2024-01-01", "2024-04-01",
+		},
+		{
+			"DAILY",
+			[]*quals.Qual{testCETimestampQual("period_start", "=", "2024-03-15T00:00:00Z")},
+			"2024-03-15", "2024-03-16",
+		},
+		{
+			"MONTHLY",
+			[]*quals.Qual{testCETimestampQual("period_start", "=", "2024-03-01T00:00:00Z")},
+			"2024-03-01", "2024-04-01",
+		},
+		{
+			"MONTHLY",
+			[]*quals.Qual{
+				testCETimestampQual("period_start", ">=", "2024-01-01T00:00:00Z"),
+				testCETimestampQual("period_end", "=", "2024-04-01T00:00:00Z"),
+			},
+			"2024-01-01", "2024-05-01",
+		},
+		{
+			"HOURLY",
+			[]*quals.Qual{
+				testCETimestampQual("period_start", ">=", "2024-03-15T00:00:00Z"),
+				testCETimestampQual("period_end", "<=", "2024-03-15T06:00:00Z"),
+			},
+			"2024-03-15T00:00:00Z", "2024-03-15T07:00:00Z",
+		},
+		{
+			// The earliest end is used
+			"DAILY",
+			[]*quals.Qual{
+				testCETimestampQual("period_start", "=", "2024-03-15T00:00:00Z"),
+				testCETimestampQual("period_end", "<", "2024-03-20T00:00:00Z"),
+			},
+			"2024-03-15", "2024-03-16",
+		},
+	}
+
+	for i, test := range tests {
+		d := &plugin.QueryData{Quals: plugin.KeyColumnQualMap{}}
+		for _, q := range test.quals {
+			if d.Quals[q.Column] == nil {
+				d.Quals[q.Column] = &plugin.KeyColumnQuals{Name: q.Column}
+			}
+			d.Quals[q.Column].Quals = append(d.Quals[q.Column].Quals, q)
+		}
+		period := getCETimePeriod(d, test.granularity)
+		if aws.ToString(period.Start) != test.start || aws.ToString(period.End) != test.end {
+			t.Errorf("test %d: got %s - %s, expected %s - %s", i, aws.ToString(period.Start), aws.ToString(period.End), test.start, test.end)
+		}
+	}
+}
+
+func TestCERequestBudget(t *testing.T) {
+	group := types.GroupDefinition{Type: types.GroupDefinitionTypeTag, Key: aws.String("team")}
+	budget := &ceRequestBudget{remaining: ceMaxGroupValueRequests}
+
+	if err := budget.use(1, group); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err := budget.use(ceMaxGroupValueRequests-1, group); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err := budget.use(1, group); err == nil {
+		t.Errorf("expected an error once the budget is used")
+	}
+}
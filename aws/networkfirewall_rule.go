@@ -0,0 +1,418 @@
+package aws
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/networkfirewall/types"
+)
+
+const (
+	networkFirewallRuleTypeStateless  = "stateless"
+	networkFirewallRuleTypeStateful   = "stateful"
+	networkFirewallRuleTypeSuricata   = "suricata"
+	networkFirewallRuleTypeDomainList = "domain_list"
+)
+
+// networkFirewallRule is a single rule of a Network Firewall rule group,
+// whichever way the rule group defines it
+type networkFirewallRule struct {
+	RuleGroupName      *string
+	RuleGroupArn       *string
+	RuleGroupType      types.RuleGroupType
+	RuleType           string
+	RuleNumber         int
+	Priority           *int32
+	Action             string
+	Actions            []string
+	Protocol           string
+	Source             string
+	SourcePort         string
+	Direction          string
+	Destination        string
+	DestinationPort    string
+	Sid                *int64
+	Msg                *string
+	Options            []networkFirewallRuleOption
+	MatchAttributes    *types.MatchAttributes
+	Domain             *string
+	TargetTypes        []types.TargetType
+	GeneratedRulesType types.GeneratedRulesType
+	RuleString         *string
+	ParseError         *string
+	OverlyBroadPass    bool
+}
+
+type networkFirewallRuleOption struct {
+	Keyword  string
+	Settings []string
+}
+
+// networkFirewallSuricataNonMatchingKeywords are the rule options that do
+// not narrow the traffic a rule matches
+var networkFirewallSuricataNonMatchingKeywords = map[string]bool{
+	"sid":       true,
+	"rev":       true,
+	"gid":       true,
+	"msg":       true,
+	"metadata":  true,
+	"classtype": true,
+	"priority":  true,
+	"reference": true,
+}
+
+// networkFirewallRulesOf flattens the rules source of a rule group into one
+// networkFirewallRule per stateless rule, stateful rule, Suricata rule or
+// domain
+func networkFirewallRulesOf(source *types.RulesSource) []networkFirewallRule {
+	rules := []networkFirewallRule{}
+	if source == nil {
+		return rules
+	}
+
+	if source.StatelessRulesAndCustomActions != nil {
+		for _, rule := range source.StatelessRulesAndCustomActions.StatelessRules {
+			rules = append(rules, networkFirewallStatelessRule(rule))
+		}
+	}
+
+	for _, rule := range source.StatefulRules {
+		rules = append(rules, networkFirewallStatefulRule(rule))
+	}
+
+	if source.RulesString != nil {
+		for _, ruleString := range splitSuricataRules(*source.RulesString) {
+			rule, err := parseSuricataRule(ruleString)
+			if err != nil {
+				rule = networkFirewallRule{
+					RuleType:   networkFirewallRuleTypeSuricata,
+					RuleString: aws.String(ruleString),
+					ParseError: aws.String(err.Error()),
+				}
+			}
+			rules = append(rules, rule)
+		}
+	}
+
+	if source.RulesSourceList != nil {
+		action := "DROP"
+		if source.RulesSourceList.GeneratedRulesType == types.GeneratedRulesTypeAllowlist {
+			action = "PASS"
+		}
+		for _, target := range source.RulesSourceList.Targets {
+			rules = append(rules, networkFirewallRule{
+				RuleType:           networkFirewallRuleTypeDomainList,
+				Action:             action,
+				Domain:             aws.String(target),
+				TargetTypes:        source.RulesSourceList.TargetTypes,
+				GeneratedRulesType: source.RulesSourceList.GeneratedRulesType,
+				OverlyBroadPass:    action == "PASS" && (target == "." || target == "*" || target == ""),
+			})
+		}
+	}
+
+	for i := range rules {
+		rules[i].RuleNumber = i + 1
+	}
+	return rules
+}
+
+func networkFirewallStatelessRule(rule types.StatelessRule) networkFirewallRule {
+	r := networkFirewallRule{
+		RuleType:        networkFirewallRuleTypeStateless,
+		Priority:        aws.Int32(rule.Priority),
+		Protocol:        "ANY",
+		Source:          "ANY",
+		SourcePort:      "ANY",
+		Direction:       "FORWARD",
+		Destination:     "ANY",
+		DestinationPort: "ANY",
+	}
+	if rule.RuleDefinition == nil {
+		return r
+	}
+
+	r.Actions = rule.RuleDefinition.Actions
+	for _, action := range r.Actions {
+		// Custom actions are applied in addition to one of the standard actions
+		if strings.HasPrefix(action, "aws:") {
+			r.Action = action
+			break
+		}
+	}
+
+	match := rule.RuleDefinition.MatchAttributes
+	r.MatchAttributes = match
+	if match == nil {
+		return r
+	}
+	if len(match.Protocols) > 0 {
+		protocols := make([]string, len(match.Protocols))
+		for i, protocol := range match.Protocols {
+			protocols[i] = strconv.Itoa(int(protocol))
+		}
+		r.Protocol = strings.Join(protocols, ",")
+	}
+	if len(match.Sources) > 0 {
+		r.Source = joinNetworkFirewallAddresses(match.Sources)
+	}
+	if len(match.SourcePorts) > 0 {
+		r.SourcePort = joinNetworkFirewallPortRanges(match.SourcePorts)
+	}
+	if len(match.Destinations) > 0 {
+		r.Destination = joinNetworkFirewallAddresses(match.Destinations)
+	}
+	if len(match.DestinationPorts) > 0 {
+		r.DestinationPort = joinNetworkFirewallPortRanges(match.DestinationPorts)
+	}
+
+	r.OverlyBroadPass = r.Action == "aws:pass" &&
+		isNetworkFirewallAnyAddress(r.Source) &&
+		isNetworkFirewallAnyAddress(r.Destination) &&
+		isNetworkFirewallAnyPort(r.DestinationPort) &&
+		len(match.TCPFlags) == 0
+	return r
+}
+
+func networkFirewallStatefulRule(rule types.StatefulRule) networkFirewallRule {
+	r := networkFirewallRule{
+		RuleType: networkFirewallRuleTypeStateful,
+		Action:   string(rule.Action),
+	}
+	if rule.Header != nil {
+		r.Protocol = string(rule.Header.Protocol)
+		r.Source = aws.ToString(rule.Header.Source)
+		r.SourcePort = aws.ToString(rule.Header.SourcePort)
+		r.Direction = string(rule.Header.Direction)
+		r.Destination = aws.ToString(rule.Header.Destination)
+		r.DestinationPort = aws.ToString(rule.Header.DestinationPort)
+	}
+	for _, option := range rule.RuleOptions {
+		r.Options = append(r.Options, networkFirewallRuleOption{
+			Keyword:  aws.ToString(option.Keyword),
+			Settings: option.Settings,
+		})
+	}
+	setNetworkFirewallRuleOptionFields(&r)
+	return r
+}
+
+// splitSuricataRules returns the rules of a Suricata compatible rules
+// string, skipping comments and joining lines continued with a backslash
+func splitSuricataRules(rulesString string) []string {
+	rules := []string{}
+	current := ""
+	for _, line := range strings.Split(rulesString, "\n") {
+		line = strings.TrimSpace(line)
+		if current == "" && (line == "" || strings.HasPrefix(line, "#")) {
+			continue
+		}
+		if strings.HasSuffix(line, "\\") {
+			current += strings.TrimSuffix(line, "\\")
+			continue
+		}
+		current += line
+		if strings.TrimSpace(current) != "" {
+			rules = append(rules, strings.TrimSpace(current))
+		}
+		current = ""
+	}
+	if strings.TrimSpace(current) != "" {
+		rules = append(rules, strings.TrimSpace(current))
+	}
+	return rules
+}
+
+// parseSuricataRule parses a rule such as
+//
+//	pass tcp $HOME_NET any -> [10.0.0.0/8,!10.1.0.0/16] 443 (msg:"Allow HTTPS"; sid:100;)
+//
+// into its action, header and options
+func parseSuricataRule(rule string) (networkFirewallRule, error) {
+	r := networkFirewallRule{
+		RuleType:   networkFirewallRuleTypeSuricata,
+		RuleString: aws.String(rule),
+	}
+
+	open := strings.Index(rule, "(")
+	end := strings.LastIndex(rule, ")")
+	if open < 0 || end < open {
+		return r, fmt.Errorf("rule options not found")
+	}
+
+	header := splitSuricataHeader(rule[:open])
+	if len(header) != 7 {
+		return r, fmt.Errorf("expected 7 header fields, found %d", len(header))
+	}
+	r.Action = strings.ToUpper(header[0])
+	r.Protocol = strings.ToUpper(header[1])
+	r.Source = header[2]
+	r.SourcePort = header[3]
+	r.Destination = header[5]
+	r.DestinationPort = header[6]
+	switch header[4] {
+	case "->":
+		r.Direction = string(types.StatefulRuleDirectionForward)
+	case "<>":
+		r.Direction = string(types.StatefulRuleDirectionAny)
+	default:
+		return r, fmt.Errorf("invalid direction %q", header[4])
+	}
+
+	r.Options = splitSuricataOptions(rule[open+1 : end])
+	setNetworkFirewallRuleOptionFields(&r)
+	return r, nil
+}
+
+// splitSuricataHeader splits the rule header on whitespace, keeping
+// bracketed address and port lists together
+func splitSuricataHeader(header string) []string {
+	fields := []string{}
+	depth := 0
+	current := strings.Builder{}
+	for _, c := range header {
+		switch {
+		case c == '[':
+			depth++
+		case c == ']':
+			depth--
+		case (c == ' ' || c == '\t') && depth <= 0:
+			if current.Len() > 0 {
+				fields = append(fields, current.String())
+				current.Reset()
+			}
+			continue
+		case c == ' ' || c == '\t':
+			continue
+		}
+		current.WriteRune(c)
+	}
+	if current.Len() > 0 {
+		fields = append(fields, current.String())
+	}
+	return fields
+}
+
+// splitSuricataOptions splits the rule options on semicolons that are not
+// quoted or escaped
+func splitSuricataOptions(options string) []networkFirewallRuleOption {
+	result := []networkFirewallRuleOption{}
+	parts := []string{}
+	current := strings.Builder{}
+	quoted, escaped := false, false
+	for _, c := range options {
+		switch {
+		case escaped:
+			escaped = false
+		case c == '\\':
+			escaped = true
+		case c == '"':
+			quoted = !quoted
+		case c == ';' && !quoted:
+			parts = append(parts, current.String())
+			current.Reset()
+			continue
+		}
+		current.WriteRune(c)
+	}
+	parts = append(parts, current.String())
+
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		option := networkFirewallRuleOption{Keyword: part}
+		if i := strings.Index(part, ":"); i >= 0 {
+			option.Keyword = strings.TrimSpace(part[:i])
+			option.Settings = []string{strings.TrimSpace(part[i+1:])}
+		}
+		result = append(result, option)
+	}
+	return result
+}
+
+// setNetworkFirewallRuleOptionFields sets the sid and msg of the rule from
+// its options and checks whether the rule passes any traffic
+func setNetworkFirewallRuleOptionFields(r *networkFirewallRule) {
+	narrowed := false
+	for _, option := range r.Options {
+		keyword := strings.ToLower(option.Keyword)
+		setting := ""
+		if len(option.Settings) > 0 {
+			setting = option.Settings[0]
+		}
+		switch keyword {
+		case "sid":
+			if sid, err := strconv.ParseInt(strings.TrimSpace(setting), 10, 64); err == nil {
+				r.Sid = aws.Int64(sid)
+			}
+		case "msg":
+			r.Msg = aws.String(strings.ReplaceAll(strings.Trim(setting, "\""), "\\", ""))
+		}
+		if !networkFirewallSuricataNonMatchingKeywords[keyword] {
+			narrowed = true
+		}
+	}
+
+	r.OverlyBroadPass = r.Action == string(types.StatefulActionPass) &&
+		!narrowed &&
+		isNetworkFirewallAnyAddress(r.Source) &&
+		isNetworkFirewallAnyAddress(r.Destination) &&
+		isNetworkFirewallAnyPort(r.DestinationPort)
+}
+
+func joinNetworkFirewallAddresses(addresses []types.Address) string {
+	definitions := make([]string, len(addresses))
+	for i, address := range addresses {
+		definitions[i] = aws.ToString(address.AddressDefinition)
+	}
+	return strings.Join(definitions, ",")
+}
+
+func joinNetworkFirewallPortRanges(ranges []types.PortRange) string {
+	ports := make([]string, len(ranges))
+	for i, r := range ranges {
+		if r.FromPort == r.ToPort {
+			ports[i] = strconv.Itoa(int(r.FromPort))
+		} else {
+			ports[i] = fmt.Sprintf("%d:%d", r.FromPort, r.ToPort)
+		}
+	}
+	return strings.Join(ports, ",")
+}
+
+// isNetworkFirewallAnyAddress returns true if the address list matches every
+// address, i.e. it contains an any address and excludes nothing
+func isNetworkFirewallAnyAddress(address string) bool {
+	matchesAny := false
+	for _, part := range strings.Split(strings.Trim(address, "[]"), ",") {
+		part = strings.TrimSpace(part)
+		if strings.HasPrefix(part, "!") {
+			return false
+		}
+		switch strings.ToLower(part) {
+		case "any", "0.0.0.0/0", "::/0":
+			matchesAny = true
+		}
+	}
+	return matchesAny
+}
+
+// isNetworkFirewallAnyPort returns true if the port list matches every port
+func isNetworkFirewallAnyPort(port string) bool {
+	matchesAny := false
+	for _, part := range strings.Split(strings.Trim(port, "[]"), ",") {
+		part = strings.TrimSpace(part)
+		if strings.HasPrefix(part, "!") {
+			return false
+		}
+		switch strings.ToLower(part) {
+		case "any", "0:65535", "1:65535", "0:", "1:":
+			matchesAny = true
+		}
+	}
+	return matchesAny
+}
@@ -0,0 +1,116 @@
+package aws
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/networkfirewall/types"
+)
+
+func TestSplitSuricataRules(t *testing.T) {
+	rulesString := "# Allow HTTPS\npass tls $HOME_NET any -> any 443 (tls.sni; content:\"example.com\"; sid:1;)\n\ndrop tcp any any -> any any \\\n  (msg:\"Drop the rest\"; sid:2;)\n"
+	expected := []string{
+		`pass tls $HOME_NET any -> any 443 (tls.sni; content:"example.com"; sid:1;)`,
+		`drop tcp any any -> any any (msg:"Drop the rest"; sid:2;)`,
+	}
+	rules := splitSuricataRules(rulesString)
+	if !reflect.DeepEqual(rules, expected) {
+		t.Errorf("splitSuricataRules() = %q, expected %q", rules, expected)
+	}
+}
+
+func TestParseSuricataRule(t *testing.T) {
+	rule, err := parseSuricataRule(`alert tcp [10.0.0.0/8, !10.1.0.0/16] any <> $EXTERNAL_NET [80,443] (msg:"Web \"traffic\"; logged"; flow:to_server; sid:100; rev:2;)`)
+	if err != nil {
+		t.Fatalf("parseSuricataRule() error = %v", err)
+	}
+	if rule.Action != "ALERT" || rule.Protocol != "TCP" || rule.Direction != "ANY" {
+		t.Errorf("unexpected action %v, protocol %v or direction %v", rule.Action, rule.Protocol, rule.Direction)
+	}
+	if rule.Source != "[10.0.0.0/8,!10.1.0.0/16]" || rule.SourcePort != "any" || rule.Destination != "$EXTERNAL_NET" || rule.DestinationPort != "[80,443]" {
+		t.Errorf("unexpected header %v %v %v %v", rule.Source, rule.SourcePort, rule.Destination, rule.DestinationPort)
+	}
+	if aws.ToInt64(rule.Sid) != 100 || aws.ToString(rule.Msg) != `Web "traffic"; logged` {
+		t.Errorf("unexpected sid %v or msg %v", aws.ToInt64(rule.Sid), aws.ToString(rule.Msg))
+	}
+	expectedOptions := []networkFirewallRuleOption{
+		{Keyword: "msg", Settings: []string{`"Web \"traffic\"; logged"`}},
+		{Keyword: "flow", Settings: []string{"to_server"}},
+		{Keyword: "sid", Settings: []string{"100"}},
+		{Keyword: "rev", Settings: []string{"2"}},
+	}
+	if !reflect.DeepEqual(rule.Options, expectedOptions) {
+		t.Errorf("unexpected options %v", rule.Options)
+	}
+
+	if _, err := parseSuricataRule("pass tcp any any any any (sid:1;)"); err == nil {
+		t.Errorf("expected an error for a rule without direction")
+	}
+}
+
+func TestNetworkFirewallRuleOverlyBroadPass(t *testing.T) {
+	cases := map[string]bool{
+		`pass ip any any -> any any (sid:1;)`:                                     true,
+		`pass tcp $HOME_NET any -> 0.0.0.0/0 any (msg:"Allow all"; sid:2;)`:       false,
+		`pass tcp [0.0.0.0/0,::/0] any <> any any (sid:3;)`:                       true,
+		`pass tcp any any -> [0.0.0.0/0,!10.0.0.0/8] any (sid:4;)`:                false,
+		`pass tls any any -> any any (tls.sni; content:"example.com"; sid:5;)`:    false,
+		`drop ip any any -> any any (sid:6;)`:                                     false,
+		`pass tcp any any -> any [1:65535] (metadata:created 2023_01_01; sid:7;)`: true,
+	}
+	for ruleString, expected := range cases {
+		rule, err := parseSuricataRule(ruleString)
+		if err != nil {
+			t.Fatalf("parseSuricataRule(%q) error = %v", ruleString, err)
+		}
+		if rule.OverlyBroadPass != expected {
+			t.Errorf("overly broad pass of %q = %v, expected %v", ruleString, rule.OverlyBroadPass, expected)
+		}
+	}
+}
+
+func TestNetworkFirewallRulesOf(t *testing.T) {
+	source := &types.RulesSource{
+		StatelessRulesAndCustomActions: &types.StatelessRulesAndCustomActions{
+			StatelessRules: []types.StatelessRule{
+				{
+					Priority: 10,
+					RuleDefinition: &types.RuleDefinition{
+						Actions: []string{"MetricsAction", "aws:pass"},
+						MatchAttributes: &types.MatchAttributes{
+							Protocols:        []int32{6},
+							Sources:          []types.Address{{AddressDefinition: aws.String("0.0.0.0/0")}},
+							DestinationPorts: []types.PortRange{{FromPort: 0, ToPort: 65535}},
+						},
+					},
+				},
+			},
+		},
+		RulesSourceList: &types.RulesSourceList{
+			GeneratedRulesType: types.GeneratedRulesTypeDenylist,
+			TargetTypes:        []types.TargetType{types.TargetTypeTlsSni},
+			Targets:            []string{".example.com", "example.org"},
+		},
+	}
+	rules := networkFirewallRulesOf(source)
+	if len(rules) != 3 {
+		t.Fatalf("networkFirewallRulesOf() returned %d rules, expected 3", len(rules))
+	}
+
+	stateless := rules[0]
+	if stateless.RuleNumber != 1 || aws.ToInt32(stateless.Priority) != 10 || stateless.Action != "aws:pass" {
+		t.Errorf("unexpected stateless rule number %v, priority %v or action %v", stateless.RuleNumber, aws.ToInt32(stateless.Priority), stateless.Action)
+	}
+	if stateless.Protocol != "6" || stateless.Source != "0.0.0.0/0" || stateless.Destination != "ANY" || stateless.DestinationPort != "0:65535" {
+		t.Errorf("unexpected stateless match %v %v %v %v", stateless.Protocol, stateless.Source, stateless.Destination, stateless.DestinationPort)
+	}
+	if !stateless.OverlyBroadPass {
+		t.Errorf("expected the stateless rule to be an overly broad pass")
+	}
+
+	domain := rules[2]
+	if domain.RuleNumber != 3 || aws.ToString(domain.Domain) != "example.org" || domain.Action != "DROP" || domain.OverlyBroadPass {
+		t.Errorf("unexpected domain rule %v %v %v %v", domain.RuleNumber, aws.ToString(domain.Domain), domain.Action, domain.OverlyBroadPass)
+	}
+}
@@ -66,6 +66,7 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			"aws_appstream_fleet":                                          tableAwsAppStreamFleet(ctx),
 			"aws_appstream_image":                                          tableAwsAppStreamImage(ctx),
 			"aws_athena_query_execution":                                   tableAwsAthenaQueryExecution(ctx),
+			"aws_athena_query_result":                                      tableAwsAthenaQueryResult(ctx),
 			"aws_athena_workgroup":                                         tableAwsAthenaWorkGroup(ctx),
 			"aws_auditmanager_assessment":                                  tableAwsAuditManagerAssessment(ctx),
 			"aws_auditmanager_control":                                     tableAwsAuditManagerControl(ctx),
@@ -85,6 +86,7 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			"aws_cloudformation_stack":                                     tableAwsCloudFormationStack(ctx),
 			"aws_cloudformation_stack_resource":                            tableAwsCloudFormationStackResource(ctx),
 			"aws_cloudformation_stack_set":                                 tableAwsCloudFormationStackSet(ctx),
+			"aws_cloudfront_access_log":                                    tableAwsCloudFrontAccessLog(ctx),
 			"aws_cloudfront_cache_policy":                                  tableAwsCloudFrontCachePolicy(ctx),
 			"aws_cloudfront_distribution":                                  tableAwsCloudFrontDistribution(ctx),
 			"aws_cloudfront_function":                                      tableAwsCloudFrontFunction(ctx),
@@ -105,8 +107,10 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			"aws_cloudwatch_log_resource_policy":                           tableAwsCloudwatchLogResourcePolicy(ctx),
 			"aws_cloudwatch_log_stream":                                    tableAwsCloudwatchLogStream(ctx),
 			"aws_cloudwatch_log_subscription_filter":                       tableAwsCloudwatchLogSubscriptionFilter(ctx),
+			"aws_cloudwatch_log_tail":                                      tableAwsCloudwatchLogTail(ctx),
 			"aws_cloudwatch_metric":                                        tableAwsCloudWatchMetric(ctx),
 			"aws_cloudwatch_metric_data_point":                             tableAwsCloudWatchMetricDataPoint(ctx),
+			"aws_cloudwatch_metric_statistic":                              tableAwsCloudWatchMetricStatistic(ctx),
 			"aws_cloudwatch_metric_statistic_data_point":                   tableAwsCloudWatchMetricStatisticDataPoint(ctx),
 			"aws_codeartifact_domain":                                      tableAwsCodeArtifactDomain(ctx),
 			"aws_codeartifact_repository":                                  tableAwsCodeArtifactRepository(ctx),
@@ -121,13 +125,24 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			"aws_cognito_identity_pool":                                    tableAwsCognitoIdentityPool(ctx),
 			"aws_cognito_identity_provider":                                tableAwsCognitoIdentityProvider(ctx),
 			"aws_cognito_user_pool":                                        tableAwsCognitoUserPool(ctx),
+			"aws_computeoptimizer_autoscaling_group_recommendation":        tableAwsComputeOptimizerAutoScalingGroupRecommendation(ctx),
+			"aws_computeoptimizer_ebs_volume_recommendation":               tableAwsComputeOptimizerEBSVolumeRecommendation(ctx),
+			"aws_computeoptimizer_ec2_instance_recommendation":             tableAwsComputeOptimizerEC2InstanceRecommendation(ctx),
+			"aws_computeoptimizer_ecs_service_recommendation":              tableAwsComputeOptimizerECSServiceRecommendation(ctx),
+			"aws_computeoptimizer_enrollment_status":                       tableAwsComputeOptimizerEnrollmentStatus(ctx),
+			"aws_computeoptimizer_lambda_function_recommendation":          tableAwsComputeOptimizerLambdaFunctionRecommendation(ctx),
 			"aws_config_aggregate_authorization":                           tableAwsConfigAggregateAuthorization(ctx),
 			"aws_config_configuration_recorder":                            tableAwsConfigConfigurationRecorder(ctx),
 			"aws_config_conformance_pack":                                  tableAwsConfigConformancePack(ctx),
 			"aws_config_retention_configuration":                           tableAwsConfigRetentionConfiguration(ctx),
 			"aws_config_rule":                                              tableAwsConfigRule(ctx),
+			"aws_cost_allocation_tag":                                      tableAwsCostAllocationTag(ctx),
+			"aws_cost_anomaly":                                             tableAwsCostAnomaly(ctx),
+			"aws_cost_anomaly_monitor":                                     tableAwsCostAnomalyMonitor(ctx),
+			"aws_cost_anomaly_subscription":                                tableAwsCostAnomalySubscription(ctx),
 			"aws_cost_by_account_daily":                                    tableAwsCostByLinkedAccountDaily(ctx),
 			"aws_cost_by_account_monthly":                                  tableAwsCostByLinkedAccountMonthly(ctx),
+			"aws_cost_by_cost_category":                                    tableAwsCostByCostCategory(ctx),
 			"aws_cost_by_record_type_daily":                                tableAwsCostByRecordTypeDaily(ctx),
 			"aws_cost_by_record_type_monthly":                              tableAwsCostByRecordTypeMonthly(ctx),
 			"aws_cost_by_service_daily":                                    tableAwsCostByServiceDaily(ctx),
@@ -135,13 +150,32 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			"aws_cost_by_service_usage_type_daily":                         tableAwsCostByServiceUsageTypeDaily(ctx),
 			"aws_cost_by_service_usage_type_monthly":                       tableAwsCostByServiceUsageTypeMonthly(ctx),
 			"aws_cost_by_tag":                                              tableAwsCostByTag(ctx),
+			"aws_cost_category_definition":                                 tableAwsCostCategoryDefinition(ctx),
+			"aws_cost_explorer_query":                                      tableAwsCostExplorerQuery(ctx),
 			"aws_cost_forecast_daily":                                      tableAwsCostForecastDaily(ctx),
 			"aws_cost_forecast_monthly":                                    tableAwsCostForecastMonthly(ctx),
+			"aws_cost_reservation_coverage":                                tableAwsCostReservationCoverage(ctx),
+			"aws_cost_reservation_purchase_recommendation":                 tableAwsCostReservationPurchaseRecommendation(ctx),
+			"aws_cost_reservation_utilization":                             tableAwsCostReservationUtilization(ctx),
+			"aws_cost_rightsizing_recommendation":                          tableAwsCostRightsizingRecommendation(ctx),
+			"aws_cost_savings_plan_coverage":                               tableAwsCostSavingsPlanCoverage(ctx),
+			"aws_cost_savings_plan_purchase_recommendation":                tableAwsCostSavingsPlanPurchaseRecommendation(ctx),
+			"aws_cost_savings_plan_utilization":                            tableAwsCostSavingsPlanUtilization(ctx),
 			"aws_cost_usage":                                               tableAwsCostAndUsage(ctx),
+			"aws_cost_usage_report_line_item":                              tableAwsCostUsageReportLineItem(ctx),
 			"aws_dax_cluster":                                              tableAwsDaxCluster(ctx),
 			"aws_dax_parameter":                                            tableAwsDaxParameter(ctx),
 			"aws_dax_parameter_group":                                      tableAwsDaxParameterGroup(ctx),
 			"aws_dax_subnet_group":                                         tableAwsDaxSubnetGroup(ctx),
+			"aws_directconnect_connection":                                 tableAwsDirectConnectConnection(ctx),
+			"aws_directconnect_connection_metric_connection_state":         tableAwsDirectConnectConnectionMetricConnectionState(ctx),
+			"aws_directconnect_connection_metric_connection_state_daily":   tableAwsDirectConnectConnectionMetricConnectionStateDaily(ctx),
+			"aws_directconnect_connection_metric_connection_state_hourly":  tableAwsDirectConnectConnectionMetricConnectionStateHourly(ctx),
+			"aws_directconnect_gateway":                                    tableAwsDirectConnectGateway(ctx),
+			"aws_directconnect_gateway_association":                        tableAwsDirectConnectGatewayAssociation(ctx),
+			"aws_directconnect_gateway_attachment":                         tableAwsDirectConnectGatewayAttachment(ctx),
+			"aws_directconnect_lag":                                        tableAwsDirectConnectLag(ctx),
+			"aws_directconnect_virtual_interface":                          tableAwsDirectConnectVirtualInterface(ctx),
 			"aws_directory_service_certificate":                            tableAwsDirectoryServiceCertificate(ctx),
 			"aws_directory_service_directory":                              tableAwsDirectoryServiceDirectory(ctx),
 			"aws_directory_service_log_subscription":                       tableAwsDirectoryServiceLogSubscription(ctx),
@@ -170,6 +204,7 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			"aws_ec2_ami":                                                  tableAwsEc2Ami(ctx),
 			"aws_ec2_ami_shared":                                           tableAwsEc2AmiShared(ctx),
 			"aws_ec2_application_load_balancer":                            tableAwsEc2ApplicationLoadBalancer(ctx),
+			"aws_ec2_application_load_balancer_access_log":                 tableAwsEc2ApplicationLoadBalancerAccessLog(ctx),
 			"aws_ec2_application_load_balancer_metric_request_count":       tableAwsEc2ApplicationLoadBalancerMetricRequestCount(ctx),
 			"aws_ec2_application_load_balancer_metric_request_count_daily": tableAwsEc2ApplicationLoadBalancerMetricRequestCountDaily(ctx),
 			"aws_ec2_autoscaling_group":                                    tableAwsEc2ASG(ctx),
@@ -192,6 +227,7 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			"aws_ec2_managed_prefix_list_entry":                            tableAwsEc2ManagedPrefixListEntry(ctx),
 			"aws_ec2_network_interface":                                    tableAwsEc2NetworkInterface(ctx),
 			"aws_ec2_network_load_balancer":                                tableAwsEc2NetworkLoadBalancer(ctx),
+			"aws_ec2_network_load_balancer_access_log":                     tableAwsEc2NetworkLoadBalancerAccessLog(ctx),
 			"aws_ec2_network_load_balancer_metric_net_flow_count":          tableAwsEc2NetworkLoadBalancerMetricNetFlowCount(ctx),
 			"aws_ec2_network_load_balancer_metric_net_flow_count_daily":    tableAwsEc2NetworkLoadBalancerMetricNetFlowCountDaily(ctx),
 			"aws_ec2_regional_settings":                                    tableAwsEc2RegionalSettings(ctx),
@@ -200,9 +236,16 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			"aws_ec2_ssl_policy":                                           tableAwsEc2SslPolicy(ctx),
 			"aws_ec2_target_group":                                         tableAwsEc2TargetGroup(ctx),
 			"aws_ec2_transit_gateway":                                      tableAwsEc2TransitGateway(ctx),
+			"aws_ec2_transit_gateway_connect_attachment":                   tableAwsEc2TransitGatewayConnectAttachment(ctx),
+			"aws_ec2_transit_gateway_direct_connect_gateway_attachment":    tableAwsEc2TransitGatewayDirectConnectGatewayAttachment(ctx),
+			"aws_ec2_transit_gateway_path":                                 tableAwsEc2TransitGatewayPath(ctx),
+			"aws_ec2_transit_gateway_peering_attachment":                   tableAwsEc2TransitGatewayPeeringAttachment(ctx),
 			"aws_ec2_transit_gateway_route":                                tableAwsEc2TransitGatewayRoute(ctx),
 			"aws_ec2_transit_gateway_route_table":                          tableAwsEc2TransitGatewayRouteTable(ctx),
+			"aws_ec2_transit_gateway_route_table_association":              tableAwsEc2TransitGatewayRouteTableAssociation(ctx),
+			"aws_ec2_transit_gateway_route_table_propagation":              tableAwsEc2TransitGatewayRouteTablePropagation(ctx),
 			"aws_ec2_transit_gateway_vpc_attachment":                       tableAwsEc2TransitGatewayVpcAttachment(ctx),
+			"aws_ec2_transit_gateway_vpn_attachment":                       tableAwsEc2TransitGatewayVpnAttachment(ctx),
 			"aws_ecr_image":                                                tableAwsEcrImage(ctx),
 			"aws_ecr_image_scan_finding":                                   tableAwsEcrImageScanFinding(ctx),
 			"aws_ecr_repository":                                           tableAwsEcrRepository(ctx),
@@ -325,8 +368,10 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			"aws_msk_serverless_cluster":                                   tableAwsMSKServerlessCluster(ctx),
 			"aws_neptune_db_cluster":                                       tableAwsNeptuneDBCluster(ctx),
 			"aws_neptune_db_cluster_snapshot":                              tableAwsNeptuneDBClusterSnapshot(ctx),
+			"aws_network_exposure":                                         tableAwsNetworkExposure(ctx),
 			"aws_networkfirewall_firewall":                                 tableAwsNetworkFirewallFirewall(ctx),
 			"aws_networkfirewall_firewall_policy":                          tableAwsNetworkFirewallPolicy(ctx),
+			"aws_networkfirewall_rule":                                     tableAwsNetworkFirewallRule(ctx),
 			"aws_networkfirewall_rule_group":                               tableAwsNetworkFirewallRuleGroup(ctx),
 			"aws_oam_link":                                                 tableAwsOAMLink(ctx),
 			"aws_oam_sink":                                                 tableAwsOAMSink(ctx),
@@ -376,12 +421,19 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			"aws_resource_explorer_index":                                  tableAWSResourceExplorerIndex(ctx),
 			"aws_resource_explorer_search":                                 tableAWSResourceExplorerSearch(ctx),
 			"aws_resource_explorer_supported_resource_type":                tableAWSResourceExplorerSupportedResourceType(ctx),
+			"aws_resource_price_estimate":                                  tableAwsResourcePriceEstimate(ctx),
 			"aws_route53_domain":                                           tableAwsRoute53Domain(ctx),
 			"aws_route53_health_check":                                     tableAwsRoute53HealthCheck(ctx),
 			"aws_route53_query_log":                                        tableAwsRoute53QueryLog(ctx),
 			"aws_route53_record":                                           tableAwsRoute53Record(ctx),
+			"aws_route53_record_target":                                    tableAwsRoute53RecordTarget(ctx),
 			"aws_route53_resolver_endpoint":                                tableAwsRoute53ResolverEndpoint(ctx),
+			"aws_route53_resolver_firewall_domain_list":                    tableAwsRoute53ResolverFirewallDomainList(ctx),
+			"aws_route53_resolver_firewall_rule":                           tableAwsRoute53ResolverFirewallRule(ctx),
+			"aws_route53_resolver_firewall_rule_group":                     tableAwsRoute53ResolverFirewallRuleGroup(ctx),
+			"aws_route53_resolver_firewall_rule_group_association":         tableAwsRoute53ResolverFirewallRuleGroupAssociation(ctx),
 			"aws_route53_resolver_query_log_config":                        tableAwsRoute53ResolverQueryLogConfig(ctx),
+			"aws_route53_resolver_query_log_event":                         tableAwsRoute53ResolverQueryLogEvent(ctx),
 			"aws_route53_resolver_rule":                                    tableAwsRoute53ResolverRule(ctx),
 			"aws_route53_traffic_policy":                                   tableAwsRoute53TrafficPolicy(ctx),
 			"aws_route53_traffic_policy_instance":                          tableAwsRoute53TrafficPolicyInstance(ctx),
@@ -392,6 +444,7 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			"aws_s3_bucket_intelligent_tiering_configuration":              tableAwsS3BucketIntelligentTieringConfiguration(ctx),
 			"aws_s3_multi_region_access_point":                             tableAwsS3MultiRegionAccessPoint(ctx),
 			"aws_s3_object":                                                tableAwsS3Object(ctx),
+			"aws_s3_object_select":                                         tableAwsS3ObjectSelect(ctx),
 			"aws_sagemaker_app":                                            tableAwsSageMakerApp(ctx),
 			"aws_sagemaker_domain":                                         tableAwsSageMakerDomain(ctx),
 			"aws_sagemaker_endpoint_configuration":                         tableAwsSageMakerEndpointConfiguration(ctx),
@@ -399,6 +452,7 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			"aws_sagemaker_notebook_instance":                              tableAwsSageMakerNotebookInstance(ctx),
 			"aws_sagemaker_training_job":                                   tableAwsSageMakerTrainingJob(ctx),
 			"aws_secretsmanager_secret":                                    tableAwsSecretsManagerSecret(ctx),
+			"aws_security_finding":                                         tableAwsSecurityFinding(ctx),
 			"aws_securityhub_action_target":                                tableAwsSecurityHubActionTarget(ctx),
 			"aws_securityhub_finding":                                      tableAwsSecurityHubFinding(ctx),
 			"aws_securityhub_finding_aggregator":                           tableAwsSecurityHubFindingAggregator(ctx),
@@ -448,6 +502,7 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			"aws_tagging_resource":                                         tableAwsTaggingResource(ctx),
 			"aws_transfer_server":                                          tableAwsTransferServer(ctx),
 			"aws_vpc":                                                      tableAwsVpc(ctx),
+			"aws_vpc_cidr_utilization":                                     tableAwsVpcCidrUtilization(ctx),
 			"aws_vpc_customer_gateway":                                     tableAwsVpcCustomerGateway(ctx),
 			"aws_vpc_dhcp_options":                                         tableAwsVpcDhcpOptions(ctx),
 			"aws_vpc_egress_only_internet_gateway":                         tableAwsVpcEgressOnlyIGW(ctx),
@@ -458,13 +513,18 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			"aws_vpc_flow_log":                                             tableAwsVpcFlowlog(ctx),
 			"aws_vpc_flow_log_event":                                       tableAwsVpcFlowLogEvent(ctx),
 			"aws_vpc_internet_gateway":                                     tableAwsVpcInternetGateway(ctx),
+			"aws_vpc_ipam":                                                 tableAwsVpcIpam(ctx),
+			"aws_vpc_ipam_pool":                                            tableAwsVpcIpamPool(ctx),
+			"aws_vpc_ipam_pool_allocation":                                 tableAwsVpcIpamPoolAllocation(ctx),
 			"aws_vpc_nat_gateway":                                          tableAwsVpcNatGateway(ctx),
 			"aws_vpc_nat_gateway_metric_bytes_out_to_destination":          tableAwsVpcNatGatewayMetricBytesOutToDestination(ctx),
 			"aws_vpc_network_acl":                                          tableAwsVpcNetworkACL(ctx),
 			"aws_vpc_peering_connection":                                   tableAwsVpcPeeringConnection(ctx),
+			"aws_vpc_reachability":                                         tableAwsVpcReachability(ctx),
 			"aws_vpc_route":                                                tableAwsVpcRoute(ctx),
 			"aws_vpc_route_table":                                          tableAwsVpcRouteTable(ctx),
 			"aws_vpc_security_group":                                       tableAwsVpcSecurityGroup(ctx),
+			"aws_vpc_security_group_effective_rule":                        tableAwsVpcSecurityGroupEffectiveRule(ctx),
 			"aws_vpc_security_group_rule":                                  tableAwsVpcSecurityGroupRule(ctx),
 			"aws_vpc_subnet":                                               tableAwsVpcSubnet(ctx),
 			"aws_vpc_verified_access_endpoint":                             tableAwsVpcVerifiedAccessEndpoint(ctx),
@@ -473,6 +533,13 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			"aws_vpc_verified_access_trust_provider":                       tableAwsVpcVerifiedAccessTrustProvider(ctx),
 			"aws_vpc_vpn_connection":                                       tableAwsVpcVpnConnection(ctx),
 			"aws_vpc_vpn_gateway":                                          tableAwsVpcVpnGateway(ctx),
+			"aws_vpclattice_listener":                                      tableAwsVpcLatticeListener(ctx),
+			"aws_vpclattice_rule":                                          tableAwsVpcLatticeRule(ctx),
+			"aws_vpclattice_service":                                       tableAwsVpcLatticeService(ctx),
+			"aws_vpclattice_service_network":                               tableAwsVpcLatticeServiceNetwork(ctx),
+			"aws_vpclattice_service_network_service_association":           tableAwsVpcLatticeServiceNetworkServiceAssociation(ctx),
+			"aws_vpclattice_service_network_vpc_association":               tableAwsVpcLatticeServiceNetworkVpcAssociation(ctx),
+			"aws_vpclattice_target_group":                                  tableAwsVpcLatticeTargetGroup(ctx),
 			"aws_waf_rate_based_rule":                                      tableAwsWafRateBasedRule(ctx),
 			"aws_waf_rule":                                                 tableAwsWAFRule(ctx),
 			"aws_waf_rule_group":                                           tableAwsWafRuleGroup(ctx),
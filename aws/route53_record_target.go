@@ -0,0 +1,370 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigateway"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	"github.com/aws/aws-sdk-go-v2/service/cloudfront"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/elasticbeanstalk"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/smithy-go"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+// Kinds of resources DNS records are resolved to
+const (
+	route53TargetElasticIp        = "eip"
+	route53TargetLoadBalancer     = "elb"
+	route53TargetCloudFront       = "cloudfront"
+	route53TargetS3Website        = "s3_website"
+	route53TargetApiGatewayDomain = "api_gateway_domain"
+	route53TargetApiGateway       = "api_gateway"
+	route53TargetElasticBeanstalk = "elastic_beanstalk"
+)
+
+// route53TargetEndpoint is an AWS endpoint a DNS record points at: the kind
+// of resource serving it, its region, and the key of the resource in the
+// resources of its kind
+type route53TargetEndpoint struct {
+	Type   string
+	Region string
+	Key    string
+}
+
+// route53TargetEndpointOf returns the AWS endpoint a host name is of, from
+// the naming scheme of the DNS names of each service. It returns nil for host
+// names outside the AWS domains, and an endpoint without a type for AWS host
+// names of services that are not resolved.
+func route53TargetEndpointOf(host string) *route53TargetEndpoint {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	host = strings.TrimPrefix(host, "dualstack.")
+	host = strings.TrimPrefix(host, "ipv6.")
+	labels := strings.Split(host, ".")
+
+	switch {
+	case strings.HasSuffix(host, ".cloudfront.net"):
+		return &route53TargetEndpoint{Type: route53TargetCloudFront, Key: host}
+
+	case strings.HasSuffix(host, ".elasticbeanstalk.com"):
+		// CNAMEs of environments created before the region was part of them
+		// are all in us-east-1
+		region := "us-east-1"
+		if len(labels) > 3 {
+			region = labels[len(labels)-3]
+		}
+		return &route53TargetEndpoint{Type: route53TargetElasticBeanstalk, Region: region, Key: host}
+
+	case strings.HasSuffix(host, ".amazonaws.com"), strings.HasSuffix(host, ".amazonaws.com.cn"):
+		labels = labels[:slices.Index(labels, "amazonaws")]
+		for i, label := range labels {
+			switch {
+			case label == "execute-api" && i == 1 && len(labels) == 3:
+				// Custom domain names have d- IDs, invoke URLs of APIs have
+				// the ID of the API
+				if strings.HasPrefix(labels[0], "d-") {
+					return &route53TargetEndpoint{Type: route53TargetApiGatewayDomain, Region: labels[2], Key: host}
+				}
+				return &route53TargetEndpoint{Type: route53TargetApiGateway, Region: labels[2], Key: labels[0]}
+
+			case label == "s3-website" && i+1 < len(labels):
+				return &route53TargetEndpoint{Type: route53TargetS3Website, Region: labels[i+1], Key: strings.Join(labels[:i], ".")}
+
+			case strings.HasPrefix(label, "s3-website-"):
+				return &route53TargetEndpoint{Type: route53TargetS3Website, Region: strings.TrimPrefix(label, "s3-website-"), Key: strings.Join(labels[:i], ".")}
+
+			case label == "elb" && i > 0:
+				// Network load balancers are name.elb.region, application and
+				// classic load balancers name.region.elb
+				region := labels[i-1]
+				if i+1 < len(labels) {
+					region = labels[i+1]
+				}
+				return &route53TargetEndpoint{Type: route53TargetLoadBalancer, Region: region, Key: host}
+			}
+		}
+		return &route53TargetEndpoint{Key: host}
+	}
+
+	return nil
+}
+
+// route53TargetResources returns the IDs of the resources of a kind in a
+// region, keyed by their DNS name, address or ID. It is cached per connection
+// and region, as records of many zones can point at the same region. The
+// resources are nil if they can't be listed, e.g. as access to the service is
+// denied, in which case whether a target exists is unknown.
+func route53TargetResources(ctx context.Context, d *plugin.QueryData, targetType string, region string) (map[string]string, error) {
+	cacheKey := "route53TargetResources-" + targetType + "-" + d.Connection.Name + "-" + region
+	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
+		return cachedData.(map[string]string), nil
+	}
+
+	var resources map[string]string
+	var err error
+	switch targetType {
+	case route53TargetElasticIp:
+		resources, err = listRoute53TargetElasticIps(ctx, d, region)
+	case route53TargetLoadBalancer:
+		resources, err = listRoute53TargetLoadBalancers(ctx, d, region)
+	case route53TargetCloudFront:
+		resources, err = listRoute53TargetDistributions(ctx, d)
+	case route53TargetS3Website:
+		resources, err = listRoute53TargetBuckets(ctx, d)
+	case route53TargetApiGatewayDomain:
+		resources, err = listRoute53TargetApiGatewayDomains(ctx, d, region)
+	case route53TargetApiGateway:
+		resources, err = listRoute53TargetApiGatewayApis(ctx, d, region)
+	case route53TargetElasticBeanstalk:
+		resources, err = listRoute53TargetEnvironments(ctx, d, region)
+	}
+	if err != nil {
+		if !isRoute53TargetLookupError(err) {
+			plugin.Logger(ctx).Error("route53TargetResources", "api_error", err, "target_type", targetType, "region", region)
+			return nil, err
+		}
+		plugin.Logger(ctx).Warn("route53TargetResources", "lookup_error", err, "target_type", targetType, "region", region)
+		resources = nil
+	}
+
+	d.ConnectionManager.Cache.Set(cacheKey, resources)
+	return resources, nil
+}
+
+// isRoute53TargetLookupError returns whether an error listing the resources of
+// a kind leaves their targets unresolved rather than failing the query. API
+// errors, e.g. access denied to the service or a region where it isn't
+// enabled, are of the lookup only. Other errors, e.g. the query being
+// cancelled, are not.
+func isRoute53TargetLookupError(err error) bool {
+	var ae smithy.APIError
+	return errors.As(err, &ae)
+}
+
+func listRoute53TargetElasticIps(ctx context.Context, d *plugin.QueryData, region string) (map[string]string, error) {
+	svc, err := EC2ClientForRegion(ctx, d, region)
+	if err != nil {
+		return nil, err
+	}
+
+	d.WaitForListRateLimit(ctx)
+	output, err := svc.DescribeAddresses(ctx, &ec2.DescribeAddressesInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	resources := map[string]string{}
+	for _, address := range output.Addresses {
+		resources[aws.ToString(address.PublicIp)] = aws.ToString(address.AllocationId)
+	}
+	return resources, nil
+}
+
+func listRoute53TargetLoadBalancers(ctx context.Context, d *plugin.QueryData, region string) (map[string]string, error) {
+	cfg, err := getClientForRegion(ctx, d, region)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := map[string]string{}
+	loadBalancers := elasticloadbalancingv2.NewDescribeLoadBalancersPaginator(elasticloadbalancingv2.NewFromConfig(*cfg), &elasticloadbalancingv2.DescribeLoadBalancersInput{})
+	for loadBalancers.HasMorePages() {
+		d.WaitForListRateLimit(ctx)
+		output, err := loadBalancers.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, loadBalancer := range output.LoadBalancers {
+			resources[strings.ToLower(aws.ToString(loadBalancer.DNSName))] = aws.ToString(loadBalancer.LoadBalancerArn)
+		}
+	}
+
+	classicLoadBalancers := elasticloadbalancing.NewDescribeLoadBalancersPaginator(elasticloadbalancing.NewFromConfig(*cfg), &elasticloadbalancing.DescribeLoadBalancersInput{})
+	for classicLoadBalancers.HasMorePages() {
+		d.WaitForListRateLimit(ctx)
+		output, err := classicLoadBalancers.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, loadBalancer := range output.LoadBalancerDescriptions {
+			resources[strings.ToLower(aws.ToString(loadBalancer.DNSName))] = aws.ToString(loadBalancer.LoadBalancerName)
+		}
+	}
+
+	return resources, nil
+}
+
+func listRoute53TargetDistributions(ctx context.Context, d *plugin.QueryData) (map[string]string, error) {
+	svc, err := CloudFrontClient(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := map[string]string{}
+	paginator := cloudfront.NewListDistributionsPaginator(svc, &cloudfront.ListDistributionsInput{})
+	for paginator.HasMorePages() {
+		d.WaitForListRateLimit(ctx)
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if output.DistributionList == nil {
+			continue
+		}
+		for _, distribution := range output.DistributionList.Items {
+			resources[strings.ToLower(aws.ToString(distribution.DomainName))] = aws.ToString(distribution.Id)
+		}
+	}
+	return resources, nil
+}
+
+func listRoute53TargetBuckets(ctx context.Context, d *plugin.QueryData) (map[string]string, error) {
+	region, err := getLastResortRegion(ctx, d, nil)
+	if err != nil {
+		return nil, err
+	}
+	svc, err := S3Client(ctx, d, region)
+	if err != nil {
+		return nil, err
+	}
+
+	d.WaitForListRateLimit(ctx)
+	output, err := svc.ListBuckets(ctx, &s3.ListBucketsInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	resources := map[string]string{}
+	for _, bucket := range output.Buckets {
+		resources[aws.ToString(bucket.Name)] = aws.ToString(bucket.Name)
+	}
+	return resources, nil
+}
+
+// listRoute53TargetApiGatewayDomains returns custom domain names keyed by
+// their regional domain names, and for edge-optimized ones, the domain names
+// of their CloudFront distributions
+func listRoute53TargetApiGatewayDomains(ctx context.Context, d *plugin.QueryData, region string) (map[string]string, error) {
+	cfg, err := getClientForRegion(ctx, d, region)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := map[string]string{}
+	domainNames := apigateway.NewGetDomainNamesPaginator(apigateway.NewFromConfig(*cfg), &apigateway.GetDomainNamesInput{})
+	for domainNames.HasMorePages() {
+		d.WaitForListRateLimit(ctx)
+		output, err := domainNames.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, domainName := range output.Items {
+			for _, name := range []*string{domainName.RegionalDomainName, domainName.DistributionDomainName} {
+				if name != nil {
+					resources[strings.ToLower(*name)] = aws.ToString(domainName.DomainName)
+				}
+			}
+		}
+	}
+
+	svc := apigatewayv2.NewFromConfig(*cfg)
+	input := &apigatewayv2.GetDomainNamesInput{}
+	for {
+		d.WaitForListRateLimit(ctx)
+		output, err := svc.GetDomainNames(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		for _, domainName := range output.Items {
+			for _, configuration := range domainName.DomainNameConfigurations {
+				if configuration.ApiGatewayDomainName != nil {
+					resources[strings.ToLower(*configuration.ApiGatewayDomainName)] = aws.ToString(domainName.DomainName)
+				}
+			}
+		}
+		if output.NextToken == nil {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+
+	return resources, nil
+}
+
+func listRoute53TargetApiGatewayApis(ctx context.Context, d *plugin.QueryData, region string) (map[string]string, error) {
+	cfg, err := getClientForRegion(ctx, d, region)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := map[string]string{}
+	restApis := apigateway.NewGetRestApisPaginator(apigateway.NewFromConfig(*cfg), &apigateway.GetRestApisInput{})
+	for restApis.HasMorePages() {
+		d.WaitForListRateLimit(ctx)
+		output, err := restApis.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, api := range output.Items {
+			resources[aws.ToString(api.Id)] = aws.ToString(api.Id)
+		}
+	}
+
+	svc := apigatewayv2.NewFromConfig(*cfg)
+	input := &apigatewayv2.GetApisInput{}
+	for {
+		d.WaitForListRateLimit(ctx)
+		output, err := svc.GetApis(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		for _, api := range output.Items {
+			resources[aws.ToString(api.ApiId)] = aws.ToString(api.ApiId)
+		}
+		if output.NextToken == nil {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+
+	return resources, nil
+}
+
+func listRoute53TargetEnvironments(ctx context.Context, d *plugin.QueryData, region string) (map[string]string, error) {
+	cfg, err := getClientForRegion(ctx, d, region)
+	if err != nil {
+		return nil, err
+	}
+	svc := elasticbeanstalk.NewFromConfig(*cfg)
+
+	resources := map[string]string{}
+	input := &elasticbeanstalk.DescribeEnvironmentsInput{
+		IncludeDeleted: aws.Bool(false),
+	}
+	for {
+		d.WaitForListRateLimit(ctx)
+		output, err := svc.DescribeEnvironments(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		for _, environment := range output.Environments {
+			if environment.CNAME != nil {
+				resources[strings.ToLower(*environment.CNAME)] = aws.ToString(environment.EnvironmentId)
+			}
+		}
+		if output.NextToken == nil {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+
+	return resources, nil
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/auditmanager"
 	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
 	"github.com/aws/aws-sdk-go-v2/service/backup"
+	"github.com/aws/aws-sdk-go-v2/service/bcmdataexports"
 	"github.com/aws/aws-sdk-go-v2/service/cloudcontrol"
 	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
 	"github.com/aws/aws-sdk-go-v2/service/cloudfront"
@@ -41,10 +42,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/codepipeline"
 	"github.com/aws/aws-sdk-go-v2/service/cognitoidentity"
 	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go-v2/service/computeoptimizer"
 	"github.com/aws/aws-sdk-go-v2/service/configservice"
 	"github.com/aws/aws-sdk-go-v2/service/costexplorer"
 	"github.com/aws/aws-sdk-go-v2/service/databasemigrationservice"
 	"github.com/aws/aws-sdk-go-v2/service/dax"
+	"github.com/aws/aws-sdk-go-v2/service/directconnect"
 	"github.com/aws/aws-sdk-go-v2/service/directoryservice"
 	"github.com/aws/aws-sdk-go-v2/service/dlm"
 	"github.com/aws/aws-sdk-go-v2/service/docdb"
@@ -122,6 +125,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ssoadmin"
 	"github.com/aws/aws-sdk-go-v2/service/sts"
 	"github.com/aws/aws-sdk-go-v2/service/transfer"
+	"github.com/aws/aws-sdk-go-v2/service/vpclattice"
 	"github.com/aws/aws-sdk-go-v2/service/waf"
 	"github.com/aws/aws-sdk-go-v2/service/wafregional"
 	"github.com/aws/aws-sdk-go-v2/service/wafv2"
@@ -142,7 +146,9 @@ import (
 	codepipelineEndpoint "github.com/aws/aws-sdk-go/service/codepipeline"
 	cognitoidentityEndpoint "github.com/aws/aws-sdk-go/service/cognitoidentity"
 	cognitoidentityproviderEndpoint "github.com/aws/aws-sdk-go/service/cognitoidentityprovider"
+	computeoptimizerEndpoint "github.com/aws/aws-sdk-go/service/computeoptimizer"
 	daxEndpoint "github.com/aws/aws-sdk-go/service/dax"
+	directconnectEndpoint "github.com/aws/aws-sdk-go/service/directconnect"
 	directoryserviceEndpoint "github.com/aws/aws-sdk-go/service/directoryservice"
 	dlmEndpoint "github.com/aws/aws-sdk-go/service/dlm"
 	drsEndpoint "github.com/aws/aws-sdk-go/service/drs"
@@ -183,6 +189,7 @@ import (
 	ssmEndpoint "github.com/aws/aws-sdk-go/service/ssm"
 	ssoEndpoint "github.com/aws/aws-sdk-go/service/sso"
 	transferEndpoint "github.com/aws/aws-sdk-go/service/transfer"
+	vpclatticeEndpoint "github.com/aws/aws-sdk-go/service/vpclattice"
 	wafregionalEndpoint "github.com/aws/aws-sdk-go/service/wafregional"
 	wafv2Endpoint "github.com/aws/aws-sdk-go/service/wafv2"
 	wellarchitectedEndpoint "github.com/aws/aws-sdk-go/service/wellarchitected"
@@ -329,6 +336,17 @@ func BackupClient(ctx context.Context, d *plugin.QueryData) (*backup.Client, err
 	return backup.NewFromConfig(*cfg), nil
 }
 
+func BCMDataExportsClient(ctx context.Context, d *plugin.QueryData) (*bcmdataexports.Client, error) {
+	// Data Exports is a global service that operates from a single
+	// region (bcm-data-exports.us-east-1.api.aws).
+	// https://docs.aws.amazon.com/general/latest/gr/billing.html
+	cfg, err := getClient(ctx, d, "us-east-1")
+	if err != nil {
+		return nil, err
+	}
+	return bcmdataexports.NewFromConfig(*cfg), nil
+}
+
 func CloudControlClient(ctx context.Context, d *plugin.QueryData) (*cloudcontrol.Client, error) {
 	// CloudControl returns GeneralServiceException in a lot of situations, which
 	// AWS SDK treats as retryable. This is frustrating because we end up retrying
@@ -494,6 +512,17 @@ func ConfigClient(ctx context.Context, d *plugin.QueryData) (*configservice.Clie
 	return configservice.NewFromConfig(*cfg), nil
 }
 
+func ComputeOptimizerClient(ctx context.Context, d *plugin.QueryData) (*computeoptimizer.Client, error) {
+	cfg, err := getClientForQuerySupportedRegion(ctx, d, computeoptimizerEndpoint.EndpointsID)
+	if err != nil {
+		return nil, err
+	}
+	if cfg == nil {
+		return nil, nil
+	}
+	return computeoptimizer.NewFromConfig(*cfg), nil
+}
+
 func CostExplorerClient(ctx context.Context, d *plugin.QueryData) (*costexplorer.Client, error) {
 	// Cost Explorer is a global service that operates from a single
 	// region (ce.us-east-1.amazonaws.com).
@@ -526,6 +555,28 @@ func DAXClient(ctx context.Context, d *plugin.QueryData) (*dax.Client, error) {
 	return dax.NewFromConfig(*cfg), nil
 }
 
+func DirectConnectClient(ctx context.Context, d *plugin.QueryData) (*directconnect.Client, error) {
+	cfg, err := getClientForQuerySupportedRegion(ctx, d, directconnectEndpoint.EndpointsID)
+	if err != nil {
+		return nil, err
+	}
+	if cfg == nil {
+		return nil, nil
+	}
+	return directconnect.NewFromConfig(*cfg), nil
+}
+
+func DirectConnectGatewayClient(ctx context.Context, d *plugin.QueryData) (*directconnect.Client, error) {
+	// Direct Connect gateways are global resources, which can be described
+	// from any region where Direct Connect is available. So, we can prefer /
+	// reuse the client region.
+	cfg, err := getClientForDefaultRegion(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+	return directconnect.NewFromConfig(*cfg), nil
+}
+
 func DirectoryServiceClient(ctx context.Context, d *plugin.QueryData) (*directoryservice.Client, error) {
 	cfg, err := getClientForQuerySupportedRegion(ctx, d, directoryserviceEndpoint.EndpointsID)
 	if err != nil {
@@ -1431,6 +1482,17 @@ func TransferClient(ctx context.Context, d *plugin.QueryData) (*transfer.Client,
 	return transfer.NewFromConfig(*cfg), nil
 }
 
+func VpcLatticeClient(ctx context.Context, d *plugin.QueryData) (*vpclattice.Client, error) {
+	cfg, err := getClientForQuerySupportedRegion(ctx, d, vpclatticeEndpoint.EndpointsID)
+	if err != nil {
+		return nil, err
+	}
+	if cfg == nil {
+		return nil, nil
+	}
+	return vpclattice.NewFromConfig(*cfg), nil
+}
+
 func WAFClient(ctx context.Context, d *plugin.QueryData) (*waf.Client, error) {
 	// WAF Classic a global service with a single DNS endpoint
 	// (waf.amazonaws.com).
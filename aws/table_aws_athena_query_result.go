@@ -0,0 +1,315 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/athena"
+	"github.com/aws/aws-sdk-go-v2/service/athena/types"
+
+	athenav1 "github.com/aws/aws-sdk-go/service/athena"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+func tableAwsAthenaQueryResult(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_athena_query_result",
+		Description: "AWS Athena Query Result",
+		List: &plugin.ListConfig{
+			Hydrate: listAwsAthenaQueryResults,
+			Tags:    map[string]string{"service": "athena", "action": "GetQueryResults"},
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "query_execution_id", Require: plugin.AnyOf},
+				{Name: "query_string", Require: plugin.AnyOf, CacheMatch: "exact"},
+				{Name: "workgroup", Require: plugin.Optional},
+				{Name: "catalog", Require: plugin.Optional},
+				{Name: "database", Require: plugin.Optional},
+				{Name: "output_location", Require: plugin.Optional},
+				{Name: "region", Require: plugin.Optional},
+			},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"ResourceNotFoundException"}),
+			},
+		},
+		GetMatrixItemFunc: SupportedRegionMatrix(athenav1.EndpointsID),
+		Columns: awsRegionalColumns([]*plugin.Column{
+			{
+				Name:        "query_execution_id",
+				Description: "The unique identifier of the query execution that produced the result.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "row_number",
+				Description: "The position of the row in the result set, starting at 1.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "data",
+				Description: "The row as a JSON object of column name to value. Numeric and boolean Athena types are returned as JSON numbers and booleans, all other types as strings.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "column_info",
+				Description: "The metadata that describes the columns of the result set, such as name, type, precision, scale and nullability.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "query_string",
+				Description: "The SQL query statement to run. If specified, a new query execution is started and its results are returned.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("query_string"),
+			},
+			{
+				Name:        "workgroup",
+				Description: "The name of the workgroup in which the query ran.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("QueryExecution.WorkGroup"),
+			},
+			{
+				Name:        "catalog",
+				Description: "The name of the data catalog used in the query execution.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("QueryExecution.QueryExecutionContext.Catalog"),
+			},
+			{
+				Name:        "database",
+				Description: "The name of the database used in the query execution.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("QueryExecution.QueryExecutionContext.Database"),
+			},
+			{
+				Name:        "output_location",
+				Description: "The location in Amazon S3 where the query results are stored.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("QueryExecution.ResultConfiguration.OutputLocation"),
+			},
+		}),
+	}
+}
+
+type athenaQueryResultRow struct {
+	QueryExecutionId *string
+	RowNumber        int64
+	Data             map[string]interface{}
+	ColumnInfo       []types.ColumnInfo
+	QueryExecution   *types.QueryExecution
+}
+
+//// LIST FUNCTION
+
+func listAwsAthenaQueryResults(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	region := d.EqualsQualString(matrixKeyRegion)
+
+	// Starting a query in every region of the connection would run it several
+	// times, so unless a region is requested only the default region is used
+	if d.EqualsQuals["region"] == nil {
+		defaultRegion, err := getDefaultRegion(ctx, d, h)
+		if err != nil {
+			return nil, err
+		}
+		if region != defaultRegion {
+			return nil, nil
+		}
+	}
+
+	// Create Session
+	svc, err := AthenaClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_athena_query_result.listAwsAthenaQueryResults", "connection_error", err)
+		return nil, err
+	}
+	if svc == nil {
+		// Unsupported region, return no data
+		return nil, nil
+	}
+
+	queryExecutionId := d.EqualsQualString("query_execution_id")
+	startedQuery := queryExecutionId == ""
+	if startedQuery {
+		params := &athena.StartQueryExecutionInput{
+			QueryString: aws.String(d.EqualsQualString("query_string")),
+		}
+		if d.EqualsQuals["workgroup"] != nil {
+			params.WorkGroup = aws.String(d.EqualsQualString("workgroup"))
+		}
+		if d.EqualsQuals["catalog"] != nil || d.EqualsQuals["database"] != nil {
+			params.QueryExecutionContext = &types.QueryExecutionContext{}
+			if d.EqualsQuals["catalog"] != nil {
+				params.QueryExecutionContext.Catalog = aws.String(d.EqualsQualString("catalog"))
+			}
+			if d.EqualsQuals["database"] != nil {
+				params.QueryExecutionContext.Database = aws.String(d.EqualsQualString("database"))
+			}
+		}
+		if d.EqualsQuals["output_location"] != nil {
+			params.ResultConfiguration = &types.ResultConfiguration{
+				OutputLocation: aws.String(d.EqualsQualString("output_location")),
+			}
+		}
+
+		d.WaitForListRateLimit(ctx)
+		output, err := svc.StartQueryExecution(ctx, params)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_athena_query_result.listAwsAthenaQueryResults", "start_query_error", err)
+			return nil, err
+		}
+		queryExecutionId = aws.ToString(output.QueryExecutionId)
+	}
+
+	execution, err := waitForAthenaQueryExecution(ctx, d, svc, queryExecutionId)
+	if err != nil {
+		// A query started by this table keeps running, and scanning data, after
+		// the Steampipe query is cancelled, so stop it
+		if startedQuery && ctx.Err() != nil {
+			stopAthenaQueryExecution(ctx, svc, queryExecutionId)
+		}
+		plugin.Logger(ctx).Error("aws_athena_query_result.listAwsAthenaQueryResults", "query_execution_error", err)
+		return nil, err
+	}
+
+	// Results of DML statements include the column names as the first row
+	skipHeader := execution.StatementType == types.StatementTypeDml
+
+	paginator := athena.NewGetQueryResultsPaginator(svc, &athena.GetQueryResultsInput{
+		QueryExecutionId: aws.String(queryExecutionId),
+		MaxResults:       aws.Int32(1000),
+	}, func(o *athena.GetQueryResultsPaginatorOptions) {
+		o.StopOnDuplicateToken = true
+	})
+
+	rowNumber := int64(0)
+	for paginator.HasMorePages() {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_athena_query_result.listAwsAthenaQueryResults", "api_error", err)
+			return nil, err
+		}
+
+		if output.ResultSet == nil {
+			continue
+		}
+
+		var columns []types.ColumnInfo
+		if output.ResultSet.ResultSetMetadata != nil {
+			columns = output.ResultSet.ResultSetMetadata.ColumnInfo
+		}
+
+		for _, row := range output.ResultSet.Rows {
+			if skipHeader {
+				skipHeader = false
+				continue
+			}
+			rowNumber++
+			d.StreamListItem(ctx, &athenaQueryResultRow{
+				QueryExecutionId: aws.String(queryExecutionId),
+				RowNumber:        rowNumber,
+				Data:             athenaRowToMap(columns, row),
+				ColumnInfo:       columns,
+				QueryExecution:   execution,
+			})
+
+			// Context may get cancelled due to manual cancellation or if the limit has been reached
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// stopAthenaQueryExecution stops a running query execution. The query context
+// is usually cancelled at this point, so the request uses a detached context.
+func stopAthenaQueryExecution(ctx context.Context, svc *athena.Client, queryExecutionId string) {
+	stopCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+	defer cancel()
+
+	_, err := svc.StopQueryExecution(stopCtx, &athena.StopQueryExecutionInput{
+		QueryExecutionId: aws.String(queryExecutionId),
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_athena_query_result.stopAthenaQueryExecution", "api_error", err)
+	}
+}
+
+// waitForAthenaQueryExecution polls the query execution until it reaches a
+// terminal state, and returns an error unless the query succeeded.
+func waitForAthenaQueryExecution(ctx context.Context, d *plugin.QueryData, svc *athena.Client, queryExecutionId string) (*types.QueryExecution, error) {
+	delay := 500 * time.Millisecond
+	for {
+		d.WaitForListRateLimit(ctx)
+		output, err := svc.GetQueryExecution(ctx, &athena.GetQueryExecutionInput{
+			QueryExecutionId: aws.String(queryExecutionId),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		execution := output.QueryExecution
+		if execution == nil || execution.Status == nil {
+			return nil, fmt.Errorf("query execution %s has no status", queryExecutionId)
+		}
+
+		switch execution.Status.State {
+		case types.QueryExecutionStateSucceeded:
+			return execution, nil
+		case types.QueryExecutionStateFailed, types.QueryExecutionStateCancelled:
+			return nil, fmt.Errorf("query execution %s %s: %s", queryExecutionId, strings.ToLower(string(execution.Status.State)), aws.ToString(execution.Status.StateChangeReason))
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+		if delay < 5*time.Second {
+			delay *= 2
+		}
+	}
+}
+
+// athenaRowToMap converts a result set row to a map of column name to value,
+// using the column type to return numbers and booleans as native values.
+func athenaRowToMap(columns []types.ColumnInfo, row types.Row) map[string]interface{} {
+	data := make(map[string]interface{}, len(row.Data))
+	for i, datum := range row.Data {
+		name := strconv.Itoa(i)
+		columnType := ""
+		if i < len(columns) {
+			name = aws.ToString(columns[i].Name)
+			columnType = aws.ToString(columns[i].Type)
+		}
+		if datum.VarCharValue == nil {
+			data[name] = nil
+			continue
+		}
+		data[name] = athenaDatumValue(columnType, *datum.VarCharValue)
+	}
+	return data
+}
+
+func athenaDatumValue(columnType string, value string) interface{} {
+	switch strings.ToLower(columnType) {
+	case "tinyint", "smallint", "integer", "bigint", "double", "float", "real", "decimal":
+		// json.Number avoids losing precision on large integers and decimals
+		if _, err := strconv.ParseFloat(value, 64); err == nil {
+			return json.Number(value)
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+	return value
+}
@@ -0,0 +1,427 @@
+package aws
+
+import (
+	"context"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudfront"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsCloudFrontAccessLog(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_cloudfront_access_log",
+		Description: "AWS CloudFront Access Log",
+		List: &plugin.ListConfig{
+			Hydrate: listCloudFrontAccessLogs,
+			Tags:    map[string]string{"service": "s3", "action": "GetObject"},
+			KeyColumns: append(plugin.KeyColumnSlice{
+				{Name: "distribution_id", Require: plugin.Required},
+			}, accessLogKeyColumns()...),
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"NoSuchDistribution"}),
+			},
+		},
+		Columns: awsRegionalColumns([]*plugin.Column{
+			{
+				Name:        "distribution_id",
+				Description: "The identifier of the distribution.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("distribution_id"),
+			},
+			{
+				Name:        "timestamp",
+				Description: "The time when the CloudFront server finished responding to the request.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "location",
+				Description: "The edge location that served the request, identified by a three-letter code and an assigned number, e.g. DFW3.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "bytes",
+				Description: "The total number of bytes that the server sent to the viewer in response to the request, including headers.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "request_ip",
+				Description: "The IP address of the viewer that made the request.",
+				Type:        proto.ColumnType_IPADDR,
+			},
+			{
+				Name:        "method",
+				Description: "The HTTP request method received from the viewer.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "host",
+				Description: "The domain name of the CloudFront distribution.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "uri",
+				Description: "The portion of the request URL that identifies the path and object.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "status",
+				Description: "The HTTP status code of the server's response, or 000 if the viewer closed the connection before the server responded.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "referrer",
+				Description: "The value of the Referer header in the request.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "user_agent",
+				Description: "The value of the User-Agent header in the request.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "query_string",
+				Description: "The query string portion of the request URL, if any.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "cookie",
+				Description: "The Cookie header in the request, if cookie logging is enabled.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "result_type",
+				Description: "How the server classified the response after the last byte left the server, e.g. Hit, RefreshHit, Miss, LimitExceeded, CapacityExceeded, Error or Redirect.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "request_id",
+				Description: "An opaque string that uniquely identifies the request.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "host_header",
+				Description: "The value that the viewer included in the Host header of the request.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "request_protocol",
+				Description: "The protocol of the viewer request, e.g. http, https, ws or wss.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "request_bytes",
+				Description: "The total number of bytes of data that the viewer included in the request, including headers.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "time_taken",
+				Description: "The number of seconds between the time the server received the request and the time it wrote the last byte of the response.",
+				Type:        proto.ColumnType_DOUBLE,
+			},
+			{
+				Name:        "forwarded_for",
+				Description: "The value of the X-Forwarded-For header, if the viewer used an HTTP proxy or load balancer.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "ssl_protocol",
+				Description: "The TLS protocol that the viewer and server negotiated, for HTTPS requests.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "ssl_cipher",
+				Description: "The TLS cipher that the viewer and server negotiated, for HTTPS requests.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "response_result_type",
+				Description: "How the server classified the response just before returning it to the viewer.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "http_version",
+				Description: "The HTTP version that the viewer specified in the request.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "fle_status",
+				Description: "The field-level encryption status, if field-level encryption is configured for the distribution.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "fle_encrypted_fields",
+				Description: "The number of field-level encryption fields that the server encrypted and forwarded to the origin.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "request_port",
+				Description: "The port number of the request from the viewer.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "time_to_first_byte",
+				Description: "The number of seconds between receiving the request and writing the first byte of the response, as measured on the server.",
+				Type:        proto.ColumnType_DOUBLE,
+			},
+			{
+				Name:        "detailed_result_type",
+				Description: "A more detailed result type, e.g. the type of origin error or OriginShieldHit.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "content_type",
+				Description: "The value of the Content-Type header of the response.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "content_length",
+				Description: "The value of the Content-Length header of the response.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "range_start",
+				Description: "The starting value of the range, when the response contains the Content-Range header.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "range_end",
+				Description: "The ending value of the range, when the response contains the Content-Range header.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "log_file_key",
+				Description: "The S3 object key of the log file containing this entry.",
+				Type:        proto.ColumnType_STRING,
+			},
+		}),
+	}
+}
+
+type cloudFrontAccessLogEntry struct {
+	Timestamp          *time.Time
+	Location           *string
+	Bytes              *int64
+	RequestIp          *string
+	Method             *string
+	Host               *string
+	Uri                *string
+	Status             *int64
+	Referrer           *string
+	UserAgent          *string
+	QueryString        *string
+	Cookie             *string
+	ResultType         *string
+	RequestId          *string
+	HostHeader         *string
+	RequestProtocol    *string
+	RequestBytes       *int64
+	TimeTaken          *float64
+	ForwardedFor       *string
+	SslProtocol        *string
+	SslCipher          *string
+	ResponseResultType *string
+	HttpVersion        *string
+	FleStatus          *string
+	FleEncryptedFields *int64
+	RequestPort        *int64
+	TimeToFirstByte    *float64
+	DetailedResultType *string
+	ContentType        *string
+	ContentLength      *int64
+	RangeStart         *int64
+	RangeEnd           *int64
+	LogFileKey         string
+}
+
+// The fields of the standard log file format, in the order they are written
+// when the file has no #Fields header
+var cloudFrontAccessLogDefaultFields = []string{
+	"date", "time", "x-edge-location", "sc-bytes", "c-ip", "cs-method", "cs(Host)", "cs-uri-stem", "sc-status",
+	"cs(Referer)", "cs(User-Agent)", "cs-uri-query", "cs(Cookie)", "x-edge-result-type", "x-edge-request-id",
+	"x-host-header", "cs-protocol", "cs-bytes", "time-taken", "x-forwarded-for", "ssl-protocol", "ssl-cipher",
+	"x-edge-response-result-type", "cs-protocol-version", "fle-status", "fle-encrypted-fields", "c-port",
+	"time-to-first-byte", "x-edge-detailed-result-type", "sc-content-type", "sc-content-len", "sc-range-start",
+	"sc-range-end",
+}
+
+//// LIST FUNCTION
+
+func listCloudFrontAccessLogs(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	distributionId := d.EqualsQualString("distribution_id")
+
+	// Get client
+	svc, err := CloudFrontClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_cloudfront_access_log.listCloudFrontAccessLogs", "client_error", err)
+		return nil, err
+	}
+
+	config, err := svc.GetDistributionConfig(ctx, &cloudfront.GetDistributionConfigInput{
+		Id: aws.String(distributionId),
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_cloudfront_access_log.listCloudFrontAccessLogs", "api_error", err)
+		return nil, err
+	}
+
+	if config.DistributionConfig == nil {
+		return nil, nil
+	}
+	logging := config.DistributionConfig.Logging
+	if logging == nil || aws.ToString(logging.Bucket) == "" {
+		return nil, nil
+	}
+
+	// The logging bucket is configured as the bucket's domain name, e.g.
+	// my-logs.s3.amazonaws.com
+	bucket := strings.Split(aws.ToString(logging.Bucket), ".s3.")[0]
+	prefix := aws.ToString(logging.Prefix)
+
+	bucketRegion, err := getBucketRegionByName(ctx, d, h, bucket)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_cloudfront_access_log.listCloudFrontAccessLogs", "bucket_region_error", err, "bucket", bucket)
+		return nil, err
+	}
+	s3Svc, err := S3Client(ctx, d, bucketRegion)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_cloudfront_access_log.listCloudFrontAccessLogs", "s3_client_error", err)
+		return nil, err
+	}
+
+	start, end := accessLogTimeRange(d)
+
+	prefixes := cloudFrontAccessLogPrefixes(prefix, distributionId, start, end)
+
+	includeKey := func(key string) bool {
+		parts := strings.Split(path.Base(key), ".")
+		if len(parts) < 3 || parts[0] != distributionId {
+			return false
+		}
+		hour, err := time.Parse("2006-01-02-15", parts[1])
+		if err != nil {
+			return false
+		}
+		// CloudFront may deliver entries for an hour in a later file, so keep
+		// files written up to an hour after the end of the range
+		return !hour.Add(time.Hour).Before(start) && !hour.After(end.Add(time.Hour))
+	}
+
+	newParser := func(key string) accessLogLineParser {
+		fields := cloudFrontAccessLogDefaultFields
+		return func(line string) interface{} {
+			if strings.HasPrefix(line, "#Fields:") {
+				fields = strings.Fields(strings.TrimPrefix(line, "#Fields:"))
+				return nil
+			}
+			if strings.HasPrefix(line, "#") || strings.TrimSpace(line) == "" {
+				return nil
+			}
+			return parseCloudFrontAccessLogLine(key, fields, line)
+		}
+	}
+
+	err = streamAccessLogFiles(ctx, d, s3Svc, bucket, prefixes, includeKey, newParser)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_cloudfront_access_log.listCloudFrontAccessLogs", "api_error", err)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// cloudFrontAccessLogPrefixes returns the object key prefixes of the log files
+// for the time range, one per day, i.e.
+// bucket[/prefix]/distribution-ID.YYYY-MM-DD-HH.unique-ID.gz
+//
+// CloudFront may deliver entries for an hour in a later file, so the files of
+// the hour after the end of the range are listed too, which may fall on the
+// next day.
+func cloudFrontAccessLogPrefixes(prefix string, distributionId string, start time.Time, end time.Time) []string {
+	var prefixes []string
+	for _, day := range accessLogDays(start, end.Add(time.Hour)) {
+		prefixes = append(prefixes, prefix+distributionId+"."+day.Format("2006-01-02")+"-")
+	}
+	return prefixes
+}
+
+// parseCloudFrontAccessLogLine parses a tab delimited entry of a standard log
+// file, using the field names from the file header.
+func parseCloudFrontAccessLogLine(key string, fields []string, line string) interface{} {
+	values := strings.Split(line, "\t")
+	index := make(map[string]int, len(fields))
+	for i, field := range fields {
+		index[field] = i
+	}
+	f := accessLogFields(values)
+	i := func(name string) int {
+		if n, ok := index[name]; ok {
+			return n
+		}
+		return -1
+	}
+
+	entry := &cloudFrontAccessLogEntry{
+		Location:           f.String(i("x-edge-location")),
+		Bytes:              f.Int(i("sc-bytes")),
+		RequestIp:          f.String(i("c-ip")),
+		Method:             f.String(i("cs-method")),
+		Host:               f.String(i("cs(Host)")),
+		Uri:                f.String(i("cs-uri-stem")),
+		Status:             f.Int(i("sc-status")),
+		Referrer:           cloudFrontAccessLogUnescape(f.String(i("cs(Referer)"))),
+		UserAgent:          cloudFrontAccessLogUnescape(f.String(i("cs(User-Agent)"))),
+		QueryString:        f.String(i("cs-uri-query")),
+		Cookie:             f.String(i("cs(Cookie)")),
+		ResultType:         f.String(i("x-edge-result-type")),
+		RequestId:          f.String(i("x-edge-request-id")),
+		HostHeader:         f.String(i("x-host-header")),
+		RequestProtocol:    f.String(i("cs-protocol")),
+		RequestBytes:       f.Int(i("cs-bytes")),
+		TimeTaken:          f.Float(i("time-taken")),
+		ForwardedFor:       f.String(i("x-forwarded-for")),
+		SslProtocol:        f.String(i("ssl-protocol")),
+		SslCipher:          f.String(i("ssl-cipher")),
+		ResponseResultType: f.String(i("x-edge-response-result-type")),
+		HttpVersion:        f.String(i("cs-protocol-version")),
+		FleStatus:          f.String(i("fle-status")),
+		FleEncryptedFields: f.Int(i("fle-encrypted-fields")),
+		RequestPort:        f.Int(i("c-port")),
+		TimeToFirstByte:    f.Float(i("time-to-first-byte")),
+		DetailedResultType: f.String(i("x-edge-detailed-result-type")),
+		ContentType:        f.String(i("sc-content-type")),
+		ContentLength:      f.Int(i("sc-content-len")),
+		RangeStart:         f.Int(i("sc-range-start")),
+		RangeEnd:           f.Int(i("sc-range-end")),
+		LogFileKey:         key,
+	}
+
+	// Date and time are logged as separate fields, in UTC
+	if ts, err := time.Parse("2006-01-02 15:04:05", f.raw(i("date"))+" "+f.raw(i("time"))); err == nil {
+		entry.Timestamp = aws.Time(ts)
+	}
+
+	return entry
+}
+
+// CloudFront URL encodes spaces and other special characters in some header
+// values, e.g. the user agent.
+func cloudFrontAccessLogUnescape(v *string) *string {
+	if v == nil {
+		return nil
+	}
+	unescaped, err := url.PathUnescape(*v)
+	if err != nil {
+		return v
+	}
+	return aws.String(unescaped)
+}
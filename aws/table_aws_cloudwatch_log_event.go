@@ -7,7 +7,6 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
-	cloudwatchlogsTypes "github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
 
 	cloudwatchlogsv1 "github.com/aws/aws-sdk-go/service/cloudwatchlogs"
 
@@ -193,10 +192,14 @@ func listCloudwatchLogEvents(ctx context.Context, d *plugin.QueryData, _ *plugin
 
 //// TRANSFORM FUNCTIONS
 
+// cloudwatchLogsMesssageJson parses the (trimmed) message value of a log
+// event, and is shared by all tables that return CloudWatch log events.
 func cloudwatchLogsMesssageJson(_ context.Context, d *transform.TransformData) (interface{}, error) {
-	event := d.HydrateItem.(cloudwatchlogsTypes.FilteredLogEvent)
+	if d.Value == nil {
+		return nil, nil
+	}
 	var eventMessage interface{}
-	err := json.Unmarshal([]byte(*event.Message), &eventMessage)
+	err := json.Unmarshal([]byte(types.ToString(d.Value)), &eventMessage)
 	if err != nil {
 		return nil, nil
 	}
@@ -0,0 +1,260 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+
+	cloudwatchlogsv1 "github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+const (
+	// Default length of a live tail session, if duration_seconds is not specified
+	cloudwatchLogTailDefaultDuration = 60
+	// CloudWatch Logs closes live tail sessions after 3 hours
+	cloudwatchLogTailMaxDuration = 3 * 60 * 60
+)
+
+func tableAwsCloudwatchLogTailListKeyColumns() []*plugin.KeyColumn {
+	return []*plugin.KeyColumn{
+		{Name: "log_group_name"},
+		{Name: "log_stream_name_prefix", Require: plugin.Optional, CacheMatch: "exact"},
+		{Name: "filter", Require: plugin.Optional, CacheMatch: "exact"},
+		{Name: "duration_seconds", Require: plugin.Optional, CacheMatch: "exact"},
+		{Name: "region", Require: plugin.Optional},
+	}
+}
+
+//// TABLE DEFINITION
+
+func tableAwsCloudwatchLogTail(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_cloudwatch_log_tail",
+		Description: "AWS CloudWatch Log Tail",
+		List: &plugin.ListConfig{
+			Hydrate:    listCloudwatchLogTailEvents,
+			Tags:       map[string]string{"service": "logs", "action": "StartLiveTail"},
+			KeyColumns: tableAwsCloudwatchLogTailListKeyColumns(),
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"ResourceNotFoundException"}),
+			},
+		},
+		GetMatrixItemFunc: SupportedRegionMatrix(cloudwatchlogsv1.EndpointsID),
+		Columns: awsRegionalColumns([]*plugin.Column{
+			{
+				Name:        "log_group_name",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("LogGroupIdentifier").Transform(logGroupNameFromIdentifier),
+				Description: "The name of the log group that ingested this log event.",
+			},
+			{
+				Name:        "log_group_identifier",
+				Type:        proto.ColumnType_STRING,
+				Description: "The name or ARN of the log group that ingested this log event.",
+			},
+			{
+				Name:        "log_stream_name",
+				Type:        proto.ColumnType_STRING,
+				Description: "The name of the log stream that ingested this log event.",
+			},
+			{
+				Name:        "timestamp",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("Timestamp").Transform(transform.UnixMsToTimestamp),
+				Description: "The time when the event occurred.",
+			},
+			{
+				Name:        "ingestion_time",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("IngestionTime").Transform(transform.UnixMsToTimestamp),
+				Description: "The time when the event was ingested.",
+			},
+			{
+				Name:        "message",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Message").Transform(trim),
+				Description: "The data contained in the log event.",
+			},
+			{
+				Name:        "message_json",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Message").Transform(trim).Transform(cloudwatchLogsMesssageJson),
+				Description: "The data contained in the log event in json format. Only if data is valid json string.",
+			},
+			{
+				Name:        "sampled",
+				Type:        proto.ColumnType_BOOL,
+				Description: "True if more than 500 log events matched the session in the second this event was received, and the events were sampled down to 500.",
+			},
+			{
+				Name:        "session_id",
+				Type:        proto.ColumnType_STRING,
+				Description: "The unique ID generated by CloudWatch Logs to identify the live tail session.",
+			},
+			{
+				Name:        "filter",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("filter"),
+				Description: "Filter pattern for the live tail session.",
+			},
+			{
+				Name:        "log_stream_name_prefix",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("log_stream_name_prefix"),
+				Description: "If specified, only log events in the log streams that have names that start with this prefix are returned. Can only be used with a single log group.",
+			},
+			{
+				Name:        "duration_seconds",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromQual("duration_seconds"),
+				Description: "The number of seconds to keep the live tail session open. Defaults to 60 seconds, and can be at most 3 hours.",
+			},
+		}),
+	}
+}
+
+type liveTailEvent struct {
+	types.LiveTailSessionLogEvent
+	Sampled   bool
+	SessionId *string
+}
+
+//// LIST FUNCTION
+
+func listCloudwatchLogTailEvents(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	region := d.EqualsQualString(matrixKeyRegion)
+
+	// Get client
+	svc, err := CloudWatchLogsClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_cloudwatch_log_tail.listCloudwatchLogTailEvents", "get_client_error", err)
+		return nil, err
+	}
+
+	// Live tail requires the ARN of each log group
+	commonData, err := getCommonColumns(ctx, d, nil)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_cloudwatch_log_tail.listCloudwatchLogTailEvents", "common_data_error", err)
+		return nil, err
+	}
+	commonColumnData := commonData.(*awsCommonColumnData)
+
+	var logGroupNames []string
+	if value := getQualsValueByColumn(d.Quals, "log_group_name", "string"); value != nil {
+		switch item := value.(type) {
+		case string:
+			logGroupNames = []string{item}
+		case []*string:
+			logGroupNames = aws.ToStringSlice(item)
+		}
+	}
+
+	params := &cloudwatchlogs.StartLiveTailInput{}
+	for _, name := range logGroupNames {
+		params.LogGroupIdentifiers = append(params.LogGroupIdentifiers, logGroupArnFromName(commonColumnData.Partition, region, commonColumnData.AccountId, name))
+	}
+	if len(params.LogGroupIdentifiers) == 0 {
+		return nil, nil
+	}
+	if len(params.LogGroupIdentifiers) > 10 {
+		return nil, fmt.Errorf("a live tail session supports at most 10 log groups, got %d", len(params.LogGroupIdentifiers))
+	}
+
+	if d.EqualsQuals["filter"] != nil {
+		params.LogEventFilterPattern = aws.String(d.EqualsQualString("filter"))
+	}
+	if d.EqualsQuals["log_stream_name_prefix"] != nil {
+		params.LogStreamNamePrefixes = []string{d.EqualsQualString("log_stream_name_prefix")}
+	}
+
+	duration := int64(cloudwatchLogTailDefaultDuration)
+	if d.EqualsQuals["duration_seconds"] != nil {
+		duration = d.EqualsQuals["duration_seconds"].GetInt64Value()
+		if duration < 1 || duration > cloudwatchLogTailMaxDuration {
+			return nil, fmt.Errorf("duration_seconds must be between 1 and %d", cloudwatchLogTailMaxDuration)
+		}
+	}
+
+	// Closing the session context ends the live tail session
+	sessionCtx, cancel := context.WithTimeout(ctx, time.Duration(duration)*time.Second)
+	defer cancel()
+
+	d.WaitForListRateLimit(ctx)
+	output, err := svc.StartLiveTail(sessionCtx, params)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_cloudwatch_log_tail.listCloudwatchLogTailEvents", "api_error", err)
+		return nil, err
+	}
+
+	stream := output.GetStream()
+	defer stream.Close()
+
+	var sessionId *string
+	for {
+		select {
+		case <-sessionCtx.Done():
+			return nil, nil
+		case event, ok := <-stream.Events():
+			if !ok {
+				// The stream is closed when the session ends, either normally or due to an error
+				if err := stream.Err(); err != nil && sessionCtx.Err() == nil {
+					plugin.Logger(ctx).Error("aws_cloudwatch_log_tail.listCloudwatchLogTailEvents", "stream_error", err)
+					return nil, err
+				}
+				return nil, nil
+			}
+
+			switch e := event.(type) {
+			case *types.StartLiveTailResponseStreamMemberSessionStart:
+				sessionId = e.Value.SessionId
+			case *types.StartLiveTailResponseStreamMemberSessionUpdate:
+				sampled := e.Value.SessionMetadata != nil && e.Value.SessionMetadata.Sampled
+				for _, logEvent := range e.Value.SessionResults {
+					d.StreamListItem(ctx, &liveTailEvent{
+						LiveTailSessionLogEvent: logEvent,
+						Sampled:                 sampled,
+						SessionId:               sessionId,
+					})
+
+					// Context may get cancelled due to manual cancellation or if the limit has been reached
+					if d.RowsRemaining(ctx) == 0 {
+						return nil, nil
+					}
+				}
+			}
+		}
+	}
+}
+
+func logGroupArnFromName(partition string, region string, accountId string, name string) string {
+	if strings.HasPrefix(name, "arn:") {
+		// The ARN returned by DescribeLogGroups ends with ":*", which live tail does not accept
+		return strings.TrimSuffix(name, ":*")
+	}
+	return fmt.Sprintf("arn:%s:logs:%s:%s:log-group:%s", partition, region, accountId, name)
+}
+
+//// TRANSFORM FUNCTIONS
+
+func logGroupNameFromIdentifier(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	value, _ := d.Value.(*string)
+	identifier := aws.ToString(value)
+	if !strings.HasPrefix(identifier, "arn:") {
+		return identifier, nil
+	}
+	// arn:aws:logs:us-east-1:123456789012:log-group:my-log-group
+	parts := strings.SplitN(identifier, ":log-group:", 2)
+	if len(parts) != 2 {
+		return identifier, nil
+	}
+	return strings.TrimSuffix(parts[1], ":*"), nil
+}
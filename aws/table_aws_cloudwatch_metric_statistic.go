@@ -0,0 +1,195 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+
+	cloudwatchv1 "github.com/aws/aws-sdk-go/service/cloudwatch"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// Statistics returned if the query has no statistic quals
+var cwMetricStatisticDefaultStatistics = []string{"Average", "Maximum", "Minimum", "SampleCount", "Sum"}
+
+//// TABLE DEFINITION
+
+func tableAwsCloudWatchMetricStatistic(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_cloudwatch_metric_statistic",
+		Description: "AWS CloudWatch Metric Statistic",
+		List: &plugin.ListConfig{
+			Hydrate: listCloudWatchMetricStatistics,
+			Tags:    map[string]string{"service": "cloudwatch", "action": "GetMetricData"},
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "namespace", Require: plugin.Required},
+				{Name: "metric_name", Require: plugin.Required},
+				{Name: "dimensions", Require: plugin.Optional, CacheMatch: "exact"},
+				{Name: "statistic", Require: plugin.Optional},
+				{Name: "period", Require: plugin.Optional, CacheMatch: "exact"},
+				{Name: "unit", Require: plugin.Optional, CacheMatch: "exact"},
+				{Name: "timestamp", Operators: []string{">", ">=", "=", "<", "<="}, Require: plugin.Optional, CacheMatch: "exact"},
+			},
+		},
+		GetMatrixItemFunc: SupportedRegionMatrix(cloudwatchv1.EndpointsID),
+		Columns: awsRegionalColumns([]*plugin.Column{
+			{
+				Name:        "namespace",
+				Description: "The namespace of the metric.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "metric_name",
+				Description: "The name of the metric.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "dimensions",
+				Description: "The dimensions of the metric, as an array of Name and Value objects. All dimensions of the metric must be specified.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromQual("dimensions"),
+			},
+			{
+				Name:        "statistic",
+				Description: "The statistic of the data point, e.g. Average, Sum, p99 or tm90. Defaults to Average, Maximum, Minimum, SampleCount and Sum.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "timestamp",
+				Description: "The start of the period of the data point.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "value",
+				Description: "The value of the statistic for the data point.",
+				Type:        proto.ColumnType_DOUBLE,
+			},
+			{
+				Name:        "period",
+				Description: "The granularity, in seconds, of the data points. Defaults to the smallest period available for the start of the time range.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "unit",
+				Description: "The unit to return data points for. If not specified, data points of all units are returned.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("unit"),
+			},
+			{
+				Name:        "label",
+				Description: "The label of the data returned by CloudWatch.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "status_code",
+				Description: "The status of the returned data. Complete indicates that all data points in the requested time range were returned. PartialData means that an incomplete set of data points were returned.",
+				Type:        proto.ColumnType_STRING,
+			},
+		}),
+	}
+}
+
+type cwMetricStatisticRow struct {
+	Namespace  *string
+	MetricName *string
+	Statistic  string
+	Timestamp  time.Time
+	Value      float64
+	Period     int32
+	Label      *string
+	StatusCode types.StatusCode
+}
+
+//// LIST FUNCTION
+
+func listCloudWatchMetricStatistics(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	namespace := d.EqualsQualString("namespace")
+	metricName := d.EqualsQualString("metric_name")
+
+	dimensions := []types.Dimension{}
+	if dimensionsString := d.EqualsQuals["dimensions"].GetJsonbValue(); dimensionsString != "" {
+		if err := json.Unmarshal([]byte(dimensionsString), &dimensions); err != nil {
+			plugin.Logger(ctx).Error("aws_cloudwatch_metric_statistic.listCloudWatchMetricStatistics", "unmarshal_error", err)
+			return nil, fmt.Errorf("failed to unmarshal dimensions %v: %v", dimensionsString, err)
+		}
+	}
+
+	statistics := cwMetricStatisticDefaultStatistics
+	switch value := getQualsValueByColumn(d.Quals, "statistic", "string").(type) {
+	case string:
+		statistics = []string{value}
+	case []*string:
+		statistics = aws.ToStringSlice(value)
+	}
+
+	startTime, endTime := getCWTimeRange(d, time.Now().AddDate(0, 0, -1))
+
+	period := getCWMinPeriodForStartTime(startTime)
+	if d.EqualsQuals["period"] != nil {
+		period = int32(d.EqualsQuals["period"].GetInt64Value())
+	}
+
+	// A single timestamp is the start of a period
+	if !endTime.After(startTime) {
+		endTime = startTime.Add(time.Duration(period) * time.Second)
+	}
+
+	queries := make([]types.MetricDataQuery, 0, len(statistics))
+	for i, statistic := range statistics {
+		metricStat := &types.MetricStat{
+			Metric: &types.Metric{
+				Namespace:  aws.String(namespace),
+				MetricName: aws.String(metricName),
+				Dimensions: dimensions,
+			},
+			Period: aws.Int32(period),
+			Stat:   aws.String(statistic),
+		}
+		if d.EqualsQuals["unit"] != nil {
+			metricStat.Unit = types.StandardUnit(d.EqualsQualString("unit"))
+		}
+		queries = append(queries, types.MetricDataQuery{
+			Id:         aws.String(fmt.Sprintf("m%d", i)),
+			MetricStat: metricStat,
+		})
+	}
+
+	results, err := getCWMetricData(ctx, d, queries, startTime, endTime)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_cloudwatch_metric_statistic.listCloudWatchMetricStatistics", "api_error", err)
+		return nil, err
+	}
+
+	for i, statistic := range statistics {
+		result, ok := results[fmt.Sprintf("m%d", i)]
+		if !ok {
+			continue
+		}
+		for j := range result.Timestamps {
+			d.StreamListItem(ctx, &cwMetricStatisticRow{
+				Namespace:  aws.String(namespace),
+				MetricName: aws.String(metricName),
+				Statistic:  statistic,
+				Timestamp:  result.Timestamps[j],
+				Value:      result.Values[j],
+				Period:     period,
+				Label:      result.Label,
+				StatusCode: result.StatusCode,
+			})
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,214 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/computeoptimizer"
+	"github.com/aws/aws-sdk-go-v2/service/computeoptimizer/types"
+
+	computeoptimizerv1 "github.com/aws/aws-sdk-go/service/computeoptimizer"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsComputeOptimizerEBSVolumeRecommendation(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_computeoptimizer_ebs_volume_recommendation",
+		Description: "AWS Compute Optimizer EBS Volume Recommendation",
+		List: &plugin.ListConfig{
+			Hydrate: listComputeOptimizerEBSVolumeRecommendations,
+			Tags:    map[string]string{"service": "compute-optimizer", "action": "GetEBSVolumeRecommendations"},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"OptInRequiredException", "ResourceNotFoundException"}),
+			},
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "volume_arn", Require: plugin.Optional},
+				{Name: "finding", Require: plugin.Optional},
+			},
+		},
+		GetMatrixItemFunc: SupportedRegionMatrix(computeoptimizerv1.EndpointsID),
+		Columns: awsRegionalColumns([]*plugin.Column{
+			{
+				Name:        "volume_arn",
+				Description: "The Amazon Resource Name (ARN) of the volume.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "finding",
+				Description: "The finding of the volume. Possible values are: NotOptimized|Optimized.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "current_volume_type",
+				Description: "The volume type of the volume.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("CurrentConfiguration.VolumeType"),
+			},
+			{
+				Name:        "current_volume_size",
+				Description: "The size of the volume, in GiB.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("CurrentConfiguration.VolumeSize"),
+			},
+			{
+				Name:        "current_performance_risk",
+				Description: "The risk of the volume not meeting the performance needs of its workloads. Possible values are: VeryLow|Low|Medium|High.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "recommended_volume_type",
+				Description: "The volume type of the top ranked recommendation option.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Option.Configuration.VolumeType"),
+			},
+			{
+				Name:        "recommended_volume_size",
+				Description: "The size of the top ranked recommendation option, in GiB.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("Option.Configuration.VolumeSize"),
+			},
+			{
+				Name:        "recommended_performance_risk",
+				Description: "The performance risk of the top ranked recommendation option.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Option.PerformanceRisk"),
+			},
+			{
+				Name:        "estimated_monthly_savings",
+				Description: "The estimated monthly savings of the top ranked recommendation option.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Option.SavingsOpportunity.EstimatedMonthlySavings.Value"),
+			},
+			{
+				Name:        "estimated_monthly_savings_currency",
+				Description: "The currency of the estimated monthly savings.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Option.SavingsOpportunity.EstimatedMonthlySavings.Currency"),
+			},
+			{
+				Name:        "savings_opportunity_percentage",
+				Description: "The estimated monthly savings of the top ranked recommendation option, as a percentage of the cost of the volume.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Option.SavingsOpportunity.SavingsOpportunityPercentage"),
+			},
+			{
+				Name:        "lookback_period_in_days",
+				Description: "The number of days of utilization metrics the recommendation is based on.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("LookBackPeriodInDays"),
+			},
+			{
+				Name:        "last_refresh_timestamp",
+				Description: "The time the recommendation was last generated.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "current_configuration",
+				Description: "The configuration of the volume.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "volume_recommendation_options",
+				Description: "The recommendation options, with their performance risk and savings.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "utilization_metrics",
+				Description: "The utilization metrics of the volume.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "effective_recommendation_preferences",
+				Description: "The preferences the recommendation is based on.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "tags_src",
+				Description: "A list of tags assigned to the volume.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Tags"),
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("VolumeArn"),
+			},
+			{
+				Name:        "tags",
+				Description: resourceInterfaceDescription("tags"),
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Tags").Transform(computeOptimizerTurbotTags),
+			},
+		}),
+	}
+}
+
+type computeOptimizerEBSVolumeRecommendation struct {
+	types.VolumeRecommendation
+	Option *types.VolumeRecommendationOption
+}
+
+//// LIST FUNCTION
+
+func listComputeOptimizerEBSVolumeRecommendations(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	svc, err := ComputeOptimizerClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_computeoptimizer_ebs_volume_recommendation.listComputeOptimizerEBSVolumeRecommendations", "client_error", err)
+		return nil, err
+	}
+	if svc == nil {
+		// Unsupported region, return no data
+		return nil, nil
+	}
+
+	params := &computeoptimizer.GetEBSVolumeRecommendationsInput{
+		MaxResults: aws.Int32(1000),
+	}
+	if d.EqualsQualString("volume_arn") != "" {
+		params.VolumeArns = []string{d.EqualsQualString("volume_arn")}
+	}
+	if d.EqualsQualString("finding") != "" {
+		params.Filters = []types.EBSFilter{{Name: types.EBSFilterNameFinding, Values: []string{d.EqualsQualString("finding")}}}
+	}
+
+	for {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		output, err := svc.GetEBSVolumeRecommendations(ctx, params)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_computeoptimizer_ebs_volume_recommendation.listComputeOptimizerEBSVolumeRecommendations", "api_error", err)
+			return nil, err
+		}
+
+		for _, recommendation := range output.VolumeRecommendations {
+			item := &computeOptimizerEBSVolumeRecommendation{VolumeRecommendation: recommendation}
+			for i, option := range recommendation.VolumeRecommendationOptions {
+				if option.Rank == 1 {
+					item.Option = &recommendation.VolumeRecommendationOptions[i]
+				}
+			}
+			d.StreamListItem(ctx, item)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+
+		if output.NextToken == nil {
+			break
+		}
+		params.NextToken = output.NextToken
+	}
+
+	return nil, nil
+}
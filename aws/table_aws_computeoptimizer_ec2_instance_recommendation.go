@@ -0,0 +1,227 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/computeoptimizer"
+	"github.com/aws/aws-sdk-go-v2/service/computeoptimizer/types"
+
+	computeoptimizerv1 "github.com/aws/aws-sdk-go/service/computeoptimizer"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsComputeOptimizerEC2InstanceRecommendation(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_computeoptimizer_ec2_instance_recommendation",
+		Description: "AWS Compute Optimizer EC2 Instance Recommendation",
+		List: &plugin.ListConfig{
+			Hydrate: listComputeOptimizerEC2InstanceRecommendations,
+			Tags:    map[string]string{"service": "compute-optimizer", "action": "GetEC2InstanceRecommendations"},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"OptInRequiredException", "ResourceNotFoundException"}),
+			},
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "instance_arn", Require: plugin.Optional},
+				{Name: "finding", Require: plugin.Optional},
+			},
+		},
+		GetMatrixItemFunc: SupportedRegionMatrix(computeoptimizerv1.EndpointsID),
+		Columns: awsRegionalColumns([]*plugin.Column{
+			{
+				Name:        "instance_arn",
+				Description: "The Amazon Resource Name (ARN) of the instance.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "instance_name",
+				Description: "The name of the instance.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "finding",
+				Description: "The finding of the instance. Possible values are: Underprovisioned|Overprovisioned|Optimized|NotOptimized.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "finding_reason_codes",
+				Description: "The reasons for the finding, e.g. CPUOverprovisioned.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "current_instance_type",
+				Description: "The instance type of the instance.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "current_performance_risk",
+				Description: "The risk of the instance not meeting the performance needs of its workloads. Possible values are: VeryLow|Low|Medium|High.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "instance_state",
+				Description: "The state of the instance.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "idle",
+				Description: "Whether the instance is idle. Possible values are: True|False.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "recommended_instance_type",
+				Description: "The instance type of the top ranked recommendation option.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Option.InstanceType"),
+			},
+			{
+				Name:        "recommended_performance_risk",
+				Description: "The performance risk of the top ranked recommendation option.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Option.PerformanceRisk"),
+			},
+			{
+				Name:        "migration_effort",
+				Description: "The effort to migrate to the top ranked recommendation option. Possible values are: VeryLow|Low|Medium|High.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Option.MigrationEffort"),
+			},
+			{
+				Name:        "estimated_monthly_savings",
+				Description: "The estimated monthly savings of the top ranked recommendation option.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Option.SavingsOpportunity.EstimatedMonthlySavings.Value"),
+			},
+			{
+				Name:        "estimated_monthly_savings_currency",
+				Description: "The currency of the estimated monthly savings.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Option.SavingsOpportunity.EstimatedMonthlySavings.Currency"),
+			},
+			{
+				Name:        "savings_opportunity_percentage",
+				Description: "The estimated monthly savings of the top ranked recommendation option, as a percentage of the cost of the instance.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Option.SavingsOpportunity.SavingsOpportunityPercentage"),
+			},
+			{
+				Name:        "lookback_period_in_days",
+				Description: "The number of days of utilization metrics the recommendation is based on.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("LookBackPeriodInDays"),
+			},
+			{
+				Name:        "last_refresh_timestamp",
+				Description: "The time the recommendation was last generated.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "recommendation_options",
+				Description: "The recommendation options, with their projected utilization and savings.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "utilization_metrics",
+				Description: "The utilization metrics of the instance.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "inferred_workload_types",
+				Description: "The workloads that might be running on the instance.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "effective_recommendation_preferences",
+				Description: "The preferences the recommendation is based on.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "tags_src",
+				Description: "A list of tags assigned to the instance.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Tags"),
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("InstanceArn"),
+			},
+			{
+				Name:        "tags",
+				Description: resourceInterfaceDescription("tags"),
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Tags").Transform(computeOptimizerTurbotTags),
+			},
+		}),
+	}
+}
+
+type computeOptimizerEC2InstanceRecommendation struct {
+	types.InstanceRecommendation
+	Option *types.InstanceRecommendationOption
+}
+
+//// LIST FUNCTION
+
+func listComputeOptimizerEC2InstanceRecommendations(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	svc, err := ComputeOptimizerClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_computeoptimizer_ec2_instance_recommendation.listComputeOptimizerEC2InstanceRecommendations", "client_error", err)
+		return nil, err
+	}
+	if svc == nil {
+		// Unsupported region, return no data
+		return nil, nil
+	}
+
+	params := &computeoptimizer.GetEC2InstanceRecommendationsInput{
+		MaxResults: aws.Int32(1000),
+	}
+	if d.EqualsQualString("instance_arn") != "" {
+		params.InstanceArns = []string{d.EqualsQualString("instance_arn")}
+	}
+	if d.EqualsQualString("finding") != "" {
+		params.Filters = []types.Filter{{Name: types.FilterNameFinding, Values: []string{d.EqualsQualString("finding")}}}
+	}
+
+	for {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		output, err := svc.GetEC2InstanceRecommendations(ctx, params)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_computeoptimizer_ec2_instance_recommendation.listComputeOptimizerEC2InstanceRecommendations", "api_error", err)
+			return nil, err
+		}
+
+		for _, recommendation := range output.InstanceRecommendations {
+			item := &computeOptimizerEC2InstanceRecommendation{InstanceRecommendation: recommendation}
+			for i, option := range recommendation.RecommendationOptions {
+				if option.Rank == 1 {
+					item.Option = &recommendation.RecommendationOptions[i]
+				}
+			}
+			d.StreamListItem(ctx, item)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+
+		if output.NextToken == nil {
+			break
+		}
+		params.NextToken = output.NextToken
+	}
+
+	return nil, nil
+}
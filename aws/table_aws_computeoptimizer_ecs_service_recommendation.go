@@ -0,0 +1,216 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/computeoptimizer"
+	"github.com/aws/aws-sdk-go-v2/service/computeoptimizer/types"
+
+	computeoptimizerv1 "github.com/aws/aws-sdk-go/service/computeoptimizer"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsComputeOptimizerECSServiceRecommendation(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_computeoptimizer_ecs_service_recommendation",
+		Description: "AWS Compute Optimizer ECS Service Recommendation",
+		List: &plugin.ListConfig{
+			Hydrate: listComputeOptimizerECSServiceRecommendations,
+			Tags:    map[string]string{"service": "compute-optimizer", "action": "GetECSServiceRecommendations"},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"OptInRequiredException", "ResourceNotFoundException"}),
+			},
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "service_arn", Require: plugin.Optional},
+				{Name: "finding", Require: plugin.Optional},
+			},
+		},
+		GetMatrixItemFunc: SupportedRegionMatrix(computeoptimizerv1.EndpointsID),
+		Columns: awsRegionalColumns([]*plugin.Column{
+			{
+				Name:        "service_arn",
+				Description: "The Amazon Resource Name (ARN) of the ECS service.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "finding",
+				Description: "The finding of the service. Possible values are: Optimized|Underprovisioned|Overprovisioned.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "finding_reason_codes",
+				Description: "The reasons for the finding, e.g. CPUOverprovisioned.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "launch_type",
+				Description: "The launch type of the service. Possible values are: EC2|Fargate.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "current_cpu",
+				Description: "The CPU units of the tasks of the service.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("CurrentServiceConfiguration.Cpu"),
+			},
+			{
+				Name:        "current_memory",
+				Description: "The memory of the tasks of the service, in MiB.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("CurrentServiceConfiguration.Memory"),
+			},
+			{
+				Name:        "current_performance_risk",
+				Description: "The risk of the service not meeting the performance needs of its workloads. Possible values are: VeryLow|Low|Medium|High.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "recommended_cpu",
+				Description: "The CPU units of the first recommendation option.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("Option.Cpu"),
+			},
+			{
+				Name:        "recommended_memory",
+				Description: "The memory of the first recommendation option, in MiB.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("Option.Memory"),
+			},
+			{
+				Name:        "estimated_monthly_savings",
+				Description: "The estimated monthly savings of the first recommendation option.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Option.SavingsOpportunity.EstimatedMonthlySavings.Value"),
+			},
+			{
+				Name:        "estimated_monthly_savings_currency",
+				Description: "The currency of the estimated monthly savings.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Option.SavingsOpportunity.EstimatedMonthlySavings.Currency"),
+			},
+			{
+				Name:        "savings_opportunity_percentage",
+				Description: "The estimated monthly savings of the first recommendation option, as a percentage of the cost of the service.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Option.SavingsOpportunity.SavingsOpportunityPercentage"),
+			},
+			{
+				Name:        "lookback_period_in_days",
+				Description: "The number of days of utilization metrics the recommendation is based on.",
+				Type:        proto.ColumnType_DOUBLE,
+			},
+			{
+				Name:        "last_refresh_timestamp",
+				Description: "The time the recommendation was last generated.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "current_service_configuration",
+				Description: "The configuration of the service.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "service_recommendation_options",
+				Description: "The recommendation options, with their projected utilization and savings.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "utilization_metrics",
+				Description: "The utilization metrics of the service.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "effective_recommendation_preferences",
+				Description: "The preferences the recommendation is based on.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "tags_src",
+				Description: "A list of tags assigned to the service.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Tags"),
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ServiceArn"),
+			},
+			{
+				Name:        "tags",
+				Description: resourceInterfaceDescription("tags"),
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Tags").Transform(computeOptimizerTurbotTags),
+			},
+		}),
+	}
+}
+
+type computeOptimizerECSServiceRecommendation struct {
+	types.ECSServiceRecommendation
+	Option *types.ECSServiceRecommendationOption
+}
+
+//// LIST FUNCTION
+
+func listComputeOptimizerECSServiceRecommendations(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	svc, err := ComputeOptimizerClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_computeoptimizer_ecs_service_recommendation.listComputeOptimizerECSServiceRecommendations", "client_error", err)
+		return nil, err
+	}
+	if svc == nil {
+		// Unsupported region, return no data
+		return nil, nil
+	}
+
+	params := &computeoptimizer.GetECSServiceRecommendationsInput{
+		MaxResults: aws.Int32(1000),
+	}
+	if d.EqualsQualString("service_arn") != "" {
+		params.ServiceArns = []string{d.EqualsQualString("service_arn")}
+	}
+	if d.EqualsQualString("finding") != "" {
+		params.Filters = []types.ECSServiceRecommendationFilter{{Name: types.ECSServiceRecommendationFilterNameFinding, Values: []string{d.EqualsQualString("finding")}}}
+	}
+
+	for {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		output, err := svc.GetECSServiceRecommendations(ctx, params)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_computeoptimizer_ecs_service_recommendation.listComputeOptimizerECSServiceRecommendations", "api_error", err)
+			return nil, err
+		}
+
+		for _, recommendation := range output.EcsServiceRecommendations {
+			item := &computeOptimizerECSServiceRecommendation{ECSServiceRecommendation: recommendation}
+			// ECS service recommendation options are not ranked
+			if len(recommendation.ServiceRecommendationOptions) > 0 {
+				item.Option = &recommendation.ServiceRecommendationOptions[0]
+			}
+			d.StreamListItem(ctx, item)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+
+		if output.NextToken == nil {
+			break
+		}
+		params.NextToken = output.NextToken
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,79 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/computeoptimizer"
+
+	computeoptimizerv1 "github.com/aws/aws-sdk-go/service/computeoptimizer"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsComputeOptimizerEnrollmentStatus(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_computeoptimizer_enrollment_status",
+		Description: "AWS Compute Optimizer Enrollment Status",
+		List: &plugin.ListConfig{
+			Hydrate: listComputeOptimizerEnrollmentStatus,
+			Tags:    map[string]string{"service": "compute-optimizer", "action": "GetEnrollmentStatus"},
+		},
+		GetMatrixItemFunc: SupportedRegionMatrix(computeoptimizerv1.EndpointsID),
+		Columns: awsRegionalColumns([]*plugin.Column{
+			{
+				Name:        "status",
+				Description: "The enrollment status of the account. Possible values are: Active|Inactive|Pending|Failed.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "status_reason",
+				Description: "The reason for the enrollment status.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "member_accounts_enrolled",
+				Description: "Whether the member accounts of the organization are enrolled, if the account is a management account.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "number_of_member_accounts_opted_in",
+				Description: "The number of member accounts of the organization that are enrolled, if the account is a management account.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "last_updated_timestamp",
+				Description: "The time the enrollment status was last updated.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listComputeOptimizerEnrollmentStatus(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	svc, err := ComputeOptimizerClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_computeoptimizer_enrollment_status.listComputeOptimizerEnrollmentStatus", "client_error", err)
+		return nil, err
+	}
+	if svc == nil {
+		// Unsupported region, return no data
+		return nil, nil
+	}
+
+	// apply rate limiting
+	d.WaitForListRateLimit(ctx)
+
+	output, err := svc.GetEnrollmentStatus(ctx, &computeoptimizer.GetEnrollmentStatusInput{})
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_computeoptimizer_enrollment_status.listComputeOptimizerEnrollmentStatus", "api_error", err)
+		return nil, err
+	}
+	d.StreamListItem(ctx, output)
+
+	return nil, nil
+}
@@ -0,0 +1,205 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/computeoptimizer"
+	"github.com/aws/aws-sdk-go-v2/service/computeoptimizer/types"
+
+	computeoptimizerv1 "github.com/aws/aws-sdk-go/service/computeoptimizer"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsComputeOptimizerLambdaFunctionRecommendation(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_computeoptimizer_lambda_function_recommendation",
+		Description: "AWS Compute Optimizer Lambda Function Recommendation",
+		List: &plugin.ListConfig{
+			Hydrate: listComputeOptimizerLambdaFunctionRecommendations,
+			Tags:    map[string]string{"service": "compute-optimizer", "action": "GetLambdaFunctionRecommendations"},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"OptInRequiredException", "ResourceNotFoundException"}),
+			},
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "function_arn", Require: plugin.Optional},
+				{Name: "finding", Require: plugin.Optional},
+			},
+		},
+		GetMatrixItemFunc: SupportedRegionMatrix(computeoptimizerv1.EndpointsID),
+		Columns: awsRegionalColumns([]*plugin.Column{
+			{
+				Name:        "function_arn",
+				Description: "The Amazon Resource Name (ARN) of the function, without its version.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("FunctionArn").Transform(computeOptimizerUnqualifiedArn),
+			},
+			{
+				Name:        "function_version",
+				Description: "The version of the function.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "finding",
+				Description: "The finding of the function. Possible values are: Optimized|NotOptimized|Unavailable.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "finding_reason_codes",
+				Description: "The reasons for the finding, e.g. MemoryOverprovisioned.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "current_memory_size",
+				Description: "The memory of the function, in MB.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "current_performance_risk",
+				Description: "The risk of the function not meeting the performance needs of its workloads. Possible values are: VeryLow|Low|Medium|High.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "number_of_invocations",
+				Description: "The number of invocations of the function in the lookback period.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "recommended_memory_size",
+				Description: "The memory of the top ranked recommendation option, in MB.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("Option.MemorySize"),
+			},
+			{
+				Name:        "estimated_monthly_savings",
+				Description: "The estimated monthly savings of the top ranked recommendation option.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Option.SavingsOpportunity.EstimatedMonthlySavings.Value"),
+			},
+			{
+				Name:        "estimated_monthly_savings_currency",
+				Description: "The currency of the estimated monthly savings.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Option.SavingsOpportunity.EstimatedMonthlySavings.Currency"),
+			},
+			{
+				Name:        "savings_opportunity_percentage",
+				Description: "The estimated monthly savings of the top ranked recommendation option, as a percentage of the cost of the function.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Option.SavingsOpportunity.SavingsOpportunityPercentage"),
+			},
+			{
+				Name:        "lookback_period_in_days",
+				Description: "The number of days of utilization metrics the recommendation is based on.",
+				Type:        proto.ColumnType_DOUBLE,
+			},
+			{
+				Name:        "last_refresh_timestamp",
+				Description: "The time the recommendation was last generated.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "memory_size_recommendation_options",
+				Description: "The recommendation options, with their projected utilization and savings.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "utilization_metrics",
+				Description: "The utilization metrics of the function.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "effective_recommendation_preferences",
+				Description: "The preferences the recommendation is based on.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "tags_src",
+				Description: "A list of tags assigned to the function.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Tags"),
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("FunctionArn"),
+			},
+			{
+				Name:        "tags",
+				Description: resourceInterfaceDescription("tags"),
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Tags").Transform(computeOptimizerTurbotTags),
+			},
+		}),
+	}
+}
+
+type computeOptimizerLambdaFunctionRecommendation struct {
+	types.LambdaFunctionRecommendation
+	Option *types.LambdaFunctionMemoryRecommendationOption
+}
+
+//// LIST FUNCTION
+
+func listComputeOptimizerLambdaFunctionRecommendations(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	svc, err := ComputeOptimizerClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_computeoptimizer_lambda_function_recommendation.listComputeOptimizerLambdaFunctionRecommendations", "client_error", err)
+		return nil, err
+	}
+	if svc == nil {
+		// Unsupported region, return no data
+		return nil, nil
+	}
+
+	params := &computeoptimizer.GetLambdaFunctionRecommendationsInput{
+		MaxResults: aws.Int32(1000),
+	}
+	if d.EqualsQualString("function_arn") != "" {
+		params.FunctionArns = []string{d.EqualsQualString("function_arn")}
+	}
+	if d.EqualsQualString("finding") != "" {
+		params.Filters = []types.LambdaFunctionRecommendationFilter{{Name: types.LambdaFunctionRecommendationFilterNameFinding, Values: []string{d.EqualsQualString("finding")}}}
+	}
+
+	for {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		output, err := svc.GetLambdaFunctionRecommendations(ctx, params)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_computeoptimizer_lambda_function_recommendation.listComputeOptimizerLambdaFunctionRecommendations", "api_error", err)
+			return nil, err
+		}
+
+		for _, recommendation := range output.LambdaFunctionRecommendations {
+			item := &computeOptimizerLambdaFunctionRecommendation{LambdaFunctionRecommendation: recommendation}
+			for i, option := range recommendation.MemorySizeRecommendationOptions {
+				if option.Rank == 1 {
+					item.Option = &recommendation.MemorySizeRecommendationOptions[i]
+				}
+			}
+			d.StreamListItem(ctx, item)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+
+		if output.NextToken == nil {
+			break
+		}
+		params.NextToken = output.NextToken
+	}
+
+	return nil, nil
+}
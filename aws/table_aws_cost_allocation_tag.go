@@ -0,0 +1,117 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer/types"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsCostAllocationTag(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_cost_allocation_tag",
+		Description: "AWS Cost Explorer - Cost Allocation Tag",
+		List: &plugin.ListConfig{
+			Hydrate: listCostAllocationTags,
+			Tags:    map[string]string{"service": "ce", "action": "ListCostAllocationTags"},
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "tag_key", Require: plugin.Optional},
+				{Name: "status", Require: plugin.Optional},
+				{Name: "type", Require: plugin.Optional},
+			},
+		},
+		Columns: awsGlobalRegionColumns([]*plugin.Column{
+			{
+				Name:        "tag_key",
+				Description: "The key of the cost allocation tag.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "status",
+				Description: "Whether the tag is activated for cost allocation. Possible values are: Active|Inactive.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "type",
+				Description: "Whether the tag was created by AWS or a user. Possible values are: AWSGenerated|UserDefined.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "last_updated_date",
+				Description: "The date the status of the tag was last updated.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "last_used_date",
+				Description: "The last month the tag was applied to a resource with costs.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listCostAllocationTags(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	svc, err := CostExplorerClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_cost_allocation_tag.listCostAllocationTags", "client_error", err)
+		return nil, err
+	}
+
+	maxItems := int32(1000)
+
+	// Reduce the basic request limit down if the user has only requested a small number of rows
+	if d.QueryContext.Limit != nil {
+		limit := int32(*d.QueryContext.Limit)
+		if limit < maxItems {
+			if limit < 1 {
+				maxItems = int32(1)
+			} else {
+				maxItems = int32(limit)
+			}
+		}
+	}
+
+	input := &costexplorer.ListCostAllocationTagsInput{
+		MaxResults: aws.Int32(maxItems),
+		Status:     types.CostAllocationTagStatus(d.EqualsQualString("status")),
+		Type:       types.CostAllocationTagType(d.EqualsQualString("type")),
+	}
+	if d.EqualsQualString("tag_key") != "" {
+		input.TagKeys = []string{d.EqualsQualString("tag_key")}
+	}
+
+	paginator := costexplorer.NewListCostAllocationTagsPaginator(svc, input, func(o *costexplorer.ListCostAllocationTagsPaginatorOptions) {
+		o.Limit = maxItems
+		o.StopOnDuplicateToken = true
+	})
+
+	for paginator.HasMorePages() {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_cost_allocation_tag.listCostAllocationTags", "api_error", err)
+			return nil, err
+		}
+
+		for _, tag := range output.CostAllocationTags {
+			d.StreamListItem(ctx, tag)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,274 @@
+package aws
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer/types"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsCostAnomaly(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_cost_anomaly",
+		Description: "AWS Cost Explorer - Cost Anomaly",
+		List: &plugin.ListConfig{
+			Hydrate: listCostAnomalies,
+			Tags:    map[string]string{"service": "ce", "action": "GetAnomalies"},
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "monitor_arn", Require: plugin.Optional},
+				{Name: "feedback", Require: plugin.Optional},
+				{Name: "anomaly_end_date", Operators: []string{">", ">=", "=", "<", "<="}, Require: plugin.Optional, CacheMatch: "exact"},
+				{Name: "total_impact", Operators: []string{">", ">=", "=", "<", "<="}, Require: plugin.Optional},
+			},
+		},
+		Columns: awsGlobalRegionColumns([]*plugin.Column{
+			{
+				Name:        "anomaly_id",
+				Description: "The ID of the anomaly.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Anomaly.AnomalyId"),
+			},
+			{
+				Name:        "monitor_arn",
+				Description: "The Amazon Resource Name (ARN) of the monitor that detected the anomaly.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Anomaly.MonitorArn"),
+			},
+			{
+				Name:        "anomaly_start_date",
+				Description: "The first day the anomaly was detected.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("Anomaly.AnomalyStartDate"),
+			},
+			{
+				Name:        "anomaly_end_date",
+				Description: "The last day the anomaly was detected. Anomalies are returned for the last 90 days by default.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("Anomaly.AnomalyEndDate"),
+			},
+			{
+				Name:        "dimension_value",
+				Description: "The value of the monitored dimension the anomaly was detected for, e.g. the service of a SERVICE monitor.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Anomaly.DimensionValue"),
+			},
+			{
+				Name:        "feedback",
+				Description: "The feedback given on the anomaly. Possible values are: YES|NO|PLANNED_ACTIVITY.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Anomaly.Feedback"),
+			},
+			{
+				Name:        "total_impact",
+				Description: "The cost of the anomaly, above the expected spend.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Anomaly.Impact.TotalImpact"),
+			},
+			{
+				Name:        "total_impact_percentage",
+				Description: "The cost of the anomaly as a percentage of the expected spend.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Anomaly.Impact.TotalImpactPercentage"),
+			},
+			{
+				Name:        "max_impact",
+				Description: "The highest daily cost of the anomaly, above the expected spend.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Anomaly.Impact.MaxImpact"),
+			},
+			{
+				Name:        "total_actual_spend",
+				Description: "The spend during the anomaly.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Anomaly.Impact.TotalActualSpend"),
+			},
+			{
+				Name:        "total_expected_spend",
+				Description: "The expected spend during the anomaly.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Anomaly.Impact.TotalExpectedSpend"),
+			},
+			{
+				Name:        "current_score",
+				Description: "The latest score of the anomaly.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Anomaly.AnomalyScore.CurrentScore"),
+			},
+			{
+				Name:        "max_score",
+				Description: "The highest score of the anomaly.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Anomaly.AnomalyScore.MaxScore"),
+			},
+			{
+				Name:        "root_cause_service",
+				Description: "The service of the first root cause of the anomaly.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("RootCause.Service"),
+			},
+			{
+				Name:        "root_cause_linked_account",
+				Description: "The account ID of the first root cause of the anomaly.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("RootCause.LinkedAccount"),
+			},
+			{
+				Name:        "root_cause_linked_account_name",
+				Description: "The account name of the first root cause of the anomaly.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("RootCause.LinkedAccountName"),
+			},
+			{
+				Name:        "root_cause_region",
+				Description: "The region of the first root cause of the anomaly.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("RootCause.Region"),
+			},
+			{
+				Name:        "root_cause_usage_type",
+				Description: "The usage type of the first root cause of the anomaly.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("RootCause.UsageType"),
+			},
+			{
+				Name:        "root_causes",
+				Description: "All the root causes of the anomaly.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Anomaly.RootCauses"),
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Anomaly.AnomalyId"),
+			},
+		}),
+	}
+}
+
+type costAnomaly struct {
+	Anomaly   types.Anomaly
+	RootCause *types.RootCause
+}
+
+//// LIST FUNCTION
+
+func listCostAnomalies(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	svc, err := CostExplorerClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_cost_anomaly.listCostAnomalies", "client_error", err)
+		return nil, err
+	}
+
+	params := &costexplorer.GetAnomaliesInput{
+		DateInterval: getCostAnomalyDateInterval(d),
+		TotalImpact:  getCostAnomalyTotalImpactFilter(d),
+		Feedback:     types.AnomalyFeedbackType(d.EqualsQualString("feedback")),
+	}
+	if d.EqualsQualString("monitor_arn") != "" {
+		params.MonitorArn = aws.String(d.EqualsQualString("monitor_arn"))
+	}
+
+	for {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		output, err := svc.GetAnomalies(ctx, params)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_cost_anomaly.listCostAnomalies", "api_error", err)
+			return nil, err
+		}
+
+		for _, anomaly := range output.Anomalies {
+			item := &costAnomaly{Anomaly: anomaly}
+			if len(anomaly.RootCauses) > 0 {
+				item.RootCause = &anomaly.RootCauses[0]
+			}
+			d.StreamListItem(ctx, item)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+
+		if output.NextPageToken == nil {
+			break
+		}
+		params.NextPageToken = output.NextPageToken
+	}
+
+	return nil, nil
+}
+
+//// UTILITY FUNCTIONS
+
+// getCostAnomalyDateInterval returns the dates of the anomaly_end_date quals,
+// or the last 90 days without quals
+func getCostAnomalyDateInterval(d *plugin.QueryData) *types.AnomalyDateInterval {
+	startDate := time.Now().AddDate(0, 0, -90)
+	var endDate *time.Time
+	if d.Quals["anomaly_end_date"] != nil {
+		for _, q := range d.Quals["anomaly_end_date"].Quals {
+			value := q.Value.GetTimestampValue().AsTime()
+			switch q.Operator {
+			case "=":
+				startDate, endDate = value, &value
+			case ">", ">=":
+				startDate = value
+			case "<", "<=":
+				endDate = &value
+			}
+		}
+	}
+
+	interval := &types.AnomalyDateInterval{
+		StartDate: aws.String(startDate.UTC().Format("2006-01-02")),
+	}
+	if endDate != nil {
+		interval.EndDate = aws.String(endDate.UTC().Format("2006-01-02"))
+	}
+	return interval
+}
+
+// getCostAnomalyTotalImpactFilter returns the filter of the total_impact
+// quals, or nil without quals. The filter bounds are inclusive; Postgres
+// rechecks strict comparisons.
+func getCostAnomalyTotalImpactFilter(d *plugin.QueryData) *types.TotalImpactFilter {
+	if d.Quals["total_impact"] == nil {
+		return nil
+	}
+
+	var lower, upper *float64
+	for _, q := range d.Quals["total_impact"].Quals {
+		value := q.Value.GetDoubleValue()
+		switch q.Operator {
+		case "=":
+			return &types.TotalImpactFilter{NumericOperator: types.NumericOperatorEqual, StartValue: value}
+		case ">", ">=":
+			lower = &value
+		case "<", "<=":
+			upper = &value
+		}
+	}
+
+	switch {
+	case lower != nil && upper != nil:
+		return &types.TotalImpactFilter{NumericOperator: types.NumericOperatorBetween, StartValue: *lower, EndValue: *upper}
+	case lower != nil:
+		return &types.TotalImpactFilter{NumericOperator: types.NumericOperatorGreaterThanOrEqual, StartValue: *lower}
+	case upper != nil:
+		return &types.TotalImpactFilter{NumericOperator: types.NumericOperatorLessThanOrEqual, StartValue: *upper}
+	}
+	return nil
+}
@@ -0,0 +1,221 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer/types"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsCostAnomalyMonitor(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_cost_anomaly_monitor",
+		Description: "AWS Cost Explorer - Cost Anomaly Monitor",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("arn"),
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"UnknownMonitorException"}),
+			},
+			Hydrate: getCostAnomalyMonitor,
+			Tags:    map[string]string{"service": "ce", "action": "GetAnomalyMonitors"},
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listCostAnomalyMonitors,
+			Tags:    map[string]string{"service": "ce", "action": "GetAnomalyMonitors"},
+		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{
+				Func: getCostAnomalyMonitorTags,
+				Tags: map[string]string{"service": "ce", "action": "ListTagsForResource"},
+			},
+		},
+		Columns: awsGlobalRegionColumns([]*plugin.Column{
+			{
+				Name:        "name",
+				Description: "The name of the monitor.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("MonitorName"),
+			},
+			{
+				Name:        "arn",
+				Description: "The Amazon Resource Name (ARN) of the monitor.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("MonitorArn"),
+			},
+			{
+				Name:        "monitor_type",
+				Description: "The type of the monitor. Possible values are: DIMENSIONAL|CUSTOM.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "monitor_dimension",
+				Description: "The dimension monitored by a DIMENSIONAL monitor, e.g. SERVICE.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "dimensional_value_count",
+				Description: "The number of values of the dimension monitored.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "creation_date",
+				Description: "The date the monitor was created.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "last_evaluated_date",
+				Description: "The date the monitor last evaluated for anomalies.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "last_updated_date",
+				Description: "The date the monitor was last updated.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "monitor_specification",
+				Description: "The Cost Explorer filter expression of the costs monitored by a CUSTOM monitor.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "tags_src",
+				Description: "A list of tags assigned to the monitor.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getCostAnomalyMonitorTags,
+				Transform:   transform.FromField("ResourceTags"),
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("MonitorName"),
+			},
+			{
+				Name:        "tags",
+				Description: resourceInterfaceDescription("tags"),
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getCostAnomalyMonitorTags,
+				Transform:   transform.FromField("ResourceTags").Transform(ceResourceTagsToTurbotTags),
+			},
+			{
+				Name:        "akas",
+				Description: resourceInterfaceDescription("akas"),
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("MonitorArn").Transform(arnToAkas),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listCostAnomalyMonitors(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	svc, err := CostExplorerClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_cost_anomaly_monitor.listCostAnomalyMonitors", "client_error", err)
+		return nil, err
+	}
+
+	params := &costexplorer.GetAnomalyMonitorsInput{}
+	for {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		output, err := svc.GetAnomalyMonitors(ctx, params)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_cost_anomaly_monitor.listCostAnomalyMonitors", "api_error", err)
+			return nil, err
+		}
+
+		for _, monitor := range output.AnomalyMonitors {
+			d.StreamListItem(ctx, monitor)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+
+		if output.NextPageToken == nil {
+			break
+		}
+		params.NextPageToken = output.NextPageToken
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getCostAnomalyMonitor(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	arn := d.EqualsQualString("arn")
+	if arn == "" {
+		return nil, nil
+	}
+
+	svc, err := CostExplorerClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_cost_anomaly_monitor.getCostAnomalyMonitor", "client_error", err)
+		return nil, err
+	}
+
+	params := &costexplorer.GetAnomalyMonitorsInput{
+		MonitorArnList: []string{arn},
+	}
+
+	output, err := svc.GetAnomalyMonitors(ctx, params)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_cost_anomaly_monitor.getCostAnomalyMonitor", "api_error", err)
+		return nil, err
+	}
+
+	if len(output.AnomalyMonitors) > 0 {
+		return output.AnomalyMonitors[0], nil
+	}
+	return nil, nil
+}
+
+func getCostAnomalyMonitorTags(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	monitor := h.Item.(types.AnomalyMonitor)
+
+	svc, err := CostExplorerClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_cost_anomaly_monitor.getCostAnomalyMonitorTags", "client_error", err)
+		return nil, err
+	}
+
+	params := &costexplorer.ListTagsForResourceInput{
+		ResourceArn: monitor.MonitorArn,
+	}
+
+	output, err := svc.ListTagsForResource(ctx, params)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_cost_anomaly_monitor.getCostAnomalyMonitorTags", "api_error", err)
+		return nil, err
+	}
+	return output, nil
+}
+
+//// TRANSFORM FUNCTIONS
+
+func ceResourceTagsToTurbotTags(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	tags, ok := d.Value.([]types.ResourceTag)
+	if !ok || len(tags) == 0 {
+		return nil, nil
+	}
+
+	// Mapping the resource tags inside turbotTags
+	turbotTagsMap := map[string]string{}
+	for _, tag := range tags {
+		turbotTagsMap[*tag.Key] = *tag.Value
+	}
+	return turbotTagsMap, nil
+}
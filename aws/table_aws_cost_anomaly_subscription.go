@@ -0,0 +1,190 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer/types"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsCostAnomalySubscription(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_cost_anomaly_subscription",
+		Description: "AWS Cost Explorer - Cost Anomaly Subscription",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("arn"),
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"UnknownSubscriptionException"}),
+			},
+			Hydrate: getCostAnomalySubscription,
+			Tags:    map[string]string{"service": "ce", "action": "GetAnomalySubscriptions"},
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listCostAnomalySubscriptions,
+			Tags:    map[string]string{"service": "ce", "action": "GetAnomalySubscriptions"},
+		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{
+				Func: getCostAnomalySubscriptionTags,
+				Tags: map[string]string{"service": "ce", "action": "ListTagsForResource"},
+			},
+		},
+		Columns: awsGlobalRegionColumns([]*plugin.Column{
+			{
+				Name:        "name",
+				Description: "The name of the subscription.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("SubscriptionName"),
+			},
+			{
+				Name:        "arn",
+				Description: "The Amazon Resource Name (ARN) of the subscription.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("SubscriptionArn"),
+			},
+			{
+				Name:        "frequency",
+				Description: "The frequency alerts are sent at. Possible values are: DAILY|IMMEDIATE|WEEKLY.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "threshold_expression",
+				Description: "The Cost Explorer filter expression on the anomaly impact that triggers an alert.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "monitor_arn_list",
+				Description: "The ARNs of the monitors the subscription alerts for.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "subscribers",
+				Description: "The email addresses and SNS topics alerts are sent to.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "tags_src",
+				Description: "A list of tags assigned to the subscription.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getCostAnomalySubscriptionTags,
+				Transform:   transform.FromField("ResourceTags"),
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("SubscriptionName"),
+			},
+			{
+				Name:        "tags",
+				Description: resourceInterfaceDescription("tags"),
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getCostAnomalySubscriptionTags,
+				Transform:   transform.FromField("ResourceTags").Transform(ceResourceTagsToTurbotTags),
+			},
+			{
+				Name:        "akas",
+				Description: resourceInterfaceDescription("akas"),
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("SubscriptionArn").Transform(arnToAkas),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listCostAnomalySubscriptions(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	svc, err := CostExplorerClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_cost_anomaly_subscription.listCostAnomalySubscriptions", "client_error", err)
+		return nil, err
+	}
+
+	params := &costexplorer.GetAnomalySubscriptionsInput{}
+	for {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		output, err := svc.GetAnomalySubscriptions(ctx, params)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_cost_anomaly_subscription.listCostAnomalySubscriptions", "api_error", err)
+			return nil, err
+		}
+
+		for _, subscription := range output.AnomalySubscriptions {
+			d.StreamListItem(ctx, subscription)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+
+		if output.NextPageToken == nil {
+			break
+		}
+		params.NextPageToken = output.NextPageToken
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getCostAnomalySubscription(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	arn := d.EqualsQualString("arn")
+	if arn == "" {
+		return nil, nil
+	}
+
+	svc, err := CostExplorerClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_cost_anomaly_subscription.getCostAnomalySubscription", "client_error", err)
+		return nil, err
+	}
+
+	params := &costexplorer.GetAnomalySubscriptionsInput{
+		SubscriptionArnList: []string{arn},
+	}
+
+	output, err := svc.GetAnomalySubscriptions(ctx, params)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_cost_anomaly_subscription.getCostAnomalySubscription", "api_error", err)
+		return nil, err
+	}
+
+	if len(output.AnomalySubscriptions) > 0 {
+		return output.AnomalySubscriptions[0], nil
+	}
+	return nil, nil
+}
+
+func getCostAnomalySubscriptionTags(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	subscription := h.Item.(types.AnomalySubscription)
+
+	svc, err := CostExplorerClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_cost_anomaly_subscription.getCostAnomalySubscriptionTags", "client_error", err)
+		return nil, err
+	}
+
+	params := &costexplorer.ListTagsForResourceInput{
+		ResourceArn: subscription.SubscriptionArn,
+	}
+
+	output, err := svc.ListTagsForResource(ctx, params)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_cost_anomaly_subscription.getCostAnomalySubscriptionTags", "api_error", err)
+		return nil, err
+	}
+	return output, nil
+}
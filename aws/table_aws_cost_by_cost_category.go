@@ -0,0 +1,73 @@
+package aws
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer/types"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+func tableAwsCostByCostCategory(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_cost_by_cost_category",
+		Description: "AWS Cost Explorer - Cost By Cost Category",
+		List: &plugin.ListConfig{
+			KeyColumns: append([]*plugin.KeyColumn{
+				{Name: "granularity", Require: plugin.Required},
+				{Name: "cost_category_name", Require: plugin.Required},
+			}, ceTimePeriodKeyColumns()...),
+			Hydrate: listCostAndUsageByCostCategory,
+			Tags:    map[string]string{"service": "ce", "action": "GetCostAndUsage"},
+		},
+		Columns: awsGlobalRegionColumns(
+			costExplorerColumns([]*plugin.Column{
+
+				// Quals columns - to filter the lookups
+				{
+					Name:        "granularity",
+					Description: "The granularity for cost and usage metric data. Possible values are: DAILY|MONTHLY.",
+					Type:        proto.ColumnType_STRING,
+					Transform:   transform.FromQual("granularity"),
+				},
+				{
+					Name:        "cost_category_name",
+					Description: "The name of the cost category to group by.",
+					Type:        proto.ColumnType_STRING,
+					Transform:   transform.FromQual("cost_category_name"),
+				},
+				{
+					Name:        "cost_category_value",
+					Description: "The cost category value grouped by. Empty for costs without a value.",
+					Type:        proto.ColumnType_STRING,
+					Transform:   transform.FromField("Dimension1").Transform(splitCETagValue),
+				},
+			}),
+		),
+	}
+}
+
+//// LIST FUNCTION
+
+func listCostAndUsageByCostCategory(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	granularity := strings.ToUpper(d.EqualsQualString("granularity"))
+
+	params := &costexplorer.GetCostAndUsageInput{
+		TimePeriod:  getCETimePeriod(d, granularity),
+		Granularity: types.Granularity(granularity),
+		Metrics:     AllCostMetrics(),
+		GroupBy: []types.GroupDefinition{
+			{
+				Type: types.GroupDefinitionTypeCostCategory,
+				Key:  aws.String(d.EqualsQualString("cost_category_name")),
+			},
+		},
+	}
+
+	return streamCostAndUsage(ctx, d, params)
+}
@@ -0,0 +1,231 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer/types"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsCostCategoryDefinition(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_cost_category_definition",
+		Description: "AWS Cost Explorer - Cost Category Definition",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("arn"),
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"ResourceNotFoundException"}),
+			},
+			Hydrate: getCostCategoryDefinition,
+			Tags:    map[string]string{"service": "ce", "action": "DescribeCostCategoryDefinition"},
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listCostCategoryDefinitions,
+			Tags:    map[string]string{"service": "ce", "action": "ListCostCategoryDefinitions"},
+		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{
+				Func: getCostCategoryDefinition,
+				Tags: map[string]string{"service": "ce", "action": "DescribeCostCategoryDefinition"},
+			},
+			{
+				Func: getCostCategoryDefinitionTags,
+				Tags: map[string]string{"service": "ce", "action": "ListTagsForResource"},
+			},
+		},
+		Columns: awsGlobalRegionColumns([]*plugin.Column{
+			{
+				Name:        "name",
+				Description: "The name of the cost category.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "arn",
+				Description: "The Amazon Resource Name (ARN) of the cost category.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("CostCategoryArn"),
+			},
+			{
+				Name:        "effective_start",
+				Description: "The date the current version of the cost category is effective from.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "effective_end",
+				Description: "The date the current version of the cost category is effective until.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "default_value",
+				Description: "The value of costs that match no rule.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "number_of_rules",
+				Description: "The number of rules of the cost category.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "rule_version",
+				Description: "The version of the rule syntax.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getCostCategoryDefinition,
+			},
+			{
+				Name:        "values",
+				Description: "The values of the cost category.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "processing_status",
+				Description: "The processing status of the cost category in Cost Explorer and other components.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "rules",
+				Description: "The rules that map costs to values of the cost category.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getCostCategoryDefinition,
+			},
+			{
+				Name:        "split_charge_rules",
+				Description: "The rules that split the costs of a value across other values.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getCostCategoryDefinition,
+			},
+			{
+				Name:        "tags_src",
+				Description: "A list of tags assigned to the cost category.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getCostCategoryDefinitionTags,
+				Transform:   transform.FromField("ResourceTags"),
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Name"),
+			},
+			{
+				Name:        "tags",
+				Description: resourceInterfaceDescription("tags"),
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getCostCategoryDefinitionTags,
+				Transform:   transform.FromField("ResourceTags").Transform(ceResourceTagsToTurbotTags),
+			},
+			{
+				Name:        "akas",
+				Description: resourceInterfaceDescription("akas"),
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("CostCategoryArn").Transform(arnToAkas),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listCostCategoryDefinitions(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	svc, err := CostExplorerClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_cost_category_definition.listCostCategoryDefinitions", "client_error", err)
+		return nil, err
+	}
+
+	paginator := costexplorer.NewListCostCategoryDefinitionsPaginator(svc, &costexplorer.ListCostCategoryDefinitionsInput{}, func(o *costexplorer.ListCostCategoryDefinitionsPaginatorOptions) {
+		o.StopOnDuplicateToken = true
+	})
+
+	for paginator.HasMorePages() {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_cost_category_definition.listCostCategoryDefinitions", "api_error", err)
+			return nil, err
+		}
+
+		for _, costCategory := range output.CostCategoryReferences {
+			d.StreamListItem(ctx, costCategory)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getCostCategoryDefinition(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	var arn string
+	if h.Item != nil {
+		arn = costCategoryDefinitionArn(h.Item)
+	} else {
+		arn = d.EqualsQualString("arn")
+	}
+	if arn == "" {
+		return nil, nil
+	}
+
+	svc, err := CostExplorerClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_cost_category_definition.getCostCategoryDefinition", "client_error", err)
+		return nil, err
+	}
+
+	params := &costexplorer.DescribeCostCategoryDefinitionInput{
+		CostCategoryArn: aws.String(arn),
+	}
+
+	output, err := svc.DescribeCostCategoryDefinition(ctx, params)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_cost_category_definition.getCostCategoryDefinition", "api_error", err)
+		return nil, err
+	}
+	return output.CostCategory, nil
+}
+
+func getCostCategoryDefinitionTags(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	svc, err := CostExplorerClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_cost_category_definition.getCostCategoryDefinitionTags", "client_error", err)
+		return nil, err
+	}
+
+	params := &costexplorer.ListTagsForResourceInput{
+		ResourceArn: aws.String(costCategoryDefinitionArn(h.Item)),
+	}
+
+	output, err := svc.ListTagsForResource(ctx, params)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_cost_category_definition.getCostCategoryDefinitionTags", "api_error", err)
+		return nil, err
+	}
+	return output, nil
+}
+
+// costCategoryDefinitionArn returns the ARN of a listed or described cost
+// category
+func costCategoryDefinitionArn(item interface{}) string {
+	switch item := item.(type) {
+	case types.CostCategoryReference:
+		return aws.ToString(item.CostCategoryArn)
+	case *types.CostCategory:
+		return aws.ToString(item.CostCategoryArn)
+	}
+	return ""
+}
@@ -0,0 +1,132 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer/types"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// Cost Explorer groups by at most two keys per request
+const ceMaxRequestGroupBy = 2
+
+func tableAwsCostExplorerQuery(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_cost_explorer_query",
+		Description: "AWS Cost Explorer - Cost and Usage Query",
+		List: &plugin.ListConfig{
+			KeyColumns: append([]*plugin.KeyColumn{
+				{Name: "granularity", Require: plugin.Required},
+				{Name: "filter", Require: plugin.Optional, CacheMatch: "exact"},
+				{Name: "group_by", Require: plugin.Optional, CacheMatch: "exact"},
+				{Name: "metrics", Require: plugin.Optional, CacheMatch: "exact"},
+			}, ceTimePeriodKeyColumns()...),
+			Hydrate: listCostExplorerQuery,
+			Tags:    map[string]string{"service": "ce", "action": "GetCostAndUsage"},
+		},
+		Columns: awsGlobalRegionColumns(
+			costExplorerColumns([]*plugin.Column{
+				{
+					Name:        "keys",
+					Description: "The values of the group by definitions of the row, in order. Tag and cost category values are returned as <key>$<value>.",
+					Type:        proto.ColumnType_JSON,
+				},
+				{
+					Name:        "groups",
+					Description: "The values of the group by definitions of the row, by group by key.",
+					Type:        proto.ColumnType_JSON,
+				},
+
+				// Quals columns - to filter the lookups
+				{
+					Name:        "granularity",
+					Description: "The granularity for cost and usage metric data. Possible values are: DAILY|MONTHLY|HOURLY.",
+					Type:        proto.ColumnType_STRING,
+					Transform:   transform.FromQual("granularity"),
+				},
+				{
+					Name:        "filter",
+					Description: "The Cost Explorer filter expression to filter costs by, e.g. {\"Dimensions\": {\"Key\": \"SERVICE\", \"Values\": [\"Amazon Elastic Compute Cloud - Compute\"]}}.",
+					Type:        proto.ColumnType_JSON,
+					Transform:   transform.FromQual("filter"),
+				},
+				{
+					Name:        "group_by",
+					Description: "The group by definitions to group costs by, as an array of Type and Key objects, e.g. [{\"Type\": \"DIMENSION\", \"Key\": \"SERVICE\"}, {\"Type\": \"TAG\", \"Key\": \"env\"}]. Type is one of DIMENSION, TAG or COST_CATEGORY.",
+					Type:        proto.ColumnType_JSON,
+					Transform:   transform.FromQual("group_by"),
+				},
+				{
+					Name:        "metrics",
+					Description: "The cost metrics to return, as an array of metric names, e.g. [\"UnblendedCost\", \"UsageQuantity\"]. Defaults to all metrics.",
+					Type:        proto.ColumnType_JSON,
+					Transform:   transform.FromQual("metrics"),
+				},
+			}),
+		),
+	}
+}
+
+//// LIST FUNCTION
+
+func listCostExplorerQuery(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	params, extraGroupBy, err := buildCostExplorerQueryInput(d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_cost_explorer_query.listCostExplorerQuery", "qual_error", err)
+		return nil, err
+	}
+	return streamCostAndUsage(ctx, d, params, extraGroupBy...)
+}
+
+// buildCostExplorerQueryInput returns the input for the quals, and the group
+// by definitions that don't fit in a single request
+func buildCostExplorerQueryInput(d *plugin.QueryData) (*costexplorer.GetCostAndUsageInput, []types.GroupDefinition, error) {
+	granularity := strings.ToUpper(d.EqualsQualString("granularity"))
+
+	params := &costexplorer.GetCostAndUsageInput{
+		TimePeriod:  getCETimePeriod(d, granularity),
+		Granularity: types.Granularity(granularity),
+		Metrics:     AllCostMetrics(),
+	}
+
+	if filter := d.EqualsQuals["filter"].GetJsonbValue(); filter != "" {
+		params.Filter = &types.Expression{}
+		if err := json.Unmarshal([]byte(filter), params.Filter); err != nil {
+			return nil, nil, fmt.Errorf("failed to unmarshal filter %v: %v", filter, err)
+		}
+	}
+
+	if metrics := d.EqualsQuals["metrics"].GetJsonbValue(); metrics != "" {
+		if err := json.Unmarshal([]byte(metrics), &params.Metrics); err != nil {
+			return nil, nil, fmt.Errorf("failed to unmarshal metrics %v: %v", metrics, err)
+		}
+	}
+
+	var groupBy []types.GroupDefinition
+	if groupByString := d.EqualsQuals["group_by"].GetJsonbValue(); groupByString != "" {
+		if err := json.Unmarshal([]byte(groupByString), &groupBy); err != nil {
+			return nil, nil, fmt.Errorf("failed to unmarshal group_by %v: %v", groupByString, err)
+		}
+	}
+	for i, group := range groupBy {
+		groupBy[i].Type = types.GroupDefinitionType(strings.ToUpper(string(group.Type)))
+		if groupBy[i].Type == types.GroupDefinitionTypeDimension {
+			groupBy[i].Key = aws.String(strings.ToUpper(aws.ToString(group.Key)))
+		}
+	}
+	if len(groupBy) <= ceMaxRequestGroupBy {
+		params.GroupBy = groupBy
+		return params, nil, nil
+	}
+	params.GroupBy = groupBy[:ceMaxRequestGroupBy]
+
+	return params, groupBy[ceMaxRequestGroupBy:], nil
+}
@@ -0,0 +1,165 @@
+package aws
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer/types"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsCostReservationCoverage(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_cost_reservation_coverage",
+		Description: "AWS Cost Explorer - Reservation Coverage",
+		List: &plugin.ListConfig{
+			Hydrate: listCostReservationCoverages,
+			Tags:    map[string]string{"service": "ce", "action": "GetReservationCoverage"},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"DataUnavailableException"}),
+			},
+			KeyColumns: append([]*plugin.KeyColumn{
+				{Name: "granularity", Require: plugin.Required},
+				{Name: "service", Require: plugin.Optional},
+			}, ceTimePeriodKeyColumns()...),
+		},
+		Columns: awsGlobalRegionColumns([]*plugin.Column{
+			{
+				Name:        "period_start",
+				Description: "Start timestamp for this coverage data.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("Coverage.TimePeriod.Start"),
+			},
+			{
+				Name:        "period_end",
+				Description: "End timestamp for this coverage data.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("Coverage.TimePeriod.End"),
+			},
+			{
+				Name:        "granularity",
+				Description: "The granularity of the coverage data. Possible values are: DAILY|MONTHLY|HOURLY.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("granularity"),
+			},
+			{
+				Name:        "service",
+				Description: "The service the coverage data is for, e.g. Amazon Relational Database Service. Empty for the coverage of all services.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "coverage_hours_percentage",
+				Description: "The percentage of running hours covered by reservations.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Coverage.Total.CoverageHours.CoverageHoursPercentage"),
+			},
+			{
+				Name:        "on_demand_hours",
+				Description: "The number of running hours not covered by reservations.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Coverage.Total.CoverageHours.OnDemandHours"),
+			},
+			{
+				Name:        "reserved_hours",
+				Description: "The number of running hours covered by reservations.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Coverage.Total.CoverageHours.ReservedHours"),
+			},
+			{
+				Name:        "total_running_hours",
+				Description: "The total number of running hours.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Coverage.Total.CoverageHours.TotalRunningHours"),
+			},
+			{
+				Name:        "coverage_normalized_units_percentage",
+				Description: "The percentage of running normalized units covered by reservations.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Coverage.Total.CoverageNormalizedUnits.CoverageNormalizedUnitsPercentage"),
+			},
+			{
+				Name:        "on_demand_normalized_units",
+				Description: "The number of running normalized units not covered by reservations.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Coverage.Total.CoverageNormalizedUnits.OnDemandNormalizedUnits"),
+			},
+			{
+				Name:        "reserved_normalized_units",
+				Description: "The number of running normalized units covered by reservations.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Coverage.Total.CoverageNormalizedUnits.ReservedNormalizedUnits"),
+			},
+			{
+				Name:        "total_running_normalized_units",
+				Description: "The total number of running normalized units.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Coverage.Total.CoverageNormalizedUnits.TotalRunningNormalizedUnits"),
+			},
+			{
+				Name:        "on_demand_cost",
+				Description: "The on-demand cost of the usage not covered by reservations.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Coverage.Total.CoverageCost.OnDemandCost"),
+			},
+		}),
+	}
+}
+
+type costReservationCoverage struct {
+	Service  string
+	Coverage types.CoverageByTime
+}
+
+//// LIST FUNCTION
+
+func listCostReservationCoverages(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	svc, err := CostExplorerClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_cost_reservation_coverage.listCostReservationCoverages", "client_error", err)
+		return nil, err
+	}
+
+	granularity := strings.ToUpper(d.EqualsQualString("granularity"))
+
+	// A request is made per service, or a single request for all services
+	for _, service := range getCEQualValues(d, "service", "") {
+		params := &costexplorer.GetReservationCoverageInput{
+			TimePeriod:  getCETimePeriod(d, granularity),
+			Granularity: types.Granularity(granularity),
+			Filter:      ceServiceFilter(service),
+		}
+
+		for {
+			// apply rate limiting
+			d.WaitForListRateLimit(ctx)
+
+			output, err := svc.GetReservationCoverage(ctx, params)
+			if err != nil {
+				plugin.Logger(ctx).Error("aws_cost_reservation_coverage.listCostReservationCoverages", "api_error", err)
+				return nil, err
+			}
+
+			for _, coverage := range output.CoveragesByTime {
+				d.StreamListItem(ctx, &costReservationCoverage{Service: service, Coverage: coverage})
+
+				// Context can be cancelled due to manual cancellation or the limit has been hit
+				if d.RowsRemaining(ctx) == 0 {
+					return nil, nil
+				}
+			}
+
+			if output.NextPageToken == nil {
+				break
+			}
+			params.NextPageToken = output.NextPageToken
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,276 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer/types"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// Services with reservations, recommendations are returned for if the query
+// has no service quals
+var costReservationServices = []string{
+	"Amazon Elastic Compute Cloud - Compute",
+	"Amazon Relational Database Service",
+	"Amazon Redshift",
+	"Amazon ElastiCache",
+	"Amazon OpenSearch Service",
+}
+
+//// TABLE DEFINITION
+
+func tableAwsCostReservationPurchaseRecommendation(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_cost_reservation_purchase_recommendation",
+		Description: "AWS Cost Explorer - Reservation Purchase Recommendation",
+		List: &plugin.ListConfig{
+			Hydrate: listCostReservationPurchaseRecommendations,
+			Tags:    map[string]string{"service": "ce", "action": "GetReservationPurchaseRecommendation"},
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "service", Require: plugin.Optional},
+				{Name: "term_in_years", Require: plugin.Optional},
+				{Name: "payment_option", Require: plugin.Optional},
+				{Name: "lookback_period_in_days", Require: plugin.Optional},
+				{Name: "account_scope", Require: plugin.Optional},
+			},
+		},
+		Columns: awsGlobalRegionColumns([]*plugin.Column{
+			{
+				Name:        "service",
+				Description: "The service of the recommended reservations, e.g. Amazon Relational Database Service. Defaults to EC2, RDS, Redshift, ElastiCache and OpenSearch.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "term_in_years",
+				Description: "The term of the recommended reservations. Possible values are: ONE_YEAR|THREE_YEARS. Defaults to ONE_YEAR.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "payment_option",
+				Description: "The payment option of the recommended reservations. Possible values are: NO_UPFRONT|PARTIAL_UPFRONT|ALL_UPFRONT. Defaults to NO_UPFRONT.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "lookback_period_in_days",
+				Description: "The number of days of usage the recommendation is based on. Possible values are: SEVEN_DAYS|THIRTY_DAYS|SIXTY_DAYS. Defaults to THIRTY_DAYS.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "account_scope",
+				Description: "Whether the recommendation is for the whole organization, or per linked account. Possible values are: PAYER|LINKED. Defaults to PAYER.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "linked_account_id",
+				Description: "The ID of the account the recommendation is for.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Detail.AccountId"),
+			},
+			{
+				Name:        "instance_details",
+				Description: "The details of the recommended reservations, e.g. the instance type, region and platform.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Detail.InstanceDetails"),
+			},
+			{
+				Name:        "recommended_number_of_instances_to_purchase",
+				Description: "The number of instances to reserve.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Detail.RecommendedNumberOfInstancesToPurchase"),
+			},
+			{
+				Name:        "recommended_normalized_units_to_purchase",
+				Description: "The number of normalized units to reserve.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Detail.RecommendedNormalizedUnitsToPurchase"),
+			},
+			{
+				Name:        "average_number_of_instances_used_per_hour",
+				Description: "The average number of instances used per hour over the lookback period.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Detail.AverageNumberOfInstancesUsedPerHour"),
+			},
+			{
+				Name:        "maximum_number_of_instances_used_per_hour",
+				Description: "The highest number of instances used per hour over the lookback period.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Detail.MaximumNumberOfInstancesUsedPerHour"),
+			},
+			{
+				Name:        "minimum_number_of_instances_used_per_hour",
+				Description: "The lowest number of instances used per hour over the lookback period.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Detail.MinimumNumberOfInstancesUsedPerHour"),
+			},
+			{
+				Name:        "average_normalized_units_used_per_hour",
+				Description: "The average number of normalized units used per hour over the lookback period.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Detail.AverageNormalizedUnitsUsedPerHour"),
+			},
+			{
+				Name:        "average_utilization",
+				Description: "The expected utilization of the recommended reservations.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Detail.AverageUtilization"),
+			},
+			{
+				Name:        "estimated_break_even_in_months",
+				Description: "The number of months until the recommended reservations pay for themselves.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Detail.EstimatedBreakEvenInMonths"),
+			},
+			{
+				Name:        "estimated_monthly_on_demand_cost",
+				Description: "The estimated monthly on-demand cost of the usage the recommended reservations would cover.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Detail.EstimatedMonthlyOnDemandCost"),
+			},
+			{
+				Name:        "estimated_monthly_savings_amount",
+				Description: "The estimated monthly savings of the recommended reservations.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Detail.EstimatedMonthlySavingsAmount"),
+			},
+			{
+				Name:        "estimated_monthly_savings_percentage",
+				Description: "The estimated monthly savings as a percentage of the on-demand cost.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Detail.EstimatedMonthlySavingsPercentage"),
+			},
+			{
+				Name:        "estimated_reservation_cost_for_lookback_period",
+				Description: "The estimated cost of the recommended reservations over the lookback period.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Detail.EstimatedReservationCostForLookbackPeriod"),
+			},
+			{
+				Name:        "recurring_standard_monthly_cost",
+				Description: "The monthly cost of the recommended reservations.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Detail.RecurringStandardMonthlyCost"),
+			},
+			{
+				Name:        "upfront_cost",
+				Description: "The upfront cost of the recommended reservations.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Detail.UpfrontCost"),
+			},
+			{
+				Name:        "currency_code",
+				Description: "The currency of the costs and savings.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Detail.CurrencyCode"),
+			},
+			{
+				Name:        "offering_class",
+				Description: "The offering class of the recommended EC2 reservations. Possible values are: STANDARD|CONVERTIBLE.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ServiceSpecification.EC2Specification.OfferingClass"),
+			},
+			{
+				Name:        "recommendation_id",
+				Description: "The ID of the recommendation.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Metadata.RecommendationId"),
+			},
+			{
+				Name:        "generation_timestamp",
+				Description: "The time the recommendation was generated.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("Metadata.GenerationTimestamp"),
+			},
+		}),
+	}
+}
+
+type costReservationPurchaseRecommendation struct {
+	Service              string
+	TermInYears          types.TermInYears
+	PaymentOption        types.PaymentOption
+	LookbackPeriodInDays types.LookbackPeriodInDays
+	AccountScope         types.AccountScope
+	ServiceSpecification *types.ServiceSpecification
+	Detail               types.ReservationPurchaseRecommendationDetail
+	Metadata             *types.ReservationPurchaseRecommendationMetadata
+}
+
+//// LIST FUNCTION
+
+func listCostReservationPurchaseRecommendations(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	svc, err := CostExplorerClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_cost_reservation_purchase_recommendation.listCostReservationPurchaseRecommendations", "client_error", err)
+		return nil, err
+	}
+
+	// A request is made per combination of the qual values
+	for _, service := range getCEQualValues(d, "service", costReservationServices...) {
+		for _, termInYears := range getCEQualValues(d, "term_in_years", string(types.TermInYearsOneYear)) {
+			for _, paymentOption := range getCEQualValues(d, "payment_option", string(types.PaymentOptionNoUpfront)) {
+				for _, lookbackPeriod := range getCEQualValues(d, "lookback_period_in_days", string(types.LookbackPeriodInDaysThirtyDays)) {
+					for _, accountScope := range getCEQualValues(d, "account_scope", string(types.AccountScopePayer)) {
+						params := &costexplorer.GetReservationPurchaseRecommendationInput{
+							Service:              aws.String(service),
+							TermInYears:          types.TermInYears(termInYears),
+							PaymentOption:        types.PaymentOption(paymentOption),
+							LookbackPeriodInDays: types.LookbackPeriodInDays(lookbackPeriod),
+							AccountScope:         types.AccountScope(accountScope),
+						}
+						done, err := streamCostReservationPurchaseRecommendations(ctx, d, svc, params)
+						if err != nil || done {
+							return nil, err
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+func streamCostReservationPurchaseRecommendations(ctx context.Context, d *plugin.QueryData, svc *costexplorer.Client, params *costexplorer.GetReservationPurchaseRecommendationInput) (bool, error) {
+	for {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		output, err := svc.GetReservationPurchaseRecommendation(ctx, params)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_cost_reservation_purchase_recommendation.streamCostReservationPurchaseRecommendations", "api_error", err, "service", aws.ToString(params.Service))
+			return false, err
+		}
+
+		for _, recommendation := range output.Recommendations {
+			for _, detail := range recommendation.RecommendationDetails {
+				d.StreamListItem(ctx, &costReservationPurchaseRecommendation{
+					Service:              aws.ToString(params.Service),
+					TermInYears:          recommendation.TermInYears,
+					PaymentOption:        recommendation.PaymentOption,
+					LookbackPeriodInDays: recommendation.LookbackPeriodInDays,
+					AccountScope:         recommendation.AccountScope,
+					ServiceSpecification: recommendation.ServiceSpecification,
+					Detail:               detail,
+					Metadata:             output.Metadata,
+				})
+
+				// Context can be cancelled due to manual cancellation or the limit has been hit
+				if d.RowsRemaining(ctx) == 0 {
+					return true, nil
+				}
+			}
+		}
+
+		if output.NextPageToken == nil {
+			break
+		}
+		params.NextPageToken = output.NextPageToken
+	}
+
+	return false, nil
+}
@@ -0,0 +1,213 @@
+package aws
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer/types"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsCostReservationUtilization(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_cost_reservation_utilization",
+		Description: "AWS Cost Explorer - Reservation Utilization",
+		List: &plugin.ListConfig{
+			Hydrate: listCostReservationUtilizations,
+			Tags:    map[string]string{"service": "ce", "action": "GetReservationUtilization"},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"DataUnavailableException"}),
+			},
+			KeyColumns: append([]*plugin.KeyColumn{
+				{Name: "granularity", Require: plugin.Required},
+				{Name: "service", Require: plugin.Optional},
+			}, ceTimePeriodKeyColumns()...),
+		},
+		Columns: awsGlobalRegionColumns([]*plugin.Column{
+			{
+				Name:        "period_start",
+				Description: "Start timestamp for this utilization data.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("Utilization.TimePeriod.Start"),
+			},
+			{
+				Name:        "period_end",
+				Description: "End timestamp for this utilization data.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("Utilization.TimePeriod.End"),
+			},
+			{
+				Name:        "granularity",
+				Description: "The granularity of the utilization data. Possible values are: DAILY|MONTHLY.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("granularity"),
+			},
+			{
+				Name:        "service",
+				Description: "The service the utilization data is for, e.g. Amazon Relational Database Service. Empty for the utilization of all services.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "utilization_percentage",
+				Description: "The percentage of the reservation hours used.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Utilization.Total.UtilizationPercentage"),
+			},
+			{
+				Name:        "utilization_percentage_in_units",
+				Description: "The percentage of the reservation normalized units used.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Utilization.Total.UtilizationPercentageInUnits"),
+			},
+			{
+				Name:        "purchased_hours",
+				Description: "The number of reservation hours purchased.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Utilization.Total.PurchasedHours"),
+			},
+			{
+				Name:        "purchased_units",
+				Description: "The number of reservation normalized units purchased.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Utilization.Total.PurchasedUnits"),
+			},
+			{
+				Name:        "total_actual_hours",
+				Description: "The number of reservation hours used.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Utilization.Total.TotalActualHours"),
+			},
+			{
+				Name:        "total_actual_units",
+				Description: "The number of reservation normalized units used.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Utilization.Total.TotalActualUnits"),
+			},
+			{
+				Name:        "unused_hours",
+				Description: "The number of reservation hours not used.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Utilization.Total.UnusedHours"),
+			},
+			{
+				Name:        "unused_units",
+				Description: "The number of reservation normalized units not used.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Utilization.Total.UnusedUnits"),
+			},
+			{
+				Name:        "on_demand_cost_of_ri_hours_used",
+				Description: "The on-demand cost of the reservation hours used.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Utilization.Total.OnDemandCostOfRIHoursUsed"),
+			},
+			{
+				Name:        "ri_cost_for_unused_hours",
+				Description: "The cost of the reservation hours not used.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Utilization.Total.RICostForUnusedHours"),
+			},
+			{
+				Name:        "net_ri_savings",
+				Description: "The savings of the reservations compared to on-demand, net of the reservation costs.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Utilization.Total.NetRISavings"),
+			},
+			{
+				Name:        "total_potential_ri_savings",
+				Description: "The savings of the reservations if they were fully used.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Utilization.Total.TotalPotentialRISavings"),
+			},
+			{
+				Name:        "realized_savings",
+				Description: "The savings realized by the reservations.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Utilization.Total.RealizedSavings"),
+			},
+			{
+				Name:        "unrealized_savings",
+				Description: "The savings not realized because of reservation hours not used.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Utilization.Total.UnrealizedSavings"),
+			},
+			{
+				Name:        "amortized_recurring_fee",
+				Description: "The amortized recurring fee of the reservations.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Utilization.Total.AmortizedRecurringFee"),
+			},
+			{
+				Name:        "amortized_upfront_fee",
+				Description: "The amortized upfront fee of the reservations.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Utilization.Total.AmortizedUpfrontFee"),
+			},
+			{
+				Name:        "total_amortized_fee",
+				Description: "The total amortized fee of the reservations.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Utilization.Total.TotalAmortizedFee"),
+			},
+		}),
+	}
+}
+
+type costReservationUtilization struct {
+	Service     string
+	Utilization types.UtilizationByTime
+}
+
+//// LIST FUNCTION
+
+func listCostReservationUtilizations(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	svc, err := CostExplorerClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_cost_reservation_utilization.listCostReservationUtilizations", "client_error", err)
+		return nil, err
+	}
+
+	granularity := strings.ToUpper(d.EqualsQualString("granularity"))
+
+	// A request is made per service, or a single request for all services
+	for _, service := range getCEQualValues(d, "service", "") {
+		params := &costexplorer.GetReservationUtilizationInput{
+			TimePeriod:  getCETimePeriod(d, granularity),
+			Granularity: types.Granularity(granularity),
+			Filter:      ceServiceFilter(service),
+		}
+
+		for {
+			// apply rate limiting
+			d.WaitForListRateLimit(ctx)
+
+			output, err := svc.GetReservationUtilization(ctx, params)
+			if err != nil {
+				plugin.Logger(ctx).Error("aws_cost_reservation_utilization.listCostReservationUtilizations", "api_error", err)
+				return nil, err
+			}
+
+			for _, utilization := range output.UtilizationsByTime {
+				d.StreamListItem(ctx, &costReservationUtilization{Service: service, Utilization: utilization})
+
+				// Context can be cancelled due to manual cancellation or the limit has been hit
+				if d.RowsRemaining(ctx) == 0 {
+					return nil, nil
+				}
+			}
+
+			if output.NextPageToken == nil {
+				break
+			}
+			params.NextPageToken = output.NextPageToken
+		}
+	}
+
+	return nil, nil
+}
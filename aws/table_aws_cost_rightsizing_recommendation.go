@@ -0,0 +1,279 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer/types"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsCostRightsizingRecommendation(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_cost_rightsizing_recommendation",
+		Description: "AWS Cost Explorer - Rightsizing Recommendation",
+		List: &plugin.ListConfig{
+			Hydrate: listCostRightsizingRecommendations,
+			Tags:    map[string]string{"service": "ce", "action": "GetRightsizingRecommendation"},
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "recommendation_target", Require: plugin.Optional},
+				{Name: "benefits_considered", Require: plugin.Optional},
+			},
+		},
+		Columns: awsGlobalRegionColumns([]*plugin.Column{
+			{
+				Name:        "resource_id",
+				Description: "The ID of the instance.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Recommendation.CurrentInstance.ResourceId"),
+			},
+			{
+				Name:        "instance_name",
+				Description: "The name of the instance.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Recommendation.CurrentInstance.InstanceName"),
+			},
+			{
+				Name:        "linked_account_id",
+				Description: "The ID of the account the instance belongs to.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Recommendation.AccountId"),
+			},
+			{
+				Name:        "rightsizing_type",
+				Description: "The recommended action for the instance. Possible values are: TERMINATE|MODIFY.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Recommendation.RightsizingType"),
+			},
+			{
+				Name:        "finding_reason_codes",
+				Description: "The reasons for the recommendation, e.g. CPU_OVER_PROVISIONED.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Recommendation.FindingReasonCodes"),
+			},
+			{
+				Name:        "current_instance_type",
+				Description: "The instance type of the instance.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Recommendation.CurrentInstance.ResourceDetails.EC2ResourceDetails.InstanceType"),
+			},
+			{
+				Name:        "current_monthly_cost",
+				Description: "The cost of the instance in the last month.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Recommendation.CurrentInstance.MonthlyCost"),
+			},
+			{
+				Name:        "target_instance_type",
+				Description: "The instance type of the default target instance, for MODIFY recommendations.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "estimated_monthly_cost",
+				Description: "The estimated monthly cost of the default target instance, for MODIFY recommendations.",
+				Type:        proto.ColumnType_DOUBLE,
+			},
+			{
+				Name:        "estimated_monthly_savings",
+				Description: "The estimated monthly savings of the recommendation.",
+				Type:        proto.ColumnType_DOUBLE,
+			},
+			{
+				Name:        "currency_code",
+				Description: "The currency of the costs and savings.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Recommendation.CurrentInstance.CurrencyCode"),
+			},
+			{
+				Name:        "total_running_hours_in_lookback_period",
+				Description: "The number of hours the instance ran in the lookback period.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Recommendation.CurrentInstance.TotalRunningHoursInLookbackPeriod"),
+			},
+			{
+				Name:        "on_demand_hours_in_lookback_period",
+				Description: "The number of hours the instance ran on-demand in the lookback period.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Recommendation.CurrentInstance.OnDemandHoursInLookbackPeriod"),
+			},
+			{
+				Name:        "reservation_covered_hours_in_lookback_period",
+				Description: "The number of hours the instance was covered by a reservation in the lookback period.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Recommendation.CurrentInstance.ReservationCoveredHoursInLookbackPeriod"),
+			},
+			{
+				Name:        "savings_plans_covered_hours_in_lookback_period",
+				Description: "The number of hours the instance was covered by a Savings Plan in the lookback period.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Recommendation.CurrentInstance.SavingsPlansCoveredHoursInLookbackPeriod"),
+			},
+			{
+				Name:        "resource_utilization",
+				Description: "The utilization of the instance in the lookback period.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Recommendation.CurrentInstance.ResourceUtilization"),
+			},
+			{
+				Name:        "resource_details",
+				Description: "The details of the instance, e.g. its instance type, platform and region.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Recommendation.CurrentInstance.ResourceDetails"),
+			},
+			{
+				Name:        "target_instances",
+				Description: "The instances the instance can be modified to, for MODIFY recommendations.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Recommendation.ModifyRecommendationDetail.TargetInstances"),
+			},
+			{
+				Name:        "recommendation_target",
+				Description: "Whether the recommendations are for the same instance family, or across instance families. Possible values are: SAME_INSTANCE_FAMILY|CROSS_INSTANCE_FAMILY. Defaults to SAME_INSTANCE_FAMILY.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "benefits_considered",
+				Description: "Whether Savings Plans and Reserved Instance benefits are considered in the recommendations. Defaults to true.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "lookback_period_in_days",
+				Description: "The number of days of usage the recommendations are based on.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Metadata.LookbackPeriodInDays"),
+			},
+			{
+				Name:        "recommendation_id",
+				Description: "The ID of the recommendations.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Metadata.RecommendationId"),
+			},
+			{
+				Name:        "generation_timestamp",
+				Description: "The time the recommendations were generated.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("Metadata.GenerationTimestamp"),
+			},
+			{
+				Name:        "tags",
+				Description: "The tags of the instance.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Recommendation.CurrentInstance.Tags").Transform(ceTagValuesToMap),
+			},
+		}),
+	}
+}
+
+type costRightsizingRecommendation struct {
+	Recommendation          types.RightsizingRecommendation
+	Metadata                *types.RightsizingRecommendationMetadata
+	RecommendationTarget    types.RecommendationTarget
+	BenefitsConsidered      bool
+	TargetInstanceType      *string
+	EstimatedMonthlyCost    *string
+	EstimatedMonthlySavings *string
+}
+
+//// LIST FUNCTION
+
+func listCostRightsizingRecommendations(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	svc, err := CostExplorerClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_cost_rightsizing_recommendation.listCostRightsizingRecommendations", "client_error", err)
+		return nil, err
+	}
+
+	benefitsConsidered := true
+	if d.EqualsQuals["benefits_considered"] != nil {
+		benefitsConsidered = d.EqualsQuals["benefits_considered"].GetBoolValue()
+	}
+
+	for _, target := range getCEQualValues(d, "recommendation_target", string(types.RecommendationTargetSameInstanceFamily)) {
+		params := &costexplorer.GetRightsizingRecommendationInput{
+			Service: aws.String("AmazonEC2"),
+			Configuration: &types.RightsizingRecommendationConfiguration{
+				RecommendationTarget: types.RecommendationTarget(target),
+				BenefitsConsidered:   benefitsConsidered,
+			},
+		}
+
+		for {
+			// apply rate limiting
+			d.WaitForListRateLimit(ctx)
+
+			output, err := svc.GetRightsizingRecommendation(ctx, params)
+			if err != nil {
+				plugin.Logger(ctx).Error("aws_cost_rightsizing_recommendation.listCostRightsizingRecommendations", "api_error", err)
+				return nil, err
+			}
+
+			for _, recommendation := range output.RightsizingRecommendations {
+				d.StreamListItem(ctx, newCostRightsizingRecommendation(recommendation, output.Metadata, params.Configuration))
+
+				// Context can be cancelled due to manual cancellation or the limit has been hit
+				if d.RowsRemaining(ctx) == 0 {
+					return nil, nil
+				}
+			}
+
+			if output.NextPageToken == nil {
+				break
+			}
+			params.NextPageToken = output.NextPageToken
+		}
+	}
+
+	return nil, nil
+}
+
+func newCostRightsizingRecommendation(recommendation types.RightsizingRecommendation, metadata *types.RightsizingRecommendationMetadata, configuration *types.RightsizingRecommendationConfiguration) *costRightsizingRecommendation {
+	item := &costRightsizingRecommendation{
+		Recommendation:       recommendation,
+		Metadata:             metadata,
+		RecommendationTarget: configuration.RecommendationTarget,
+		BenefitsConsidered:   configuration.BenefitsConsidered,
+	}
+
+	if detail := recommendation.TerminateRecommendationDetail; detail != nil {
+		item.EstimatedMonthlySavings = detail.EstimatedMonthlySavings
+	}
+	if detail := recommendation.ModifyRecommendationDetail; detail != nil {
+		for _, target := range detail.TargetInstances {
+			if !target.DefaultTargetInstance {
+				continue
+			}
+			item.EstimatedMonthlyCost = target.EstimatedMonthlyCost
+			item.EstimatedMonthlySavings = target.EstimatedMonthlySavings
+			if target.ResourceDetails != nil && target.ResourceDetails.EC2ResourceDetails != nil {
+				item.TargetInstanceType = target.ResourceDetails.EC2ResourceDetails.InstanceType
+			}
+		}
+	}
+
+	return item
+}
+
+//// TRANSFORM FUNCTIONS
+
+// ceTagValuesToMap turns the tags of a resource, as a list of tag keys and
+// values, into a map of the first value of each tag key
+func ceTagValuesToMap(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	tags, ok := d.Value.([]types.TagValues)
+	if !ok || len(tags) == 0 {
+		return nil, nil
+	}
+
+	turbotTags := map[string]string{}
+	for _, tag := range tags {
+		if len(tag.Values) > 0 {
+			turbotTags[aws.ToString(tag.Key)] = tag.Values[0]
+		}
+	}
+	return turbotTags, nil
+}
@@ -0,0 +1,141 @@
+package aws
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer/types"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsCostSavingsPlanCoverage(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_cost_savings_plan_coverage",
+		Description: "AWS Cost Explorer - Savings Plan Coverage",
+		List: &plugin.ListConfig{
+			Hydrate: listCostSavingsPlanCoverages,
+			Tags:    map[string]string{"service": "ce", "action": "GetSavingsPlansCoverage"},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"DataUnavailableException"}),
+			},
+			KeyColumns: append([]*plugin.KeyColumn{
+				{Name: "granularity", Require: plugin.Required},
+				{Name: "service", Require: plugin.Optional},
+			}, ceTimePeriodKeyColumns()...),
+		},
+		Columns: awsGlobalRegionColumns([]*plugin.Column{
+			{
+				Name:        "period_start",
+				Description: "Start timestamp for this coverage data.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("Coverage.TimePeriod.Start"),
+			},
+			{
+				Name:        "period_end",
+				Description: "End timestamp for this coverage data.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("Coverage.TimePeriod.End"),
+			},
+			{
+				Name:        "granularity",
+				Description: "The granularity of the coverage data. Possible values are: DAILY|MONTHLY.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("granularity"),
+			},
+			{
+				Name:        "service",
+				Description: "The service the coverage data is for, e.g. Amazon Elastic Compute Cloud - Compute. Empty for the coverage of all services.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "coverage_percentage",
+				Description: "The percentage of eligible spend covered by Savings Plans.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Coverage.Coverage.CoveragePercentage"),
+			},
+			{
+				Name:        "spend_covered_by_savings_plans",
+				Description: "The spend covered by Savings Plans.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Coverage.Coverage.SpendCoveredBySavingsPlans"),
+			},
+			{
+				Name:        "on_demand_cost",
+				Description: "The on-demand cost of the usage not covered by Savings Plans.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Coverage.Coverage.OnDemandCost"),
+			},
+			{
+				Name:        "total_cost",
+				Description: "The total eligible spend.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Coverage.Coverage.TotalCost"),
+			},
+			{
+				Name:        "attributes",
+				Description: "The attributes of the coverage data.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Coverage.Attributes"),
+			},
+		}),
+	}
+}
+
+type costSavingsPlanCoverage struct {
+	Service  string
+	Coverage types.SavingsPlansCoverage
+}
+
+//// LIST FUNCTION
+
+func listCostSavingsPlanCoverages(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	svc, err := CostExplorerClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_cost_savings_plan_coverage.listCostSavingsPlanCoverages", "client_error", err)
+		return nil, err
+	}
+
+	granularity := strings.ToUpper(d.EqualsQualString("granularity"))
+
+	// A request is made per service, or a single request for all services
+	for _, service := range getCEQualValues(d, "service", "") {
+		params := &costexplorer.GetSavingsPlansCoverageInput{
+			TimePeriod:  getCETimePeriod(d, granularity),
+			Granularity: types.Granularity(granularity),
+			Filter:      ceServiceFilter(service),
+		}
+
+		for {
+			// apply rate limiting
+			d.WaitForListRateLimit(ctx)
+
+			output, err := svc.GetSavingsPlansCoverage(ctx, params)
+			if err != nil {
+				plugin.Logger(ctx).Error("aws_cost_savings_plan_coverage.listCostSavingsPlanCoverages", "api_error", err)
+				return nil, err
+			}
+
+			for _, coverage := range output.SavingsPlansCoverages {
+				d.StreamListItem(ctx, &costSavingsPlanCoverage{Service: service, Coverage: coverage})
+
+				// Context can be cancelled due to manual cancellation or the limit has been hit
+				if d.RowsRemaining(ctx) == 0 {
+					return nil, nil
+				}
+			}
+
+			if output.NextToken == nil {
+				break
+			}
+			params.NextToken = output.NextToken
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,270 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer/types"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsCostSavingsPlanPurchaseRecommendation(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_cost_savings_plan_purchase_recommendation",
+		Description: "AWS Cost Explorer - Savings Plan Purchase Recommendation",
+		List: &plugin.ListConfig{
+			Hydrate: listCostSavingsPlanPurchaseRecommendations,
+			Tags:    map[string]string{"service": "ce", "action": "GetSavingsPlansPurchaseRecommendation"},
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "savings_plans_type", Require: plugin.Optional},
+				{Name: "term_in_years", Require: plugin.Optional},
+				{Name: "payment_option", Require: plugin.Optional},
+				{Name: "lookback_period_in_days", Require: plugin.Optional},
+				{Name: "account_scope", Require: plugin.Optional},
+			},
+		},
+		Columns: awsGlobalRegionColumns([]*plugin.Column{
+			{
+				Name:        "savings_plans_type",
+				Description: "The type of Savings Plan recommended. Possible values are: COMPUTE_SP|EC2_INSTANCE_SP|SAGEMAKER_SP. Defaults to COMPUTE_SP.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "term_in_years",
+				Description: "The term of the recommended Savings Plan. Possible values are: ONE_YEAR|THREE_YEARS. Defaults to ONE_YEAR.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "payment_option",
+				Description: "The payment option of the recommended Savings Plan. Possible values are: NO_UPFRONT|PARTIAL_UPFRONT|ALL_UPFRONT. Defaults to NO_UPFRONT.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "lookback_period_in_days",
+				Description: "The number of days of usage the recommendation is based on. Possible values are: SEVEN_DAYS|THIRTY_DAYS|SIXTY_DAYS. Defaults to THIRTY_DAYS.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "account_scope",
+				Description: "Whether the recommendation is for the whole organization, or per linked account. Possible values are: PAYER|LINKED. Defaults to PAYER.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "linked_account_id",
+				Description: "The ID of the account the recommendation is for.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Detail.AccountId"),
+			},
+			{
+				Name:        "hourly_commitment_to_purchase",
+				Description: "The recommended hourly commitment to purchase.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Detail.HourlyCommitmentToPurchase"),
+			},
+			{
+				Name:        "upfront_cost",
+				Description: "The upfront cost of the recommended Savings Plan.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Detail.UpfrontCost"),
+			},
+			{
+				Name:        "estimated_monthly_savings_amount",
+				Description: "The estimated monthly savings of the recommended Savings Plan.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Detail.EstimatedMonthlySavingsAmount"),
+			},
+			{
+				Name:        "estimated_savings_amount",
+				Description: "The estimated savings of the recommended Savings Plan over the lookback period.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Detail.EstimatedSavingsAmount"),
+			},
+			{
+				Name:        "estimated_savings_percentage",
+				Description: "The estimated savings as a percentage of the on-demand cost over the lookback period.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Detail.EstimatedSavingsPercentage"),
+			},
+			{
+				Name:        "estimated_roi",
+				Description: "The estimated return on investment of the recommended Savings Plan.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Detail.EstimatedROI"),
+			},
+			{
+				Name:        "estimated_average_utilization",
+				Description: "The estimated utilization of the recommended Savings Plan.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Detail.EstimatedAverageUtilization"),
+			},
+			{
+				Name:        "estimated_sp_cost",
+				Description: "The estimated cost of the recommended Savings Plan over the lookback period.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Detail.EstimatedSPCost"),
+			},
+			{
+				Name:        "estimated_on_demand_cost",
+				Description: "The estimated on-demand cost of the usage the recommended Savings Plan would cover.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Detail.EstimatedOnDemandCost"),
+			},
+			{
+				Name:        "estimated_on_demand_cost_with_current_commitment",
+				Description: "The estimated on-demand cost of the usage with the current Savings Plans.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Detail.EstimatedOnDemandCostWithCurrentCommitment"),
+			},
+			{
+				Name:        "current_average_hourly_on_demand_spend",
+				Description: "The average hourly on-demand spend over the lookback period.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Detail.CurrentAverageHourlyOnDemandSpend"),
+			},
+			{
+				Name:        "current_maximum_hourly_on_demand_spend",
+				Description: "The highest hourly on-demand spend over the lookback period.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Detail.CurrentMaximumHourlyOnDemandSpend"),
+			},
+			{
+				Name:        "current_minimum_hourly_on_demand_spend",
+				Description: "The lowest hourly on-demand spend over the lookback period.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Detail.CurrentMinimumHourlyOnDemandSpend"),
+			},
+			{
+				Name:        "currency_code",
+				Description: "The currency of the costs and savings.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Detail.CurrencyCode"),
+			},
+			{
+				Name:        "instance_family",
+				Description: "The instance family of the recommended EC2 Instance Savings Plan.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Detail.SavingsPlansDetails.InstanceFamily"),
+			},
+			{
+				Name:        "offering_id",
+				Description: "The ID of the recommended Savings Plan offering.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Detail.SavingsPlansDetails.OfferingId"),
+			},
+			{
+				Name:        "savings_plan_region",
+				Description: "The region of the recommended EC2 Instance Savings Plan.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Detail.SavingsPlansDetails.Region"),
+			},
+			{
+				Name:        "recommendation_id",
+				Description: "The ID of the recommendation.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Metadata.RecommendationId"),
+			},
+			{
+				Name:        "generation_timestamp",
+				Description: "The time the recommendation was generated.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("Metadata.GenerationTimestamp"),
+			},
+			{
+				Name:        "summary",
+				Description: "The summary of the recommendations of all accounts.",
+				Type:        proto.ColumnType_JSON,
+			},
+		}),
+	}
+}
+
+type costSavingsPlanPurchaseRecommendation struct {
+	SavingsPlansType     types.SupportedSavingsPlansType
+	TermInYears          types.TermInYears
+	PaymentOption        types.PaymentOption
+	LookbackPeriodInDays types.LookbackPeriodInDays
+	AccountScope         types.AccountScope
+	Detail               types.SavingsPlansPurchaseRecommendationDetail
+	Metadata             *types.SavingsPlansPurchaseRecommendationMetadata
+	Summary              *types.SavingsPlansPurchaseRecommendationSummary
+}
+
+//// LIST FUNCTION
+
+func listCostSavingsPlanPurchaseRecommendations(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	svc, err := CostExplorerClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_cost_savings_plan_purchase_recommendation.listCostSavingsPlanPurchaseRecommendations", "client_error", err)
+		return nil, err
+	}
+
+	// A request is made per combination of the qual values
+	for _, savingsPlansType := range getCEQualValues(d, "savings_plans_type", string(types.SupportedSavingsPlansTypeComputeSp)) {
+		for _, termInYears := range getCEQualValues(d, "term_in_years", string(types.TermInYearsOneYear)) {
+			for _, paymentOption := range getCEQualValues(d, "payment_option", string(types.PaymentOptionNoUpfront)) {
+				for _, lookbackPeriod := range getCEQualValues(d, "lookback_period_in_days", string(types.LookbackPeriodInDaysThirtyDays)) {
+					for _, accountScope := range getCEQualValues(d, "account_scope", string(types.AccountScopePayer)) {
+						params := &costexplorer.GetSavingsPlansPurchaseRecommendationInput{
+							SavingsPlansType:     types.SupportedSavingsPlansType(savingsPlansType),
+							TermInYears:          types.TermInYears(termInYears),
+							PaymentOption:        types.PaymentOption(paymentOption),
+							LookbackPeriodInDays: types.LookbackPeriodInDays(lookbackPeriod),
+							AccountScope:         types.AccountScope(accountScope),
+						}
+						done, err := streamCostSavingsPlanPurchaseRecommendations(ctx, d, svc, params)
+						if err != nil || done {
+							return nil, err
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+func streamCostSavingsPlanPurchaseRecommendations(ctx context.Context, d *plugin.QueryData, svc *costexplorer.Client, params *costexplorer.GetSavingsPlansPurchaseRecommendationInput) (bool, error) {
+	for {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		output, err := svc.GetSavingsPlansPurchaseRecommendation(ctx, params)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_cost_savings_plan_purchase_recommendation.streamCostSavingsPlanPurchaseRecommendations", "api_error", err)
+			return false, err
+		}
+
+		if recommendation := output.SavingsPlansPurchaseRecommendation; recommendation != nil {
+			for _, detail := range recommendation.SavingsPlansPurchaseRecommendationDetails {
+				d.StreamListItem(ctx, &costSavingsPlanPurchaseRecommendation{
+					SavingsPlansType:     params.SavingsPlansType,
+					TermInYears:          params.TermInYears,
+					PaymentOption:        params.PaymentOption,
+					LookbackPeriodInDays: params.LookbackPeriodInDays,
+					AccountScope:         params.AccountScope,
+					Detail:               detail,
+					Metadata:             output.Metadata,
+					Summary:              recommendation.SavingsPlansPurchaseRecommendationSummary,
+				})
+
+				// Context can be cancelled due to manual cancellation or the limit has been hit
+				if d.RowsRemaining(ctx) == 0 {
+					return true, nil
+				}
+			}
+		}
+
+		if output.NextPageToken == nil {
+			break
+		}
+		params.NextPageToken = output.NextPageToken
+	}
+
+	return false, nil
+}
@@ -0,0 +1,142 @@
+package aws
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer/types"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsCostSavingsPlanUtilization(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_cost_savings_plan_utilization",
+		Description: "AWS Cost Explorer - Savings Plan Utilization",
+		List: &plugin.ListConfig{
+			Hydrate: listCostSavingsPlanUtilizations,
+			Tags:    map[string]string{"service": "ce", "action": "GetSavingsPlansUtilization"},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"DataUnavailableException"}),
+			},
+			KeyColumns: append([]*plugin.KeyColumn{
+				{Name: "granularity", Require: plugin.Required},
+			}, ceTimePeriodKeyColumns()...),
+		},
+		Columns: awsGlobalRegionColumns([]*plugin.Column{
+			{
+				Name:        "period_start",
+				Description: "Start timestamp for this utilization data.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("TimePeriod.Start"),
+			},
+			{
+				Name:        "period_end",
+				Description: "End timestamp for this utilization data.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("TimePeriod.End"),
+			},
+			{
+				Name:        "granularity",
+				Description: "The granularity of the utilization data. Possible values are: DAILY|MONTHLY.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("granularity"),
+			},
+			{
+				Name:        "total_commitment",
+				Description: "The total commitment of the Savings Plans.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Utilization.TotalCommitment"),
+			},
+			{
+				Name:        "used_commitment",
+				Description: "The commitment used by eligible usage.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Utilization.UsedCommitment"),
+			},
+			{
+				Name:        "unused_commitment",
+				Description: "The commitment not used by eligible usage.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Utilization.UnusedCommitment"),
+			},
+			{
+				Name:        "utilization_percentage",
+				Description: "The percentage of the commitment used by eligible usage.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Utilization.UtilizationPercentage"),
+			},
+			{
+				Name:        "net_savings",
+				Description: "The savings of the Savings Plans compared to on-demand, net of the commitment.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Savings.NetSavings"),
+			},
+			{
+				Name:        "on_demand_cost_equivalent",
+				Description: "The on-demand cost of the usage covered by the Savings Plans.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("Savings.OnDemandCostEquivalent"),
+			},
+			{
+				Name:        "amortized_recurring_commitment",
+				Description: "The amortized recurring commitment of No Upfront and Partial Upfront Savings Plans.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("AmortizedCommitment.AmortizedRecurringCommitment"),
+			},
+			{
+				Name:        "amortized_upfront_commitment",
+				Description: "The amortized upfront commitment of All Upfront and Partial Upfront Savings Plans.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("AmortizedCommitment.AmortizedUpfrontCommitment"),
+			},
+			{
+				Name:        "total_amortized_commitment",
+				Description: "The total amortized commitment of the Savings Plans.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("AmortizedCommitment.TotalAmortizedCommitment"),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listCostSavingsPlanUtilizations(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	svc, err := CostExplorerClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_cost_savings_plan_utilization.listCostSavingsPlanUtilizations", "client_error", err)
+		return nil, err
+	}
+
+	granularity := strings.ToUpper(d.EqualsQualString("granularity"))
+	params := &costexplorer.GetSavingsPlansUtilizationInput{
+		TimePeriod:  getCETimePeriod(d, granularity),
+		Granularity: types.Granularity(granularity),
+	}
+
+	// apply rate limiting
+	d.WaitForListRateLimit(ctx)
+
+	output, err := svc.GetSavingsPlansUtilization(ctx, params)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_cost_savings_plan_utilization.listCostSavingsPlanUtilizations", "api_error", err)
+		return nil, err
+	}
+
+	for _, utilization := range output.SavingsPlansUtilizationsByTime {
+		d.StreamListItem(ctx, utilization)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+
+	return nil, nil
+}
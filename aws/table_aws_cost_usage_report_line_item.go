@@ -0,0 +1,894 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/bcmdataexports"
+	bcmtypes "github.com/aws/aws-sdk-go-v2/service/bcmdataexports/types"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/parquet-go/parquet-go"
+	"github.com/turbot/go-kit/helpers"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// Cost and usage reports are read from the Parquet files written to S3 by CUR
+// 2.0 Data Exports, or by legacy CUR reports in Parquet format. Each billing
+// period has a manifest listing its current data files, so only the manifests
+// of the requested billing periods are read, and only their data files are
+// downloaded.
+
+// Columns read from the data files. Legacy CUR reports have a column per tag
+// key instead of resource_tags, which are read as well.
+var costUsageReportColumns = map[string]bool{
+	"bill_billing_period_start_date":                              true,
+	"bill_billing_period_end_date":                                true,
+	"bill_payer_account_id":                                       true,
+	"identity_line_item_id":                                       true,
+	"line_item_blended_cost":                                      true,
+	"line_item_currency_code":                                     true,
+	"line_item_line_item_description":                             true,
+	"line_item_line_item_type":                                    true,
+	"line_item_net_unblended_cost":                                true,
+	"line_item_operation":                                         true,
+	"line_item_product_code":                                      true,
+	"line_item_resource_id":                                       true,
+	"line_item_unblended_cost":                                    true,
+	"line_item_unblended_rate":                                    true,
+	"line_item_usage_account_id":                                  true,
+	"line_item_usage_amount":                                      true,
+	"line_item_usage_end_date":                                    true,
+	"line_item_usage_start_date":                                  true,
+	"line_item_usage_type":                                        true,
+	"pricing_public_on_demand_cost":                               true,
+	"pricing_unit":                                                true,
+	"product_region":                                              true,
+	"product_region_code":                                         true,
+	"reservation_effective_cost":                                  true,
+	"reservation_reservation_a_r_n":                               true,
+	"reservation_unused_amortized_upfront_fee_for_billing_period": true,
+	"reservation_unused_recurring_fee":                            true,
+	"resource_tags":                                               true,
+	"savings_plan_savings_plan_a_r_n":                             true,
+	"savings_plan_savings_plan_effective_cost":                    true,
+	"savings_plan_total_commitment_to_date":                       true,
+	"savings_plan_used_commitment":                                true,
+}
+
+// The billing period of a manifest, from CUR 2.0 (BILLING_PERIOD=2024-05) and
+// legacy CUR (20240501-20240601) object keys
+var (
+	costUsageReportExportPeriodRegexp = regexp.MustCompile(`/BILLING_PERIOD=(\d{4}-\d{2})/`)
+	costUsageReportLegacyPeriodRegexp = regexp.MustCompile(`/(\d{8})-\d{8}/`)
+)
+
+//// TABLE DEFINITION
+
+func tableAwsCostUsageReportLineItem(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_cost_usage_report_line_item",
+		Description: "AWS Cost and Usage Report Line Item",
+		List: &plugin.ListConfig{
+			Hydrate: listCostUsageReportLineItems,
+			Tags:    map[string]string{"service": "s3", "action": "GetObject"},
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "export_arn", Require: plugin.Optional},
+				{Name: "bucket_name", Require: plugin.Optional},
+				{Name: "prefix", Require: plugin.Optional, CacheMatch: "exact"},
+				{Name: "billing_period_start", Operators: []string{">", ">=", "=", "<", "<="}, Require: plugin.Optional},
+				{Name: "usage_account_id", Require: plugin.Optional},
+			},
+		},
+		Columns: awsGlobalRegionColumns([]*plugin.Column{
+			{
+				Name:        "export_arn",
+				Description: "The ARN of the data export the line item was read from. Not set if the report was read from the bucket_name and prefix quals.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "bucket_name",
+				Description: "The name of the bucket the report is delivered to.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "prefix",
+				Description: "The key prefix to look for report manifests under, when reading a report from the bucket_name qual.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("prefix"),
+			},
+			{
+				Name:        "file_key",
+				Description: "The key of the data file the line item was read from.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "billing_period_start",
+				Description: "The start of the billing period the line item belongs to.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "billing_period_end",
+				Description: "The end of the billing period the line item belongs to.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "payer_account_id",
+				Description: "The ID of the account that pays for the line item.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "usage_account_id",
+				Description: "The ID of the account that used the resource of the line item.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "line_item_id",
+				Description: "The ID of the line item, unique within a billing period.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "line_item_type",
+				Description: "The type of charge of the line item, e.g. Usage, Tax, DiscountedUsage or SavingsPlanCoveredUsage.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "product_code",
+				Description: "The code of the product measured, e.g. AmazonEC2.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "usage_type",
+				Description: "The usage details of the line item, e.g. USW2-BoxUsage:m5.large.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "operation",
+				Description: "The operation covered by the line item, e.g. RunInstances.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "resource_id",
+				Description: "The ID of the resource of the line item, if the report includes resource IDs.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "description",
+				Description: "The description of the line item.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "product_region_code",
+				Description: "The region of the product of the line item.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "usage_start_date",
+				Description: "The start of the usage of the line item.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "usage_end_date",
+				Description: "The end of the usage of the line item.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "usage_amount",
+				Description: "The amount of usage of the line item, in pricing_unit.",
+				Type:        proto.ColumnType_DOUBLE,
+			},
+			{
+				Name:        "pricing_unit",
+				Description: "The unit usage is measured and priced in, e.g. Hrs.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "currency_code",
+				Description: "The currency of the costs of the line item.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "unblended_rate",
+				Description: "The rate the usage of the line item is charged at.",
+				Type:        proto.ColumnType_DOUBLE,
+			},
+			{
+				Name:        "unblended_cost",
+				Description: "The cost of the line item, at the unblended rate.",
+				Type:        proto.ColumnType_DOUBLE,
+			},
+			{
+				Name:        "blended_cost",
+				Description: "The cost of the line item, at the average rate across the consolidated billing family.",
+				Type:        proto.ColumnType_DOUBLE,
+			},
+			{
+				Name:        "net_unblended_cost",
+				Description: "The cost of the line item after discounts. Only available if the report includes discounts.",
+				Type:        proto.ColumnType_DOUBLE,
+			},
+			{
+				Name:        "public_on_demand_cost",
+				Description: "The cost of the line item at public on-demand rates.",
+				Type:        proto.ColumnType_DOUBLE,
+			},
+			{
+				Name:        "amortized_cost",
+				Description: "The cost of the line item with Savings Plan and Reserved Instance fees spread across the usage they cover.",
+				Type:        proto.ColumnType_DOUBLE,
+			},
+			{
+				Name:        "savings_plan_arn",
+				Description: "The ARN of the Savings Plan covering the line item.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "savings_plan_effective_cost",
+				Description: "The share of the Savings Plan commitment allocated to the usage of the line item.",
+				Type:        proto.ColumnType_DOUBLE,
+			},
+			{
+				Name:        "reservation_arn",
+				Description: "The ARN of the Reserved Instance covering the line item.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "reservation_effective_cost",
+				Description: "The share of the upfront and hourly Reserved Instance fees allocated to the usage of the line item.",
+				Type:        proto.ColumnType_DOUBLE,
+			},
+			{
+				Name:        "tags",
+				Description: "The cost allocation tags of the resource of the line item.",
+				Type:        proto.ColumnType_JSON,
+			},
+		}),
+	}
+}
+
+type costUsageReportSource struct {
+	ExportArn *string
+	Bucket    string
+	Region    string
+	Prefix    string
+}
+
+type costUsageReportManifest struct {
+	Key          string
+	LastModified time.Time
+	Period       string
+}
+
+type costUsageReportLineItem struct {
+	ExportArn                *string
+	BucketName               string
+	FileKey                  string
+	BillingPeriodStart       *time.Time
+	BillingPeriodEnd         *time.Time
+	PayerAccountId           *string
+	UsageAccountId           *string
+	LineItemId               *string
+	LineItemType             *string
+	ProductCode              *string
+	UsageType                *string
+	Operation                *string
+	ResourceId               *string
+	Description              *string
+	ProductRegionCode        *string
+	UsageStartDate           *time.Time
+	UsageEndDate             *time.Time
+	UsageAmount              *float64
+	PricingUnit              *string
+	CurrencyCode             *string
+	UnblendedRate            *float64
+	UnblendedCost            *float64
+	BlendedCost              *float64
+	NetUnblendedCost         *float64
+	PublicOnDemandCost       *float64
+	AmortizedCost            *float64
+	SavingsPlanArn           *string
+	SavingsPlanEffectiveCost *float64
+	ReservationArn           *string
+	ReservationEffectiveCost *float64
+	Tags                     map[string]string
+}
+
+//// LIST FUNCTION
+
+func listCostUsageReportLineItems(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	sources, err := listCostUsageReportSources(ctx, d, h)
+	if err != nil {
+		return nil, err
+	}
+
+	var accounts []string
+	switch value := getQualsValueByColumn(d.Quals, "usage_account_id", "string").(type) {
+	case string:
+		accounts = []string{value}
+	case []*string:
+		accounts = aws.ToStringSlice(value)
+	}
+
+	for _, source := range sources {
+		svc, err := S3Client(ctx, d, source.Region)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_cost_usage_report_line_item.listCostUsageReportLineItems", "get_client_error", err, "region", source.Region)
+			return nil, err
+		}
+
+		manifests, err := listCostUsageReportManifests(ctx, d, svc, source)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, manifest := range manifests {
+			bucket, keys, err := getCostUsageReportDataFiles(ctx, d, svc, source.Bucket, manifest.Key)
+			if err != nil {
+				return nil, err
+			}
+			for _, key := range keys {
+				done, err := streamCostUsageReportFile(ctx, d, svc, source, bucket, key, accounts)
+				if err != nil {
+					return nil, err
+				}
+				if done {
+					return nil, nil
+				}
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// listCostUsageReportSources returns the reports to read. A report is read
+// from the bucket_name and prefix quals if set, otherwise from the Parquet
+// cost and usage report exports of the account, or the one in export_arn.
+func listCostUsageReportSources(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) ([]costUsageReportSource, error) {
+	if bucket := d.EqualsQualString("bucket_name"); bucket != "" {
+		region, err := getBucketRegionByName(ctx, d, h, bucket)
+		if err != nil {
+			return nil, err
+		}
+		return []costUsageReportSource{{Bucket: bucket, Region: region, Prefix: d.EqualsQualString("prefix")}}, nil
+	}
+
+	svc, err := BCMDataExportsClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_cost_usage_report_line_item.listCostUsageReportSources", "get_client_error", err)
+		return nil, err
+	}
+
+	var arns []string
+	if arn := d.EqualsQualString("export_arn"); arn != "" {
+		arns = append(arns, arn)
+	} else {
+		paginator := bcmdataexports.NewListExportsPaginator(svc, &bcmdataexports.ListExportsInput{})
+		for paginator.HasMorePages() {
+			// apply rate limiting
+			d.WaitForListRateLimit(ctx)
+
+			output, err := paginator.NextPage(ctx)
+			if err != nil {
+				plugin.Logger(ctx).Error("aws_cost_usage_report_line_item.listCostUsageReportSources", "api_error", err)
+				return nil, err
+			}
+			for _, export := range output.Exports {
+				arns = append(arns, aws.ToString(export.ExportArn))
+			}
+		}
+	}
+
+	var sources []costUsageReportSource
+	for _, arn := range arns {
+		output, err := svc.GetExport(ctx, &bcmdataexports.GetExportInput{ExportArn: aws.String(arn)})
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_cost_usage_report_line_item.listCostUsageReportSources", "api_error", err, "export_arn", arn)
+			return nil, err
+		}
+		export := output.Export
+		if export == nil || export.DestinationConfigurations == nil || export.DestinationConfigurations.S3Destination == nil {
+			continue
+		}
+		destination := export.DestinationConfigurations.S3Destination
+		if destination.S3OutputConfigurations == nil || destination.S3OutputConfigurations.Format != bcmtypes.FormatOptionParquet {
+			continue
+		}
+		if export.DataQuery == nil || !strings.Contains(strings.ToUpper(aws.ToString(export.DataQuery.QueryStatement)), "COST_AND_USAGE_REPORT") {
+			continue
+		}
+
+		// Exports are written to <prefix>/<export name>/metadata and
+		// <prefix>/<export name>/data
+		prefix := aws.ToString(export.Name) + "/metadata/"
+		if p := strings.Trim(aws.ToString(destination.S3Prefix), "/"); p != "" {
+			prefix = p + "/" + prefix
+		}
+		sources = append(sources, costUsageReportSource{
+			ExportArn: aws.String(arn),
+			Bucket:    aws.ToString(destination.S3Bucket),
+			Region:    aws.ToString(destination.S3Region),
+			Prefix:    prefix,
+		})
+	}
+
+	return sources, nil
+}
+
+// listCostUsageReportManifests returns the current manifest of each billing
+// period of a report that matches the billing_period_start quals, oldest
+// first. Reports can keep a manifest per refresh of a billing period, so the
+// last modified one is used.
+func listCostUsageReportManifests(ctx context.Context, d *plugin.QueryData, svc *s3.Client, source costUsageReportSource) ([]costUsageReportManifest, error) {
+	latest := map[string]costUsageReportManifest{}
+
+	paginator := s3.NewListObjectsV2Paginator(svc, &s3.ListObjectsV2Input{
+		Bucket: aws.String(source.Bucket),
+		Prefix: aws.String(source.Prefix),
+	})
+	for paginator.HasMorePages() {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_cost_usage_report_line_item.listCostUsageReportManifests", "list_objects_error", err, "bucket", source.Bucket, "prefix", source.Prefix)
+			return nil, err
+		}
+
+		for _, object := range output.Contents {
+			key := aws.ToString(object.Key)
+			if !strings.HasSuffix(key, "-Manifest.json") {
+				continue
+			}
+
+			var period time.Time
+			if match := costUsageReportExportPeriodRegexp.FindStringSubmatch(key); match != nil {
+				period, _ = time.Parse("2006-01", match[1])
+			} else if match := costUsageReportLegacyPeriodRegexp.FindStringSubmatch(key); match != nil {
+				// Legacy reports also keep the manifest of each report version
+				// in a sub folder of the billing period
+				if !strings.HasSuffix(key[:strings.LastIndex(key, "/")+1], match[0]) {
+					continue
+				}
+				period, _ = time.Parse("20060102", match[1])
+			}
+			if period.IsZero() || !costUsageReportPeriodMatches(d, period) {
+				continue
+			}
+
+			manifest := costUsageReportManifest{Key: key, LastModified: aws.ToTime(object.LastModified), Period: period.Format("2006-01")}
+			if current, ok := latest[manifest.Period]; !ok || manifest.LastModified.After(current.LastModified) {
+				latest[manifest.Period] = manifest
+			}
+		}
+	}
+
+	manifests := make([]costUsageReportManifest, 0, len(latest))
+	for _, manifest := range latest {
+		manifests = append(manifests, manifest)
+	}
+	sort.Slice(manifests, func(i, j int) bool { return manifests[i].Period < manifests[j].Period })
+
+	return manifests, nil
+}
+
+// costUsageReportPeriodMatches returns true if a billing period matches the
+// billing_period_start quals
+func costUsageReportPeriodMatches(d *plugin.QueryData, period time.Time) bool {
+	if d.Quals["billing_period_start"] == nil {
+		return true
+	}
+	for _, q := range d.Quals["billing_period_start"].Quals {
+		timestamp := q.Value.GetTimestampValue().AsTime()
+		switch q.Operator {
+		case "=":
+			if !period.Equal(timestamp) {
+				return false
+			}
+		case ">":
+			if !period.After(timestamp) {
+				return false
+			}
+		case ">=":
+			if period.Before(timestamp) {
+				return false
+			}
+		case "<":
+			if !period.Before(timestamp) {
+				return false
+			}
+		case "<=":
+			if period.After(timestamp) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// getCostUsageReportDataFiles returns the bucket and keys of the Parquet data
+// files listed in a manifest. CUR 2.0 manifests list S3 URIs in dataFiles,
+// legacy CUR manifests list keys in reportKeys.
+func getCostUsageReportDataFiles(ctx context.Context, d *plugin.QueryData, svc *s3.Client, bucket string, key string) (string, []string, error) {
+	d.WaitForListRateLimit(ctx)
+
+	object, err := svc.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_cost_usage_report_line_item.getCostUsageReportDataFiles", "get_object_error", err, "bucket", bucket, "key", key)
+		return "", nil, err
+	}
+	defer object.Body.Close()
+
+	var manifest struct {
+		DataFiles  []string `json:"dataFiles"`
+		ReportKeys []string `json:"reportKeys"`
+		Bucket     string   `json:"bucket"`
+	}
+	if err := json.NewDecoder(object.Body).Decode(&manifest); err != nil {
+		plugin.Logger(ctx).Error("aws_cost_usage_report_line_item.getCostUsageReportDataFiles", "unmarshal_error", err, "bucket", bucket, "key", key)
+		return "", nil, fmt.Errorf("failed to parse manifest s3://%s/%s: %v", bucket, key, err)
+	}
+
+	keys := manifest.ReportKeys
+	if manifest.Bucket != "" {
+		bucket = manifest.Bucket
+	}
+	for _, uri := range manifest.DataFiles {
+		path := strings.TrimPrefix(uri, "s3://")
+		if i := strings.Index(path, "/"); i > 0 {
+			bucket = path[:i]
+			keys = append(keys, path[i+1:])
+		}
+	}
+
+	var parquetKeys []string
+	for _, key := range keys {
+		if strings.HasSuffix(key, ".parquet") {
+			parquetKeys = append(parquetKeys, key)
+		}
+	}
+	return bucket, parquetKeys, nil
+}
+
+// streamCostUsageReportFile streams the line items of a data file. Parquet
+// files are read from the end, so the file is downloaded to a temporary file
+// first. It returns true once no more rows are required by the query.
+func streamCostUsageReportFile(ctx context.Context, d *plugin.QueryData, svc *s3.Client, source costUsageReportSource, bucket string, key string, accounts []string) (bool, error) {
+	d.WaitForListRateLimit(ctx)
+
+	object, err := svc.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_cost_usage_report_line_item.streamCostUsageReportFile", "get_object_error", err, "bucket", bucket, "key", key)
+		return false, err
+	}
+	defer object.Body.Close()
+
+	tmp, err := os.CreateTemp("", "aws-cost-usage-report-*.parquet")
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_cost_usage_report_line_item.streamCostUsageReportFile", "temp_file_error", err)
+		return false, err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	size, err := io.Copy(tmp, object.Body)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_cost_usage_report_line_item.streamCostUsageReportFile", "download_error", err, "bucket", bucket, "key", key)
+		return false, err
+	}
+
+	file, err := parquet.OpenFile(tmp, size, parquet.SkipPageIndex(true), parquet.SkipBloomFilters(true))
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_cost_usage_report_line_item.streamCostUsageReportFile", "parquet_error", err, "bucket", bucket, "key", key)
+		return false, err
+	}
+
+	// Only the columns of the table are read, out of the 100+ of a report
+	fields := parquet.Group{}
+	for _, field := range file.Schema().Fields() {
+		if costUsageReportColumns[field.Name()] || strings.HasPrefix(field.Name(), "resource_tags_") {
+			fields[field.Name()] = field
+		}
+	}
+	schema := parquet.NewSchema("cost_usage_report", fields)
+	columns := schema.Columns()
+	timeUnits := costUsageReportTimeUnits(schema)
+
+	accountColumn, hasAccountColumn := file.Schema().Lookup("line_item_usage_account_id")
+	for i, rowGroup := range file.RowGroups() {
+		// Skip row groups without the requested accounts, based on the
+		// min and max account ID of the row group
+		if len(accounts) > 0 && hasAccountColumn {
+			statistics := file.Metadata().RowGroups[i].Columns[accountColumn.ColumnIndex].MetaData.Statistics
+			if !costUsageReportStatisticsInclude(statistics.MinValue, statistics.MaxValue, accounts) {
+				continue
+			}
+		}
+
+		reader := parquet.NewRowGroupReader(rowGroup, schema)
+		rows := make([]parquet.Row, 100)
+		for {
+			n, err := reader.ReadRows(rows)
+			for _, row := range rows[:n] {
+				item := newCostUsageReportLineItem(costUsageReportRowValues(columns, row), timeUnits)
+				if len(accounts) > 0 && !helpers.StringSliceContains(accounts, aws.ToString(item.UsageAccountId)) {
+					continue
+				}
+				item.ExportArn = source.ExportArn
+				item.BucketName = bucket
+				item.FileKey = key
+				d.StreamListItem(ctx, item)
+
+				// Context can be cancelled due to manual cancellation or the limit has been hit
+				if d.RowsRemaining(ctx) == 0 {
+					return true, nil
+				}
+			}
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				plugin.Logger(ctx).Error("aws_cost_usage_report_line_item.streamCostUsageReportFile", "parquet_error", err, "bucket", bucket, "key", key)
+				return false, err
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// costUsageReportRowValues returns the values of a row by column name. Rows
+// are read as column values rather than reconstructed into Go values, since
+// parquet-go can't reconstruct empty maps into an interface{}. The only nested
+// column read is the resource_tags map, whose keys and values are separate
+// leaf columns.
+func costUsageReportRowValues(columns [][]string, row parquet.Row) map[string]interface{} {
+	values := map[string]interface{}{}
+	var tagKeys, tagValues []interface{}
+	row.Range(func(columnIndex int, columnValues []parquet.Value) bool {
+		path := columns[columnIndex]
+		if len(path) > 1 {
+			if path[0] == "resource_tags" {
+				for _, v := range columnValues {
+					switch path[len(path)-1] {
+					case "key":
+						tagKeys = append(tagKeys, costUsageReportValue(v))
+					case "value":
+						tagValues = append(tagValues, costUsageReportValue(v))
+					}
+				}
+			}
+			return true
+		}
+		if len(columnValues) > 0 {
+			if v := costUsageReportValue(columnValues[0]); v != nil {
+				values[path[0]] = v
+			}
+		}
+		return true
+	})
+
+	if len(tagKeys) > 0 {
+		tags := map[string]interface{}{}
+		for i, k := range tagKeys {
+			if key, ok := k.(string); ok && i < len(tagValues) {
+				tags[key] = tagValues[i]
+			}
+		}
+		values["resource_tags"] = tags
+	}
+
+	return values
+}
+
+func costUsageReportValue(v parquet.Value) interface{} {
+	if v.IsNull() {
+		return nil
+	}
+	switch v.Kind() {
+	case parquet.Boolean:
+		return v.Boolean()
+	case parquet.Int32:
+		return int64(v.Int32())
+	case parquet.Int64:
+		return v.Int64()
+	case parquet.Float:
+		return float64(v.Float())
+	case parquet.Double:
+		return v.Double()
+	case parquet.ByteArray, parquet.FixedLenByteArray:
+		return string(v.ByteArray())
+	}
+	return nil
+}
+
+// costUsageReportStatisticsInclude returns true if any of the values is within
+// the min and max statistics of a column. Missing statistics include all values.
+func costUsageReportStatisticsInclude(min []byte, max []byte, values []string) bool {
+	if len(min) == 0 || len(max) == 0 {
+		return true
+	}
+	for _, value := range values {
+		if value >= string(min) && value <= string(max) {
+			return true
+		}
+	}
+	return false
+}
+
+// Timestamps are read as integers, so the unit of each timestamp column is
+// needed to convert them, as nanoseconds per unit
+func costUsageReportTimeUnits(schema *parquet.Schema) map[string]int64 {
+	units := map[string]int64{}
+	for _, path := range schema.Columns() {
+		column, _ := schema.Lookup(path...)
+		logicalType := column.Node.Type().LogicalType()
+		if logicalType == nil || logicalType.Timestamp == nil {
+			continue
+		}
+		switch unit := logicalType.Timestamp.Unit; {
+		case unit.Millis != nil:
+			units[path[0]] = int64(time.Millisecond)
+		case unit.Micros != nil:
+			units[path[0]] = int64(time.Microsecond)
+		default:
+			units[path[0]] = 1
+		}
+	}
+	return units
+}
+
+func newCostUsageReportLineItem(row map[string]interface{}, timeUnits map[string]int64) *costUsageReportLineItem {
+	str := func(column string) *string { return costUsageReportString(row[column]) }
+	num := func(column string) *float64 { return costUsageReportFloat(row[column]) }
+	ts := func(column string) *time.Time { return costUsageReportTime(row[column], timeUnits[column]) }
+
+	item := &costUsageReportLineItem{
+		BillingPeriodStart:       ts("bill_billing_period_start_date"),
+		BillingPeriodEnd:         ts("bill_billing_period_end_date"),
+		PayerAccountId:           str("bill_payer_account_id"),
+		UsageAccountId:           str("line_item_usage_account_id"),
+		LineItemId:               str("identity_line_item_id"),
+		LineItemType:             str("line_item_line_item_type"),
+		ProductCode:              str("line_item_product_code"),
+		UsageType:                str("line_item_usage_type"),
+		Operation:                str("line_item_operation"),
+		ResourceId:               str("line_item_resource_id"),
+		Description:              str("line_item_line_item_description"),
+		ProductRegionCode:        str("product_region_code"),
+		UsageStartDate:           ts("line_item_usage_start_date"),
+		UsageEndDate:             ts("line_item_usage_end_date"),
+		UsageAmount:              num("line_item_usage_amount"),
+		PricingUnit:              str("pricing_unit"),
+		CurrencyCode:             str("line_item_currency_code"),
+		UnblendedRate:            num("line_item_unblended_rate"),
+		UnblendedCost:            num("line_item_unblended_cost"),
+		BlendedCost:              num("line_item_blended_cost"),
+		NetUnblendedCost:         num("line_item_net_unblended_cost"),
+		PublicOnDemandCost:       num("pricing_public_on_demand_cost"),
+		SavingsPlanArn:           str("savings_plan_savings_plan_a_r_n"),
+		SavingsPlanEffectiveCost: num("savings_plan_savings_plan_effective_cost"),
+		ReservationArn:           str("reservation_reservation_a_r_n"),
+		ReservationEffectiveCost: num("reservation_effective_cost"),
+		Tags:                     map[string]string{},
+	}
+	if item.ProductRegionCode == nil {
+		item.ProductRegionCode = str("product_region")
+	}
+
+	// Amortized cost, as calculated in the CUR documentation
+	// https://docs.aws.amazon.com/cur/latest/userguide/cur-sp.html
+	// https://docs.aws.amazon.com/cur/latest/userguide/amortized-reservation.html
+	value := func(v *float64) float64 { return aws.ToFloat64(v) }
+	switch aws.ToString(item.LineItemType) {
+	case "SavingsPlanCoveredUsage":
+		item.AmortizedCost = aws.Float64(value(item.SavingsPlanEffectiveCost))
+	case "SavingsPlanRecurringFee":
+		item.AmortizedCost = aws.Float64(value(num("savings_plan_total_commitment_to_date")) - value(num("savings_plan_used_commitment")))
+	case "SavingsPlanNegation", "SavingsPlanUpfrontFee":
+		item.AmortizedCost = aws.Float64(0)
+	case "DiscountedUsage":
+		item.AmortizedCost = aws.Float64(value(item.ReservationEffectiveCost))
+	case "RIFee":
+		item.AmortizedCost = aws.Float64(value(num("reservation_unused_amortized_upfront_fee_for_billing_period")) + value(num("reservation_unused_recurring_fee")))
+	case "Fee":
+		if item.ReservationArn != nil {
+			item.AmortizedCost = aws.Float64(0)
+		} else {
+			item.AmortizedCost = item.UnblendedCost
+		}
+	default:
+		item.AmortizedCost = item.UnblendedCost
+	}
+
+	// CUR 2.0 has a map of tags, legacy CUR has a column per tag, named e.g.
+	// resource_tags_user_cost_center for the user:cost-center tag. The original
+	// tag key can't be recovered from the column name, so the first "_" is
+	// replaced with ":".
+	if tags, ok := row["resource_tags"].(map[string]interface{}); ok {
+		for k, v := range tags {
+			if s := costUsageReportString(v); s != nil {
+				item.Tags[k] = *s
+			}
+		}
+	}
+	for column, v := range row {
+		if !strings.HasPrefix(column, "resource_tags_") {
+			continue
+		}
+		if s := costUsageReportString(v); s != nil {
+			item.Tags[strings.Replace(strings.TrimPrefix(column, "resource_tags_"), "_", ":", 1)] = *s
+		}
+	}
+
+	return item
+}
+
+func costUsageReportString(v interface{}) *string {
+	switch v := v.(type) {
+	case string:
+		if v != "" {
+			return aws.String(v)
+		}
+	case int64:
+		return aws.String(strconv.FormatInt(v, 10))
+	case float64:
+		return aws.String(strconv.FormatFloat(v, 'f', -1, 64))
+	}
+	return nil
+}
+
+func costUsageReportFloat(v interface{}) *float64 {
+	switch v := v.(type) {
+	case float64:
+		return aws.Float64(v)
+	case float32:
+		return aws.Float64(float64(v))
+	case int64:
+		return aws.Float64(float64(v))
+	case int32:
+		return aws.Float64(float64(v))
+	case string:
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return aws.Float64(f)
+		}
+	}
+	return nil
+}
+
+func costUsageReportTime(v interface{}, unit int64) *time.Time {
+	switch v := v.(type) {
+	case time.Time:
+		return aws.Time(v)
+	case int64:
+		if unit == 0 {
+			unit = int64(time.Millisecond)
+		}
+		return aws.Time(time.Unix(0, v*unit).UTC())
+	case string:
+		for _, layout := range []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02T15:04:05.000Z"} {
+			if t, err := time.Parse(layout, v); err == nil {
+				return aws.Time(t)
+			}
+		}
+	}
+	return nil
+}
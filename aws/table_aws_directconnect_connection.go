@@ -0,0 +1,286 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/directconnect"
+	"github.com/aws/aws-sdk-go-v2/service/directconnect/types"
+
+	directconnectv1 "github.com/aws/aws-sdk-go/service/directconnect"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsDirectConnectConnection(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_directconnect_connection",
+		Description: "AWS Direct Connect Connection",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("connection_id"),
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"DirectConnectClientException"}),
+			},
+			Hydrate: getDirectConnectConnection,
+			Tags:    map[string]string{"service": "directconnect", "action": "DescribeConnections"},
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listDirectConnectConnections,
+			Tags:    map[string]string{"service": "directconnect", "action": "DescribeConnections"},
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "lag_id", Require: plugin.Optional},
+			},
+		},
+		GetMatrixItemFunc: SupportedRegionMatrix(directconnectv1.EndpointsID),
+		Columns: awsRegionalColumns([]*plugin.Column{
+			{
+				Name:        "connection_id",
+				Description: "The ID of the connection.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "connection_name",
+				Description: "The name of the connection.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "arn",
+				Description: "The Amazon Resource Name (ARN) of the connection.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getDirectConnectConnectionArn,
+				Transform:   transform.FromValue(),
+			},
+			{
+				Name:        "connection_state",
+				Description: "The state of the connection, e.g. ordering, requested, pending, available, down, deleting, deleted, rejected or unknown.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "bandwidth",
+				Description: "The bandwidth of the connection.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "location",
+				Description: "The location of the connection.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "lag_id",
+				Description: "The ID of the link aggregation group (LAG) the connection belongs to.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "owner_account",
+				Description: "The ID of the Amazon Web Services account that owns the connection.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "partner_name",
+				Description: "The name of the Direct Connect service provider associated with a hosted connection.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "provider_name",
+				Description: "The name of the service provider associated with the connection.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "vlan",
+				Description: "The ID of the VLAN of a hosted connection.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "aws_device_v2",
+				Description: "The Direct Connect endpoint that terminates the physical connection.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "aws_logical_device_id",
+				Description: "The Direct Connect endpoint that terminates the logical connection.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "has_logical_redundancy",
+				Description: "Indicates whether the connection supports a secondary BGP peer in the same address family (IPv4/IPv6).",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "jumbo_frame_capable",
+				Description: "Indicates whether jumbo frames are supported.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "mac_sec_capable",
+				Description: "Indicates whether the connection supports MAC Security (MACsec).",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "encryption_mode",
+				Description: "The MAC Security (MACsec) connection encryption mode, i.e. no_encrypt, should_encrypt or must_encrypt.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "port_encryption_status",
+				Description: "The MAC Security (MACsec) port link status of the connection.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "mac_sec_keys",
+				Description: "The MAC Security (MACsec) security keys associated with the connection.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "loa_issue_time",
+				Description: "The time of the most recent call to DescribeLoa for the connection.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "tags_src",
+				Description: "A list of tags attached to the connection.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Tags"),
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ConnectionName"),
+			},
+			{
+				Name:        "tags",
+				Description: resourceInterfaceDescription("tags"),
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Tags").Transform(directConnectTagsToTurbotTags),
+			},
+			{
+				Name:        "akas",
+				Description: resourceInterfaceDescription("akas"),
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getDirectConnectConnectionArn,
+				Transform:   transform.FromValue().Transform(transform.EnsureStringArray),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listDirectConnectConnections(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// Create Session
+	svc, err := DirectConnectClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_directconnect_connection.listDirectConnectConnections", "connection_error", err)
+		return nil, err
+	}
+	if svc == nil {
+		// Unsupported region, return no data
+		return nil, nil
+	}
+
+	// apply rate limiting
+	d.WaitForListRateLimit(ctx)
+
+	// DescribeConnections returns all the connections of the region in a
+	// single page
+	output, err := svc.DescribeConnections(ctx, &directconnect.DescribeConnectionsInput{})
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_directconnect_connection.listDirectConnectConnections", "api_error", err)
+		return nil, err
+	}
+
+	lagId := d.EqualsQualString("lag_id")
+	for _, connection := range output.Connections {
+		if lagId != "" && lagId != aws.ToString(connection.LagId) {
+			continue
+		}
+
+		d.StreamListItem(ctx, connection)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getDirectConnectConnection(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	connectionId := d.EqualsQualString("connection_id")
+	if connectionId == "" {
+		return nil, nil
+	}
+
+	// Create Session
+	svc, err := DirectConnectClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_directconnect_connection.getDirectConnectConnection", "connection_error", err)
+		return nil, err
+	}
+	if svc == nil {
+		// Unsupported region, return no data
+		return nil, nil
+	}
+
+	params := &directconnect.DescribeConnectionsInput{
+		ConnectionId: aws.String(connectionId),
+	}
+
+	op, err := svc.DescribeConnections(ctx, params)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_directconnect_connection.getDirectConnectConnection", "api_error", err)
+		return nil, err
+	}
+
+	if len(op.Connections) > 0 {
+		return op.Connections[0], nil
+	}
+	return nil, nil
+}
+
+func getDirectConnectConnectionArn(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	connection := h.Item.(types.Connection)
+	return directConnectResourceArn(ctx, d, h, aws.ToString(connection.Region), aws.ToString(connection.OwnerAccount), "dxcon/"+aws.ToString(connection.ConnectionId))
+}
+
+//// UTILITY FUNCTIONS
+
+// directConnectResourceArn builds the ARN of a Direct Connect resource. The
+// region is empty for the global Direct Connect gateways.
+func directConnectResourceArn(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData, region string, ownerAccount string, resource string) (string, error) {
+	commonData, err := getCommonColumns(ctx, d, h)
+	if err != nil {
+		return "", err
+	}
+	commonColumnData := commonData.(*awsCommonColumnData)
+
+	if ownerAccount == "" {
+		ownerAccount = commonColumnData.AccountId
+	}
+
+	return "arn:" + commonColumnData.Partition + ":directconnect:" + region + ":" + ownerAccount + ":" + resource, nil
+}
+
+//// TRANSFORM FUNCTIONS
+
+func directConnectTagsToTurbotTags(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	tags, ok := d.Value.([]types.Tag)
+	if !ok || len(tags) == 0 {
+		return nil, nil
+	}
+
+	turbotTagsMap := map[string]string{}
+	for _, tag := range tags {
+		turbotTagsMap[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+	return turbotTagsMap, nil
+}
@@ -0,0 +1,40 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/directconnect/types"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsDirectConnectConnectionMetricConnectionState(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_directconnect_connection_metric_connection_state",
+		Description: "AWS Direct Connect Connection Cloudwatch Metrics - Connection State",
+		List: &plugin.ListConfig{
+			ParentHydrate: listDirectConnectConnections,
+			Hydrate:       listDirectConnectConnectionMetricConnectionState,
+			Tags:          map[string]string{"service": "cloudwatch", "action": "GetMetricData"},
+			KeyColumns:    cwMetricKeyColumns(),
+		},
+		GetMatrixItemFunc: CloudWatchRegionsMatrix,
+		Columns: awsRegionalColumns(cwMetricColumns(
+			[]*plugin.Column{
+				{
+					Name:        "connection_id",
+					Description: "The ID of the connection.",
+					Type:        proto.ColumnType_STRING,
+					Transform:   transform.FromField("DimensionValue"),
+				},
+			})),
+	}
+}
+
+func listDirectConnectConnectionMetricConnectionState(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	connection := h.Item.(types.Connection)
+	return listCWMetricStatistics(ctx, d, "5_MIN", "AWS/DX", "ConnectionState", "ConnectionId", *connection.ConnectionId)
+}
@@ -0,0 +1,40 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/directconnect/types"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsDirectConnectConnectionMetricConnectionStateDaily(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_directconnect_connection_metric_connection_state_daily",
+		Description: "AWS Direct Connect Connection Cloudwatch Metrics - Connection State (Daily)",
+		List: &plugin.ListConfig{
+			ParentHydrate: listDirectConnectConnections,
+			Hydrate:       listDirectConnectConnectionMetricConnectionStateDaily,
+			Tags:          map[string]string{"service": "cloudwatch", "action": "GetMetricData"},
+			KeyColumns:    cwMetricKeyColumns(),
+		},
+		GetMatrixItemFunc: CloudWatchRegionsMatrix,
+		Columns: awsRegionalColumns(cwMetricColumns(
+			[]*plugin.Column{
+				{
+					Name:        "connection_id",
+					Description: "The ID of the connection.",
+					Type:        proto.ColumnType_STRING,
+					Transform:   transform.FromField("DimensionValue"),
+				},
+			})),
+	}
+}
+
+func listDirectConnectConnectionMetricConnectionStateDaily(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	connection := h.Item.(types.Connection)
+	return listCWMetricStatistics(ctx, d, "DAILY", "AWS/DX", "ConnectionState", "ConnectionId", *connection.ConnectionId)
+}
@@ -0,0 +1,227 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/directconnect"
+	"github.com/aws/aws-sdk-go-v2/service/directconnect/types"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsDirectConnectGateway(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_directconnect_gateway",
+		Description: "AWS Direct Connect Gateway",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("direct_connect_gateway_id"),
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"DirectConnectClientException"}),
+			},
+			Hydrate: getDirectConnectGateway,
+			Tags:    map[string]string{"service": "directconnect", "action": "DescribeDirectConnectGateways"},
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listDirectConnectGateways,
+			Tags:    map[string]string{"service": "directconnect", "action": "DescribeDirectConnectGateways"},
+		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{
+				Func: getDirectConnectGatewayTags,
+				Tags: map[string]string{"service": "directconnect", "action": "DescribeTags"},
+			},
+		},
+		Columns: awsGlobalRegionColumns([]*plugin.Column{
+			{
+				Name:        "direct_connect_gateway_id",
+				Description: "The ID of the Direct Connect gateway.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "direct_connect_gateway_name",
+				Description: "The name of the Direct Connect gateway.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "arn",
+				Description: "The Amazon Resource Name (ARN) of the Direct Connect gateway.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getDirectConnectGatewayArn,
+				Transform:   transform.FromValue(),
+			},
+			{
+				Name:        "direct_connect_gateway_state",
+				Description: "The state of the Direct Connect gateway, i.e. pending, available, deleting or deleted.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "amazon_side_asn",
+				Description: "The autonomous system number (ASN) of the Amazon side of the Direct Connect gateway.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "owner_account",
+				Description: "The ID of the Amazon Web Services account that owns the Direct Connect gateway.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "state_change_error",
+				Description: "The error message if the state of the Direct Connect gateway could not be changed.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "tags_src",
+				Description: "A list of tags attached to the Direct Connect gateway.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getDirectConnectGatewayTags,
+				Transform:   transform.FromValue(),
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("DirectConnectGatewayName"),
+			},
+			{
+				Name:        "tags",
+				Description: resourceInterfaceDescription("tags"),
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getDirectConnectGatewayTags,
+				Transform:   transform.FromValue().Transform(directConnectTagsToTurbotTags),
+			},
+			{
+				Name:        "akas",
+				Description: resourceInterfaceDescription("akas"),
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getDirectConnectGatewayArn,
+				Transform:   transform.FromValue().Transform(transform.EnsureStringArray),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listDirectConnectGateways(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// Create Session
+	svc, err := DirectConnectGatewayClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_directconnect_gateway.listDirectConnectGateways", "connection_error", err)
+		return nil, err
+	}
+
+	// Limiting the results
+	maxLimit := int32(100)
+	if d.QueryContext.Limit != nil {
+		limit := int32(*d.QueryContext.Limit)
+		if limit < maxLimit {
+			maxLimit = limit
+		}
+	}
+
+	pagesLeft := true
+	params := &directconnect.DescribeDirectConnectGatewaysInput{
+		MaxResults: aws.Int32(maxLimit),
+	}
+
+	for pagesLeft {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		result, err := svc.DescribeDirectConnectGateways(ctx, params)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_directconnect_gateway.listDirectConnectGateways", "api_error", err)
+			return nil, err
+		}
+
+		for _, gateway := range result.DirectConnectGateways {
+			d.StreamListItem(ctx, gateway)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+
+		if result.NextToken != nil {
+			pagesLeft = true
+			params.NextToken = result.NextToken
+		} else {
+			pagesLeft = false
+		}
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getDirectConnectGateway(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	gatewayId := d.EqualsQualString("direct_connect_gateway_id")
+	if gatewayId == "" {
+		return nil, nil
+	}
+
+	// Create Session
+	svc, err := DirectConnectGatewayClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_directconnect_gateway.getDirectConnectGateway", "connection_error", err)
+		return nil, err
+	}
+
+	params := &directconnect.DescribeDirectConnectGatewaysInput{
+		DirectConnectGatewayId: aws.String(gatewayId),
+	}
+
+	op, err := svc.DescribeDirectConnectGateways(ctx, params)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_directconnect_gateway.getDirectConnectGateway", "api_error", err)
+		return nil, err
+	}
+
+	if len(op.DirectConnectGateways) > 0 {
+		return op.DirectConnectGateways[0], nil
+	}
+	return nil, nil
+}
+
+func getDirectConnectGatewayTags(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	arn, err := getDirectConnectGatewayArn(ctx, d, h)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create Session
+	svc, err := DirectConnectGatewayClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_directconnect_gateway.getDirectConnectGatewayTags", "connection_error", err)
+		return nil, err
+	}
+
+	params := &directconnect.DescribeTagsInput{
+		ResourceArns: []string{arn.(string)},
+	}
+
+	op, err := svc.DescribeTags(ctx, params)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_directconnect_gateway.getDirectConnectGatewayTags", "api_error", err)
+		return nil, err
+	}
+
+	if len(op.ResourceTags) > 0 {
+		return op.ResourceTags[0].Tags, nil
+	}
+	return nil, nil
+}
+
+func getDirectConnectGatewayArn(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	gateway := h.Item.(types.DirectConnectGateway)
+	return directConnectResourceArn(ctx, d, h, "", aws.ToString(gateway.OwnerAccount), "dx-gateway/"+aws.ToString(gateway.DirectConnectGatewayId))
+}
@@ -0,0 +1,172 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/directconnect"
+	"github.com/aws/aws-sdk-go-v2/service/directconnect/types"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsDirectConnectGatewayAssociation(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_directconnect_gateway_association",
+		Description: "AWS Direct Connect Gateway Association",
+		List: &plugin.ListConfig{
+			ParentHydrate: listDirectConnectGateways,
+			Hydrate:       listDirectConnectGatewayAssociations,
+			Tags:          map[string]string{"service": "directconnect", "action": "DescribeDirectConnectGatewayAssociations"},
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "direct_connect_gateway_id", Require: plugin.Optional},
+				{Name: "association_id", Require: plugin.Optional},
+				{Name: "associated_gateway_id", Require: plugin.Optional},
+			},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"DirectConnectClientException"}),
+			},
+		},
+		Columns: awsGlobalRegionColumns([]*plugin.Column{
+			{
+				Name:        "association_id",
+				Description: "The ID of the Direct Connect gateway association.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "direct_connect_gateway_id",
+				Description: "The ID of the Direct Connect gateway.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "direct_connect_gateway_owner_account",
+				Description: "The ID of the Amazon Web Services account that owns the Direct Connect gateway.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "association_state",
+				Description: "The state of the association, i.e. associating, associated, disassociating, disassociated or updating.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "associated_gateway_id",
+				Description: "The ID of the virtual private gateway or transit gateway associated with the Direct Connect gateway.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("AssociatedGateway.Id"),
+			},
+			{
+				Name:        "associated_gateway_type",
+				Description: "The type of the associated gateway, i.e. virtualPrivateGateway or transitGateway.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("AssociatedGateway.Type"),
+			},
+			{
+				Name:        "associated_gateway_owner_account",
+				Description: "The ID of the Amazon Web Services account that owns the associated gateway.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("AssociatedGateway.OwnerAccount"),
+			},
+			{
+				Name:        "associated_gateway_region",
+				Description: "The region of the associated gateway.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("AssociatedGateway.Region"),
+			},
+			{
+				Name:        "associated_core_network",
+				Description: "The Cloud WAN core network associated with the Direct Connect gateway.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "allowed_prefixes_to_direct_connect_gateway",
+				Description: "The Amazon VPC prefixes to advertise to the Direct Connect gateway.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "state_change_error",
+				Description: "The error message if the state of the association could not be changed.",
+				Type:        proto.ColumnType_STRING,
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("AssociationId"),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listDirectConnectGatewayAssociations(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	gateway := h.Item.(types.DirectConnectGateway)
+
+	// check if the provided direct_connect_gateway_id is not matching with the parentHydrate
+	if d.EqualsQualString("direct_connect_gateway_id") != "" && d.EqualsQualString("direct_connect_gateway_id") != aws.ToString(gateway.DirectConnectGatewayId) {
+		return nil, nil
+	}
+
+	// Create Session
+	svc, err := DirectConnectGatewayClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_directconnect_gateway_association.listDirectConnectGatewayAssociations", "connection_error", err)
+		return nil, err
+	}
+
+	// Limiting the results
+	maxLimit := int32(100)
+	if d.QueryContext.Limit != nil {
+		limit := int32(*d.QueryContext.Limit)
+		if limit < maxLimit {
+			maxLimit = limit
+		}
+	}
+
+	pagesLeft := true
+	params := &directconnect.DescribeDirectConnectGatewayAssociationsInput{
+		DirectConnectGatewayId: gateway.DirectConnectGatewayId,
+		MaxResults:             aws.Int32(maxLimit),
+	}
+	if d.EqualsQualString("association_id") != "" {
+		params.AssociationId = aws.String(d.EqualsQualString("association_id"))
+	}
+	if d.EqualsQualString("associated_gateway_id") != "" {
+		params.AssociatedGatewayId = aws.String(d.EqualsQualString("associated_gateway_id"))
+	}
+
+	for pagesLeft {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		result, err := svc.DescribeDirectConnectGatewayAssociations(ctx, params)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_directconnect_gateway_association.listDirectConnectGatewayAssociations", "api_error", err)
+			return nil, err
+		}
+
+		for _, association := range result.DirectConnectGatewayAssociations {
+			d.StreamListItem(ctx, association)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+
+		if result.NextToken != nil {
+			pagesLeft = true
+			params.NextToken = result.NextToken
+		} else {
+			pagesLeft = false
+		}
+	}
+
+	return nil, nil
+}
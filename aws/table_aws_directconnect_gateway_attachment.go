@@ -0,0 +1,144 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/directconnect"
+	"github.com/aws/aws-sdk-go-v2/service/directconnect/types"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsDirectConnectGatewayAttachment(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_directconnect_gateway_attachment",
+		Description: "AWS Direct Connect Gateway Attachment",
+		List: &plugin.ListConfig{
+			ParentHydrate: listDirectConnectGateways,
+			Hydrate:       listDirectConnectGatewayAttachments,
+			Tags:          map[string]string{"service": "directconnect", "action": "DescribeDirectConnectGatewayAttachments"},
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "direct_connect_gateway_id", Require: plugin.Optional},
+				{Name: "virtual_interface_id", Require: plugin.Optional},
+			},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"DirectConnectClientException"}),
+			},
+		},
+		Columns: awsGlobalRegionColumns([]*plugin.Column{
+			{
+				Name:        "direct_connect_gateway_id",
+				Description: "The ID of the Direct Connect gateway.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "virtual_interface_id",
+				Description: "The ID of the virtual interface attached to the Direct Connect gateway.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "virtual_interface_owner_account",
+				Description: "The ID of the Amazon Web Services account that owns the virtual interface.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "virtual_interface_region",
+				Description: "The region of the virtual interface.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "attachment_state",
+				Description: "The state of the attachment, i.e. attaching, attached, detaching or detached.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "attachment_type",
+				Description: "The type of attachment, i.e. TransitVirtualInterface or PrivateVirtualInterface.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "state_change_error",
+				Description: "The error message if the state of the attachment could not be changed.",
+				Type:        proto.ColumnType_STRING,
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("VirtualInterfaceId"),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listDirectConnectGatewayAttachments(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	gateway := h.Item.(types.DirectConnectGateway)
+
+	// check if the provided direct_connect_gateway_id is not matching with the parentHydrate
+	if d.EqualsQualString("direct_connect_gateway_id") != "" && d.EqualsQualString("direct_connect_gateway_id") != aws.ToString(gateway.DirectConnectGatewayId) {
+		return nil, nil
+	}
+
+	// Create Session
+	svc, err := DirectConnectGatewayClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_directconnect_gateway_attachment.listDirectConnectGatewayAttachments", "connection_error", err)
+		return nil, err
+	}
+
+	// Limiting the results
+	maxLimit := int32(100)
+	if d.QueryContext.Limit != nil {
+		limit := int32(*d.QueryContext.Limit)
+		if limit < maxLimit {
+			maxLimit = limit
+		}
+	}
+
+	pagesLeft := true
+	params := &directconnect.DescribeDirectConnectGatewayAttachmentsInput{
+		DirectConnectGatewayId: gateway.DirectConnectGatewayId,
+		MaxResults:             aws.Int32(maxLimit),
+	}
+	if d.EqualsQualString("virtual_interface_id") != "" {
+		params.VirtualInterfaceId = aws.String(d.EqualsQualString("virtual_interface_id"))
+	}
+
+	for pagesLeft {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		result, err := svc.DescribeDirectConnectGatewayAttachments(ctx, params)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_directconnect_gateway_attachment.listDirectConnectGatewayAttachments", "api_error", err)
+			return nil, err
+		}
+
+		for _, attachment := range result.DirectConnectGatewayAttachments {
+			d.StreamListItem(ctx, attachment)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+
+		if result.NextToken != nil {
+			pagesLeft = true
+			params.NextToken = result.NextToken
+		} else {
+			pagesLeft = false
+		}
+	}
+
+	return nil, nil
+}
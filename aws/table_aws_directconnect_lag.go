@@ -0,0 +1,239 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/directconnect"
+	"github.com/aws/aws-sdk-go-v2/service/directconnect/types"
+
+	directconnectv1 "github.com/aws/aws-sdk-go/service/directconnect"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsDirectConnectLag(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_directconnect_lag",
+		Description: "AWS Direct Connect Link Aggregation Group",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("lag_id"),
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"DirectConnectClientException"}),
+			},
+			Hydrate: getDirectConnectLag,
+			Tags:    map[string]string{"service": "directconnect", "action": "DescribeLags"},
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listDirectConnectLags,
+			Tags:    map[string]string{"service": "directconnect", "action": "DescribeLags"},
+		},
+		GetMatrixItemFunc: SupportedRegionMatrix(directconnectv1.EndpointsID),
+		Columns: awsRegionalColumns([]*plugin.Column{
+			{
+				Name:        "lag_id",
+				Description: "The ID of the LAG.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "lag_name",
+				Description: "The name of the LAG.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "arn",
+				Description: "The Amazon Resource Name (ARN) of the LAG.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getDirectConnectLagArn,
+				Transform:   transform.FromValue(),
+			},
+			{
+				Name:        "lag_state",
+				Description: "The state of the LAG, e.g. requested, pending, available, down, deleting, deleted or unknown.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "connections_bandwidth",
+				Description: "The individual bandwidth of the physical connections bundled by the LAG.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "number_of_connections",
+				Description: "The number of physical dedicated connections bundled by the LAG.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "minimum_links",
+				Description: "The minimum number of physical dedicated connections that must be operational for the LAG itself to be operational.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "location",
+				Description: "The location of the LAG.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "owner_account",
+				Description: "The ID of the Amazon Web Services account that owns the LAG.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "provider_name",
+				Description: "The name of the service provider associated with the LAG.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "allows_hosted_connections",
+				Description: "Indicates whether the LAG can host other connections.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "aws_device_v2",
+				Description: "The Direct Connect endpoint that hosts the LAG.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "aws_logical_device_id",
+				Description: "The Direct Connect endpoint that terminates the logical connection.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "has_logical_redundancy",
+				Description: "Indicates whether the LAG supports a secondary BGP peer in the same address family (IPv4/IPv6).",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "jumbo_frame_capable",
+				Description: "Indicates whether jumbo frames are supported.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "mac_sec_capable",
+				Description: "Indicates whether the LAG supports MAC Security (MACsec).",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "encryption_mode",
+				Description: "The LAG MAC Security (MACsec) encryption mode, i.e. no_encrypt, should_encrypt or must_encrypt.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "mac_sec_keys",
+				Description: "The MAC Security (MACsec) security keys associated with the LAG.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "connections",
+				Description: "The connections bundled by the LAG.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "tags_src",
+				Description: "A list of tags attached to the LAG.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Tags"),
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("LagName"),
+			},
+			{
+				Name:        "tags",
+				Description: resourceInterfaceDescription("tags"),
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Tags").Transform(directConnectTagsToTurbotTags),
+			},
+			{
+				Name:        "akas",
+				Description: resourceInterfaceDescription("akas"),
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getDirectConnectLagArn,
+				Transform:   transform.FromValue().Transform(transform.EnsureStringArray),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listDirectConnectLags(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// Create Session
+	svc, err := DirectConnectClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_directconnect_lag.listDirectConnectLags", "connection_error", err)
+		return nil, err
+	}
+	if svc == nil {
+		// Unsupported region, return no data
+		return nil, nil
+	}
+
+	// apply rate limiting
+	d.WaitForListRateLimit(ctx)
+
+	// DescribeLags returns all the LAGs of the region in a single page
+	output, err := svc.DescribeLags(ctx, &directconnect.DescribeLagsInput{})
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_directconnect_lag.listDirectConnectLags", "api_error", err)
+		return nil, err
+	}
+
+	for _, lag := range output.Lags {
+		d.StreamListItem(ctx, lag)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getDirectConnectLag(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	lagId := d.EqualsQualString("lag_id")
+	if lagId == "" {
+		return nil, nil
+	}
+
+	// Create Session
+	svc, err := DirectConnectClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_directconnect_lag.getDirectConnectLag", "connection_error", err)
+		return nil, err
+	}
+	if svc == nil {
+		// Unsupported region, return no data
+		return nil, nil
+	}
+
+	params := &directconnect.DescribeLagsInput{
+		LagId: aws.String(lagId),
+	}
+
+	op, err := svc.DescribeLags(ctx, params)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_directconnect_lag.getDirectConnectLag", "api_error", err)
+		return nil, err
+	}
+
+	if len(op.Lags) > 0 {
+		return op.Lags[0], nil
+	}
+	return nil, nil
+}
+
+func getDirectConnectLagArn(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	lag := h.Item.(types.Lag)
+	return directConnectResourceArn(ctx, d, h, aws.ToString(lag.Region), aws.ToString(lag.OwnerAccount), "dxlag/"+aws.ToString(lag.LagId))
+}
@@ -0,0 +1,278 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/directconnect"
+	"github.com/aws/aws-sdk-go-v2/service/directconnect/types"
+
+	directconnectv1 "github.com/aws/aws-sdk-go/service/directconnect"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsDirectConnectVirtualInterface(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_directconnect_virtual_interface",
+		Description: "AWS Direct Connect Virtual Interface",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("virtual_interface_id"),
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"DirectConnectClientException"}),
+			},
+			Hydrate: getDirectConnectVirtualInterface,
+			Tags:    map[string]string{"service": "directconnect", "action": "DescribeVirtualInterfaces"},
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listDirectConnectVirtualInterfaces,
+			Tags:    map[string]string{"service": "directconnect", "action": "DescribeVirtualInterfaces"},
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "connection_id", Require: plugin.Optional},
+			},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"DirectConnectClientException"}),
+			},
+		},
+		GetMatrixItemFunc: SupportedRegionMatrix(directconnectv1.EndpointsID),
+		Columns: awsRegionalColumns([]*plugin.Column{
+			{
+				Name:        "virtual_interface_id",
+				Description: "The ID of the virtual interface.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "virtual_interface_name",
+				Description: "The name of the virtual interface.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "arn",
+				Description: "The Amazon Resource Name (ARN) of the virtual interface.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getDirectConnectVirtualInterfaceArn,
+				Transform:   transform.FromValue(),
+			},
+			{
+				Name:        "virtual_interface_type",
+				Description: "The type of virtual interface, i.e. private, public or transit.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "virtual_interface_state",
+				Description: "The state of the virtual interface, e.g. confirming, verifying, pending, available, down, deleting, deleted, rejected or unknown.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "connection_id",
+				Description: "The ID of the connection or LAG of the virtual interface.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "direct_connect_gateway_id",
+				Description: "The ID of the Direct Connect gateway the virtual interface is attached to.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("DirectConnectGatewayId").NullIfZero(),
+			},
+			{
+				Name:        "virtual_gateway_id",
+				Description: "The ID of the virtual private gateway the virtual interface is attached to.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("VirtualGatewayId").NullIfZero(),
+			},
+			{
+				Name:        "vlan",
+				Description: "The ID of the VLAN.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "asn",
+				Description: "The autonomous system number (ASN) of the customer side of the BGP session.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "amazon_side_asn",
+				Description: "The autonomous system number (ASN) of the Amazon side of the BGP session.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "address_family",
+				Description: "The address family of the BGP peer, i.e. ipv4 or ipv6.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "amazon_address",
+				Description: "The IP address assigned to the Amazon interface.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "customer_address",
+				Description: "The IP address assigned to the customer interface.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "bgp_peers",
+				Description: "The BGP peers configured on the virtual interface, with their BGP peer state and BGP status.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "route_filter_prefixes",
+				Description: "The routes to be advertised to the Amazon Web Services network in the region, for a public virtual interface.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "location",
+				Description: "The location of the connection.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "owner_account",
+				Description: "The ID of the Amazon Web Services account that owns the virtual interface.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "mtu",
+				Description: "The maximum transmission unit (MTU), in bytes.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "jumbo_frame_capable",
+				Description: "Indicates whether jumbo frames are supported.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "site_link_enabled",
+				Description: "Indicates whether SiteLink is enabled.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "aws_device_v2",
+				Description: "The Direct Connect endpoint that terminates the physical connection.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "aws_logical_device_id",
+				Description: "The Direct Connect endpoint that terminates the logical connection.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "customer_router_config",
+				Description: "The customer router configuration.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "tags_src",
+				Description: "A list of tags attached to the virtual interface.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Tags"),
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("VirtualInterfaceName"),
+			},
+			{
+				Name:        "tags",
+				Description: resourceInterfaceDescription("tags"),
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Tags").Transform(directConnectTagsToTurbotTags),
+			},
+			{
+				Name:        "akas",
+				Description: resourceInterfaceDescription("akas"),
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getDirectConnectVirtualInterfaceArn,
+				Transform:   transform.FromValue().Transform(transform.EnsureStringArray),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listDirectConnectVirtualInterfaces(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// Create Session
+	svc, err := DirectConnectClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_directconnect_virtual_interface.listDirectConnectVirtualInterfaces", "connection_error", err)
+		return nil, err
+	}
+	if svc == nil {
+		// Unsupported region, return no data
+		return nil, nil
+	}
+
+	params := &directconnect.DescribeVirtualInterfacesInput{}
+	if d.EqualsQualString("connection_id") != "" {
+		params.ConnectionId = aws.String(d.EqualsQualString("connection_id"))
+	}
+
+	// apply rate limiting
+	d.WaitForListRateLimit(ctx)
+
+	// DescribeVirtualInterfaces returns all the virtual interfaces of the
+	// region in a single page
+	output, err := svc.DescribeVirtualInterfaces(ctx, params)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_directconnect_virtual_interface.listDirectConnectVirtualInterfaces", "api_error", err)
+		return nil, err
+	}
+
+	for _, virtualInterface := range output.VirtualInterfaces {
+		d.StreamListItem(ctx, virtualInterface)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getDirectConnectVirtualInterface(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	virtualInterfaceId := d.EqualsQualString("virtual_interface_id")
+	if virtualInterfaceId == "" {
+		return nil, nil
+	}
+
+	// Create Session
+	svc, err := DirectConnectClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_directconnect_virtual_interface.getDirectConnectVirtualInterface", "connection_error", err)
+		return nil, err
+	}
+	if svc == nil {
+		// Unsupported region, return no data
+		return nil, nil
+	}
+
+	params := &directconnect.DescribeVirtualInterfacesInput{
+		VirtualInterfaceId: aws.String(virtualInterfaceId),
+	}
+
+	op, err := svc.DescribeVirtualInterfaces(ctx, params)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_directconnect_virtual_interface.getDirectConnectVirtualInterface", "api_error", err)
+		return nil, err
+	}
+
+	if len(op.VirtualInterfaces) > 0 {
+		return op.VirtualInterfaces[0], nil
+	}
+	return nil, nil
+}
+
+func getDirectConnectVirtualInterfaceArn(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	virtualInterface := h.Item.(types.VirtualInterface)
+	return directConnectResourceArn(ctx, d, h, aws.ToString(virtualInterface.Region), aws.ToString(virtualInterface.OwnerAccount), "dxvif/"+aws.ToString(virtualInterface.VirtualInterfaceId))
+}
@@ -13,8 +13,9 @@ func tableAwsDynamoDBMetricAccountProvisionedReadCapacityUtilization(_ context.C
 		Name:        "aws_dynamodb_metric_account_provisioned_read_capacity_util",
 		Description: "AWS DynamoDB Metric Account Provisioned Read Capacity Utilization",
 		List: &plugin.ListConfig{
-			Hydrate: listDynamoDBMetricAccountProvisionedReadCapacityUtilization,
-			Tags:    map[string]string{"service": "cloudwatch", "action": "GetMetricStatistics"},
+			Hydrate:    listDynamoDBMetricAccountProvisionedReadCapacityUtilization,
+			Tags:       map[string]string{"service": "cloudwatch", "action": "GetMetricData"},
+			KeyColumns: cwMetricKeyColumns(),
 		},
 		GetMatrixItemFunc: CloudWatchRegionsMatrix,
 		Columns:           awsRegionalColumns(cwMetricColumns([]*plugin.Column{})),
@@ -13,8 +13,9 @@ func tableAwsDynamoDBMetricAccountProvisionedWriteCapacityUtilization(_ context.
 		Name:        "aws_dynamodb_metric_account_provisioned_write_capacity_util",
 		Description: "AWS DynamoDB Metric Account Provisioned Write Capacity Utilization",
 		List: &plugin.ListConfig{
-			Hydrate: listDynamoDBMetricAccountProvisionedWriteCapacityUtilization,
-			Tags:    map[string]string{"service": "cloudwatch", "action": "GetMetricStatistics"},
+			Hydrate:    listDynamoDBMetricAccountProvisionedWriteCapacityUtilization,
+			Tags:       map[string]string{"service": "cloudwatch", "action": "GetMetricData"},
+			KeyColumns: cwMetricKeyColumns(),
 		},
 		GetMatrixItemFunc: CloudWatchRegionsMatrix,
 		Columns:           awsRegionalColumns(cwMetricColumns([]*plugin.Column{})),
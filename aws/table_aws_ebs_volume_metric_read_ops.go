@@ -18,7 +18,8 @@ func tableAwsEbsVolumeMetricReadOps(_ context.Context) *plugin.Table {
 		List: &plugin.ListConfig{
 			ParentHydrate: listEBSVolume,
 			Hydrate:       listEbsVolumeMetricReadOps,
-			Tags:          map[string]string{"service": "cloudwatch", "action": "GetMetricStatistics"},
+			Tags:          map[string]string{"service": "cloudwatch", "action": "GetMetricData"},
+			KeyColumns:    cwMetricKeyColumns(),
 		},
 		GetMatrixItemFunc: CloudWatchRegionsMatrix,
 		Columns: awsRegionalColumns(cwMetricColumns(
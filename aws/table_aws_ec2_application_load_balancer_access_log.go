@@ -0,0 +1,409 @@
+package aws
+
+import (
+	"context"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+
+	elbv2v1 "github.com/aws/aws-sdk-go/service/elbv2"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsEc2ApplicationLoadBalancerAccessLog(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_ec2_application_load_balancer_access_log",
+		Description: "AWS EC2 Application Load Balancer Access Log",
+		List: &plugin.ListConfig{
+			Hydrate: listEc2ApplicationLoadBalancerAccessLogs,
+			Tags:    map[string]string{"service": "s3", "action": "GetObject"},
+			KeyColumns: append(plugin.KeyColumnSlice{
+				{Name: "load_balancer_arn", Require: plugin.Required},
+			}, accessLogKeyColumns()...),
+		},
+		GetMatrixItemFunc: SupportedRegionMatrix(elbv2v1.EndpointsID),
+		Columns: awsRegionalColumns([]*plugin.Column{
+			{
+				Name:        "load_balancer_arn",
+				Description: "The Amazon Resource Name (ARN) of the load balancer.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("load_balancer_arn"),
+			},
+			{
+				Name:        "timestamp",
+				Description: "The time when the load balancer generated a response to the client. For WebSockets, this is the time when the connection is closed.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "type",
+				Description: "The type of request or connection. Possible values are http, https, h2, grpcs, ws and wss.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "elb",
+				Description: "The resource ID of the load balancer.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "client_ip",
+				Description: "The IP address of the requesting client.",
+				Type:        proto.ColumnType_IPADDR,
+			},
+			{
+				Name:        "client_port",
+				Description: "The port of the requesting client.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "target_ip",
+				Description: "The IP address of the target that processed this request. Null if the client didn't send a full request or the target is a Lambda function.",
+				Type:        proto.ColumnType_IPADDR,
+			},
+			{
+				Name:        "target_port",
+				Description: "The port of the target that processed this request.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "request_processing_time",
+				Description: "The total time elapsed (in seconds) from the time the load balancer received the request until the time it sent the request to a target. This value is -1 if the load balancer can't dispatch the request to a target.",
+				Type:        proto.ColumnType_DOUBLE,
+			},
+			{
+				Name:        "target_processing_time",
+				Description: "The total time elapsed (in seconds) from the time the load balancer sent the request to a target until the target started to send the response headers. This value is -1 if the load balancer can't dispatch the request to a target.",
+				Type:        proto.ColumnType_DOUBLE,
+			},
+			{
+				Name:        "response_processing_time",
+				Description: "The total time elapsed (in seconds) from the time the load balancer received the response header from the target until it started to send the response to the client. This value is -1 if the load balancer can't dispatch the request to a target.",
+				Type:        proto.ColumnType_DOUBLE,
+			},
+			{
+				Name:        "elb_status_code",
+				Description: "The status code of the response from the load balancer.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "target_status_code",
+				Description: "The status code of the response from the target.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "received_bytes",
+				Description: "The size of the request, in bytes, received from the client.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "sent_bytes",
+				Description: "The size of the response, in bytes, sent to the client.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "request_verb",
+				Description: "The HTTP method of the request.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "request_url",
+				Description: "The URL of the request, including the protocol, host header, port and path.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "request_protocol",
+				Description: "The HTTP version of the request.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "user_agent",
+				Description: "The User-Agent string that identifies the client that originated the request.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "ssl_cipher",
+				Description: "The TLS cipher used for an HTTPS listener.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "ssl_protocol",
+				Description: "The TLS protocol used for an HTTPS listener.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "target_group_arn",
+				Description: "The Amazon Resource Name (ARN) of the target group.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "trace_id",
+				Description: "The contents of the X-Amzn-Trace-Id header.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "domain_name",
+				Description: "The SNI domain provided by the client during the TLS handshake.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "chosen_cert_arn",
+				Description: "The ARN of the certificate presented to the client.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "matched_rule_priority",
+				Description: "The priority value of the rule that matched the request. The default rule has a priority of 0.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "request_creation_time",
+				Description: "The time when the load balancer received the request from the client.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "actions_executed",
+				Description: "The actions taken when processing the request, such as waf, authenticate or redirect.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "redirect_url",
+				Description: "The URL of the redirect target for the location header of the HTTP response.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "error_reason",
+				Description: "The error reason code, if the request failed.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "target_port_list",
+				Description: "A list of IP addresses and ports for the targets that processed this request.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "target_status_code_list",
+				Description: "A list of status codes from the responses of the targets.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "classification",
+				Description: "The classification for desync mitigation. Possible values are Acceptable, Ambiguous and Severe.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "classification_reason",
+				Description: "The classification reason code, if the request is not compliant with RFC 7230.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "conn_trace_id",
+				Description: "The connection traceability ID, used to find the connection log entry of the request.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "log_file_key",
+				Description: "The S3 object key of the log file containing this entry.",
+				Type:        proto.ColumnType_STRING,
+			},
+		}),
+	}
+}
+
+type albAccessLogEntry struct {
+	Timestamp              *time.Time
+	Type                   *string
+	Elb                    *string
+	ClientIp               *string
+	ClientPort             *int64
+	TargetIp               *string
+	TargetPort             *int64
+	RequestProcessingTime  *float64
+	TargetProcessingTime   *float64
+	ResponseProcessingTime *float64
+	ElbStatusCode          *int64
+	TargetStatusCode       *int64
+	ReceivedBytes          *int64
+	SentBytes              *int64
+	RequestVerb            *string
+	RequestUrl             *string
+	RequestProtocol        *string
+	UserAgent              *string
+	SslCipher              *string
+	SslProtocol            *string
+	TargetGroupArn         *string
+	TraceId                *string
+	DomainName             *string
+	ChosenCertArn          *string
+	MatchedRulePriority    *int64
+	RequestCreationTime    *time.Time
+	ActionsExecuted        []string
+	RedirectUrl            *string
+	ErrorReason            *string
+	TargetPortList         []string
+	TargetStatusCodeList   []string
+	Classification         *string
+	ClassificationReason   *string
+	ConnTraceId            *string
+	LogFileKey             string
+}
+
+//// LIST FUNCTION
+
+func listEc2ApplicationLoadBalancerAccessLogs(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	return listElbAccessLogs(ctx, d, "aws_ec2_application_load_balancer_access_log", func(key string) accessLogLineParser {
+		return func(line string) interface{} {
+			return parseAlbAccessLogLine(key, line)
+		}
+	})
+}
+
+// listElbAccessLogs streams the access log entries of the application or
+// network load balancer given by the load_balancer_arn qual.
+func listElbAccessLogs(ctx context.Context, d *plugin.QueryData, tableName string, newParser func(key string) accessLogLineParser) (interface{}, error) {
+	region := d.EqualsQualString(matrixKeyRegion)
+	loadBalancerArn := d.EqualsQualString("load_balancer_arn")
+
+	// Log files are written to a bucket in the region of the load balancer
+	arnData, err := arn.Parse(loadBalancerArn)
+	if err != nil || arnData.Region != region {
+		return nil, nil
+	}
+
+	// Create service
+	svc, err := ELBV2Client(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error(tableName+".listElbAccessLogs", "connection_error", err)
+		return nil, err
+	}
+
+	attributes, err := svc.DescribeLoadBalancerAttributes(ctx, &elasticloadbalancingv2.DescribeLoadBalancerAttributesInput{
+		LoadBalancerArn: aws.String(loadBalancerArn),
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error(tableName+".listElbAccessLogs", "api_error", err)
+		return nil, err
+	}
+
+	var enabled bool
+	var bucket, prefix string
+	for _, attribute := range attributes.Attributes {
+		switch aws.ToString(attribute.Key) {
+		case "access_logs.s3.enabled":
+			enabled = aws.ToString(attribute.Value) == "true"
+		case "access_logs.s3.bucket":
+			bucket = aws.ToString(attribute.Value)
+		case "access_logs.s3.prefix":
+			prefix = aws.ToString(attribute.Value)
+		}
+	}
+	// Logs written while access logging was enabled are kept, so only a missing
+	// bucket means there is nothing to read
+	if bucket == "" {
+		plugin.Logger(ctx).Debug(tableName+".listElbAccessLogs", "access_logs_enabled", enabled, "load_balancer_arn", loadBalancerArn)
+		return nil, nil
+	}
+
+	s3Svc, err := S3Client(ctx, d, region)
+	if err != nil {
+		plugin.Logger(ctx).Error(tableName+".listElbAccessLogs", "s3_client_error", err)
+		return nil, err
+	}
+
+	loadBalancerId := elbAccessLogLoadBalancerId(arnData.Resource)
+	start, end := accessLogTimeRange(d)
+
+	// bucket[/prefix]/AWSLogs/aws-account-id/elasticloadbalancing/region/yyyy/mm/dd/
+	var prefixes []string
+	for _, day := range accessLogDays(start, end) {
+		prefixes = append(prefixes, path.Join(prefix, "AWSLogs", arnData.AccountID, "elasticloadbalancing", region, day.Format("2006/01/02"))+"/")
+	}
+
+	includeKey := func(key string) bool {
+		// aws-account-id_elasticloadbalancing_region_app.load-balancer-id_end-time_ip-address_random-string.log.gz
+		parts := strings.Split(path.Base(key), "_")
+		if len(parts) < 5 || parts[3] != loadBalancerId {
+			return false
+		}
+		fileEnd, err := time.Parse("20060102T1504Z", parts[4])
+		if err != nil {
+			return false
+		}
+		// Each file holds the entries of the 5 minutes before its end time
+		return !fileEnd.Before(start) && !fileEnd.Add(-5*time.Minute).After(end)
+	}
+
+	err = streamAccessLogFiles(ctx, d, s3Svc, bucket, prefixes, includeKey, newParser)
+	if err != nil {
+		plugin.Logger(ctx).Error(tableName+".listElbAccessLogs", "api_error", err)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// elbAccessLogLoadBalancerId converts the resource of a load balancer ARN,
+// e.g. loadbalancer/app/my-load-balancer/50dc6c495c0c9188, to the ID used in
+// log file names, e.g. app.my-load-balancer.50dc6c495c0c9188.
+func elbAccessLogLoadBalancerId(resource string) string {
+	return strings.ReplaceAll(strings.TrimPrefix(resource, "loadbalancer/"), "/", ".")
+}
+
+// parseAlbAccessLogLine parses an entry in the documented application load
+// balancer access log format. Fields added in future are ignored.
+func parseAlbAccessLogLine(key string, line string) interface{} {
+	f := accessLogFields(splitAccessLogLine(line))
+	if len(f) < 12 {
+		return nil
+	}
+
+	entry := &albAccessLogEntry{
+		Type:                   f.String(0),
+		Timestamp:              f.Time(1, time.RFC3339Nano),
+		Elb:                    f.String(2),
+		RequestProcessingTime:  f.Float(5),
+		TargetProcessingTime:   f.Float(6),
+		ResponseProcessingTime: f.Float(7),
+		ElbStatusCode:          f.Int(8),
+		TargetStatusCode:       f.Int(9),
+		ReceivedBytes:          f.Int(10),
+		SentBytes:              f.Int(11),
+		UserAgent:              f.String(13),
+		SslCipher:              f.String(14),
+		SslProtocol:            f.String(15),
+		TargetGroupArn:         f.String(16),
+		TraceId:                f.String(17),
+		DomainName:             f.String(18),
+		ChosenCertArn:          f.String(19),
+		MatchedRulePriority:    f.Int(20),
+		RequestCreationTime:    f.Time(21, time.RFC3339Nano),
+		ActionsExecuted:        f.List(22, ","),
+		RedirectUrl:            f.String(23),
+		ErrorReason:            f.String(24),
+		TargetPortList:         f.List(25, " "),
+		TargetStatusCodeList:   f.List(26, " "),
+		Classification:         f.String(27),
+		ClassificationReason:   f.String(28),
+		ConnTraceId:            f.String(29),
+		LogFileKey:             key,
+	}
+	entry.ClientIp, entry.ClientPort = splitHostPort(f.String(3))
+	entry.TargetIp, entry.TargetPort = splitHostPort(f.String(4))
+
+	// "GET http://www.example.com:80/ HTTP/1.1"
+	if request := f.String(12); request != nil {
+		parts := strings.SplitN(*request, " ", 3)
+		entry.RequestVerb = accessLogFields(parts).String(0)
+		entry.RequestUrl = accessLogFields(parts).String(1)
+		entry.RequestProtocol = accessLogFields(parts).String(2)
+	}
+
+	return entry
+}
@@ -0,0 +1,238 @@
+package aws
+
+import (
+	"context"
+	"time"
+
+	elbv2v1 "github.com/aws/aws-sdk-go/service/elbv2"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsEc2NetworkLoadBalancerAccessLog(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_ec2_network_load_balancer_access_log",
+		Description: "AWS EC2 Network Load Balancer Access Log",
+		List: &plugin.ListConfig{
+			Hydrate: listEc2NetworkLoadBalancerAccessLogs,
+			Tags:    map[string]string{"service": "s3", "action": "GetObject"},
+			KeyColumns: append(plugin.KeyColumnSlice{
+				{Name: "load_balancer_arn", Require: plugin.Required},
+			}, accessLogKeyColumns()...),
+		},
+		GetMatrixItemFunc: SupportedRegionMatrix(elbv2v1.EndpointsID),
+		Columns: awsRegionalColumns([]*plugin.Column{
+			{
+				Name:        "load_balancer_arn",
+				Description: "The Amazon Resource Name (ARN) of the load balancer.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("load_balancer_arn"),
+			},
+			{
+				Name:        "timestamp",
+				Description: "The time recorded at the end of the TLS connection.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "type",
+				Description: "The type of listener. The supported value is tls.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "version",
+				Description: "The version of the log entry.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "elb",
+				Description: "The resource ID of the load balancer.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "listener",
+				Description: "The resource ID of the TLS listener for the connection.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "client_ip",
+				Description: "The IP address of the client.",
+				Type:        proto.ColumnType_IPADDR,
+			},
+			{
+				Name:        "client_port",
+				Description: "The port of the client.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "destination_ip",
+				Description: "The IP address of the destination. If the client connects directly to the load balancer, the destination is the listener.",
+				Type:        proto.ColumnType_IPADDR,
+			},
+			{
+				Name:        "destination_port",
+				Description: "The port of the destination.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "connection_time",
+				Description: "The total time for the connection to complete, from start to closure, in milliseconds.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "tls_handshake_time",
+				Description: "The total time for the TLS handshake to complete after the TCP connection is established, including client-side delays, in milliseconds.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "received_bytes",
+				Description: "The count of bytes received by the load balancer from the client, after decryption.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "sent_bytes",
+				Description: "The count of bytes sent by the load balancer to the client, before encryption.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "incoming_tls_alert",
+				Description: "The integer value of TLS alerts received by the load balancer from the client, if present.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "chosen_cert_arn",
+				Description: "The ARN of the certificate served to the client.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "chosen_cert_serial",
+				Description: "Reserved for future use. This value is always set to null.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "tls_cipher",
+				Description: "The cipher suite negotiated with the client, in OpenSSL format.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "tls_protocol_version",
+				Description: "The TLS protocol negotiated with the client.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "tls_named_group",
+				Description: "Reserved for future use. This value is always set to null.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "domain_name",
+				Description: "The value of the server_name extension in the client hello message.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "alpn_fe_protocol",
+				Description: "The application protocol negotiated with the client.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "alpn_be_protocol",
+				Description: "The application protocol negotiated with the target.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "alpn_client_preference_list",
+				Description: "The value of the application_layer_protocol_negotiation extension in the client hello message.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "tls_connection_creation_time",
+				Description: "The time recorded at the beginning of the TLS connection.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "log_file_key",
+				Description: "The S3 object key of the log file containing this entry.",
+				Type:        proto.ColumnType_STRING,
+			},
+		}),
+	}
+}
+
+type nlbAccessLogEntry struct {
+	Timestamp                 *time.Time
+	Type                      *string
+	Version                   *string
+	Elb                       *string
+	Listener                  *string
+	ClientIp                  *string
+	ClientPort                *int64
+	DestinationIp             *string
+	DestinationPort           *int64
+	ConnectionTime            *int64
+	TlsHandshakeTime          *int64
+	ReceivedBytes             *int64
+	SentBytes                 *int64
+	IncomingTlsAlert          *string
+	ChosenCertArn             *string
+	ChosenCertSerial          *string
+	TlsCipher                 *string
+	TlsProtocolVersion        *string
+	TlsNamedGroup             *string
+	DomainName                *string
+	AlpnFeProtocol            *string
+	AlpnBeProtocol            *string
+	AlpnClientPreferenceList  []string
+	TlsConnectionCreationTime *time.Time
+	LogFileKey                string
+}
+
+//// LIST FUNCTION
+
+func listEc2NetworkLoadBalancerAccessLogs(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	return listElbAccessLogs(ctx, d, "aws_ec2_network_load_balancer_access_log", func(key string) accessLogLineParser {
+		return func(line string) interface{} {
+			return parseNlbAccessLogLine(key, line)
+		}
+	})
+}
+
+// parseNlbAccessLogLine parses an entry in the documented network load
+// balancer access log format. Network load balancers only write access logs
+// for TLS listeners.
+func parseNlbAccessLogLine(key string, line string) interface{} {
+	f := accessLogFields(splitAccessLogLine(line))
+	if len(f) < 13 {
+		return nil
+	}
+
+	entry := &nlbAccessLogEntry{
+		Type:                      f.String(0),
+		Version:                   f.String(1),
+		Timestamp:                 f.Time(2, "2006-01-02T15:04:05"),
+		Elb:                       f.String(3),
+		Listener:                  f.String(4),
+		ConnectionTime:            f.Int(7),
+		TlsHandshakeTime:          f.Int(8),
+		ReceivedBytes:             f.Int(9),
+		SentBytes:                 f.Int(10),
+		IncomingTlsAlert:          f.String(11),
+		ChosenCertArn:             f.String(12),
+		ChosenCertSerial:          f.String(13),
+		TlsCipher:                 f.String(14),
+		TlsProtocolVersion:        f.String(15),
+		TlsNamedGroup:             f.String(16),
+		DomainName:                f.String(17),
+		AlpnFeProtocol:            f.String(18),
+		AlpnBeProtocol:            f.String(19),
+		AlpnClientPreferenceList:  f.List(20, ","),
+		TlsConnectionCreationTime: f.Time(21, "2006-01-02T15:04:05"),
+		LogFileKey:                key,
+	}
+	entry.ClientIp, entry.ClientPort = splitHostPort(f.String(5))
+	entry.DestinationIp, entry.DestinationPort = splitHostPort(f.String(6))
+
+	return entry
+}
@@ -0,0 +1,246 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	ec2v1 "github.com/aws/aws-sdk-go/service/ec2"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+func tableAwsEc2TransitGatewayConnectAttachment(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_ec2_transit_gateway_connect_attachment",
+		Description: "AWS EC2 Transit Gateway Connect Attachment",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("transit_gateway_attachment_id"),
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"InvalidTransitGatewayAttachmentID.NotFound", "InvalidTransitGatewayAttachmentID.Unavailable", "InvalidTransitGatewayAttachmentID.Malformed", "InvalidAction"}),
+			},
+			Hydrate: getEc2TransitGatewayConnectAttachment,
+			Tags:    map[string]string{"service": "ec2", "action": "DescribeTransitGatewayConnects"},
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listEc2TransitGatewayConnectAttachments,
+			Tags:    map[string]string{"service": "ec2", "action": "DescribeTransitGatewayConnects"},
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "state", Require: plugin.Optional},
+				{Name: "transit_gateway_id", Require: plugin.Optional},
+				{Name: "transport_transit_gateway_attachment_id", Require: plugin.Optional},
+			},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"InvalidAction"}),
+			},
+		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{
+				Func: listEc2TransitGatewayConnectPeers,
+				Tags: map[string]string{"service": "ec2", "action": "DescribeTransitGatewayConnectPeers"},
+			},
+		},
+		GetMatrixItemFunc: SupportedRegionMatrix(ec2v1.EndpointsID),
+		Columns: awsRegionalColumns([]*plugin.Column{
+			{
+				Name:        "transit_gateway_attachment_id",
+				Description: "The ID of the Connect attachment.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "transit_gateway_id",
+				Description: "The ID of the transit gateway.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "transport_transit_gateway_attachment_id",
+				Description: "The ID of the attachment from which the Connect attachment was created, used as the transport of the Connect peers.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "state",
+				Description: "The state of the Connect attachment.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "protocol",
+				Description: "The tunnel protocol of the Connect attachment.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Options.Protocol"),
+			},
+			{
+				Name:        "creation_time",
+				Description: "The creation time of the Connect attachment.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "connect_peers",
+				Description: "The Connect peers of the attachment, with their tunnel addresses and BGP configurations.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     listEc2TransitGatewayConnectPeers,
+				Transform:   transform.FromValue(),
+			},
+			{
+				Name:        "tags_src",
+				Description: "A list of tags assigned.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Tags"),
+			},
+
+			/// Standard columns
+			{
+				Name:        "tags",
+				Description: resourceInterfaceDescription("tags"),
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Tags").Transform(ec2TransitGatewayTagsToTurbotTags),
+			},
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Tags").Transform(ec2TransitGatewayTagsToTitle),
+			},
+			{
+				Name:        "akas",
+				Description: resourceInterfaceDescription("akas"),
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getEc2TransitGatewayAttachmentAkas,
+				Transform:   transform.FromValue(),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listEc2TransitGatewayConnectAttachments(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// Create Session
+	svc, err := EC2Client(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_ec2_transit_gateway_connect_attachment.listEc2TransitGatewayConnectAttachments", "connection_error", err)
+		return nil, err
+	}
+
+	// Limiting the results
+	maxLimit := int32(1000)
+	if d.QueryContext.Limit != nil {
+		limit := int32(*d.QueryContext.Limit)
+		if limit < maxLimit {
+			if limit < 5 {
+				maxLimit = 5
+			} else {
+				maxLimit = limit
+			}
+		}
+	}
+
+	input := &ec2.DescribeTransitGatewayConnectsInput{
+		MaxResults: aws.Int32(maxLimit),
+	}
+
+	filterQuals := map[string]string{
+		"state":              "state",
+		"transit_gateway_id": "transit-gateway-id",
+		"transport_transit_gateway_attachment_id": "transport-transit-gateway-attachment-id",
+	}
+	for columnName, filterName := range filterQuals {
+		if d.EqualsQualString(columnName) != "" {
+			input.Filters = append(input.Filters, types.Filter{
+				Name:   aws.String(filterName),
+				Values: []string{d.EqualsQualString(columnName)},
+			})
+		}
+	}
+
+	paginator := ec2.NewDescribeTransitGatewayConnectsPaginator(svc, input, func(o *ec2.DescribeTransitGatewayConnectsPaginatorOptions) {
+		o.Limit = maxLimit
+		o.StopOnDuplicateToken = true
+	})
+
+	// List call
+	for paginator.HasMorePages() {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_ec2_transit_gateway_connect_attachment.listEc2TransitGatewayConnectAttachments", "api_error", err)
+			return nil, err
+		}
+
+		for _, items := range output.TransitGatewayConnects {
+			d.StreamListItem(ctx, items)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getEc2TransitGatewayConnectAttachment(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	transitGatewayAttachmentID := d.EqualsQuals["transit_gateway_attachment_id"].GetStringValue()
+
+	// Create Session
+	svc, err := EC2Client(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_ec2_transit_gateway_connect_attachment.getEc2TransitGatewayConnectAttachment", "connection_error", err)
+		return nil, err
+	}
+
+	// Build params
+	params := &ec2.DescribeTransitGatewayConnectsInput{
+		TransitGatewayAttachmentIds: []string{transitGatewayAttachmentID},
+	}
+
+	op, err := svc.DescribeTransitGatewayConnects(ctx, params)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_ec2_transit_gateway_connect_attachment.getEc2TransitGatewayConnectAttachment", "api_error", err)
+		return nil, err
+	}
+
+	if len(op.TransitGatewayConnects) > 0 {
+		return op.TransitGatewayConnects[0], nil
+	}
+	return nil, nil
+}
+
+func listEc2TransitGatewayConnectPeers(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	connect := h.Item.(types.TransitGatewayConnect)
+
+	// Create Session
+	svc, err := EC2Client(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_ec2_transit_gateway_connect_attachment.listEc2TransitGatewayConnectPeers", "connection_error", err)
+		return nil, err
+	}
+
+	peers := []types.TransitGatewayConnectPeer{}
+	paginator := ec2.NewDescribeTransitGatewayConnectPeersPaginator(svc, &ec2.DescribeTransitGatewayConnectPeersInput{
+		Filters: []types.Filter{
+			{
+				Name:   aws.String("transit-gateway-attachment-id"),
+				Values: []string{aws.ToString(connect.TransitGatewayAttachmentId)},
+			},
+		},
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_ec2_transit_gateway_connect_attachment.listEc2TransitGatewayConnectPeers", "api_error", err)
+			return nil, err
+		}
+		peers = append(peers, output.TransitGatewayConnectPeers...)
+	}
+
+	return peers, nil
+}
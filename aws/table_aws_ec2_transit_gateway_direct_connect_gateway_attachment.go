@@ -0,0 +1,132 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	ec2v1 "github.com/aws/aws-sdk-go/service/ec2"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+func tableAwsEc2TransitGatewayDirectConnectGatewayAttachment(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_ec2_transit_gateway_direct_connect_gateway_attachment",
+		Description: "AWS EC2 Transit Gateway Direct Connect Gateway Attachment",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("transit_gateway_attachment_id"),
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"InvalidTransitGatewayAttachmentID.NotFound", "InvalidTransitGatewayAttachmentID.Unavailable", "InvalidTransitGatewayAttachmentID.Malformed", "InvalidAction"}),
+			},
+			Hydrate: getEc2TransitGatewayDirectConnectGatewayAttachment,
+			Tags:    map[string]string{"service": "ec2", "action": "DescribeTransitGatewayAttachments"},
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listEc2TransitGatewayDirectConnectGatewayAttachments,
+			Tags:    map[string]string{"service": "ec2", "action": "DescribeTransitGatewayAttachments"},
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "association_state", Require: plugin.Optional},
+				{Name: "association_transit_gateway_route_table_id", Require: plugin.Optional},
+				{Name: "direct_connect_gateway_id", Require: plugin.Optional},
+				{Name: "state", Require: plugin.Optional},
+				{Name: "transit_gateway_id", Require: plugin.Optional},
+			},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"InvalidAction"}),
+			},
+		},
+		GetMatrixItemFunc: SupportedRegionMatrix(ec2v1.EndpointsID),
+		Columns: awsRegionalColumns([]*plugin.Column{
+			{
+				Name:        "transit_gateway_attachment_id",
+				Description: "The ID of the transit gateway attachment.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "direct_connect_gateway_id",
+				Description: "The ID of the Direct Connect gateway.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ResourceId"),
+			},
+			{
+				Name:        "transit_gateway_id",
+				Description: "The ID of the transit gateway.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "transit_gateway_owner_id",
+				Description: "The ID of the AWS account that owns the transit gateway.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "resource_owner_id",
+				Description: "The ID of the AWS account that owns the Direct Connect gateway.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "state",
+				Description: "The attachment state of the transit gateway attachment.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "creation_time",
+				Description: "The creation time of the transit gateway attachment.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "association_state",
+				Description: "The state of the association.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Association.State"),
+			},
+			{
+				Name:        "association_transit_gateway_route_table_id",
+				Description: "The ID of the route table for the transit gateway.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Association.TransitGatewayRouteTableId"),
+			},
+			{
+				Name:        "tags_src",
+				Description: "A list of tags assigned.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Tags"),
+			},
+
+			/// Standard columns
+			{
+				Name:        "tags",
+				Description: resourceInterfaceDescription("tags"),
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.From(transitGatewayAttachmentRawTagsToTurbotTags),
+			},
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.From(getEc2TransitGatewayAttachmentTitle),
+			},
+			{
+				Name:        "akas",
+				Description: resourceInterfaceDescription("akas"),
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getAwsEc2TransitGatewayVpcAttachmentAkas,
+				Transform:   transform.FromValue(),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listEc2TransitGatewayDirectConnectGatewayAttachments(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	return listEc2TransitGatewayAttachmentsOfType(ctx, d, "aws_ec2_transit_gateway_direct_connect_gateway_attachment", types.TransitGatewayAttachmentResourceTypeDirectConnectGateway, "direct_connect_gateway_id")
+}
+
+//// HYDRATE FUNCTIONS
+
+func getEc2TransitGatewayDirectConnectGatewayAttachment(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	return getEc2TransitGatewayAttachmentOfType(ctx, d, "aws_ec2_transit_gateway_direct_connect_gateway_attachment", types.TransitGatewayAttachmentResourceTypeDirectConnectGateway)
+}
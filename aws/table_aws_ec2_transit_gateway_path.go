@@ -0,0 +1,320 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	ec2v1 "github.com/aws/aws-sdk-go/service/ec2"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+const (
+	transitGatewayPathDelivered            = "delivered"
+	transitGatewayPathPeering              = "peering"
+	transitGatewayPathBlackhole            = "blackhole"
+	transitGatewayPathNoRoute              = "no_route"
+	transitGatewayPathNoAssociation        = "no_association"
+	transitGatewayPathPeerNotFound         = "peer_not_found"
+	transitGatewayPathLoop                 = "loop"
+	transitGatewayPathIncompleteRouteTable = "incomplete_route_table"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsEc2TransitGatewayPath(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_ec2_transit_gateway_path",
+		Description: "AWS EC2 Transit Gateway Path",
+		List: &plugin.ListConfig{
+			Hydrate: listEc2TransitGatewayPath,
+			Tags:    map[string]string{"service": "ec2", "action": "SearchTransitGatewayRoutes"},
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "source_attachment_id", Require: plugin.Required},
+				{Name: "destination_cidr", Require: plugin.Required},
+			},
+		},
+		GetMatrixItemFunc: SupportedRegionMatrix(ec2v1.EndpointsID),
+		Columns: awsRegionalColumns([]*plugin.Column{
+			{
+				Name:        "source_attachment_id",
+				Description: "The ID of the transit gateway attachment the traffic enters the transit gateway from.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "destination_cidr",
+				Description: "The destination address or CIDR block of the traffic.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "hop",
+				Description: "The position of the transit gateway in the path, starting at 1.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "hop_region",
+				Description: "The region of the transit gateway of the hop.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "transit_gateway_id",
+				Description: "The ID of the transit gateway of the hop.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "attachment_id",
+				Description: "The ID of the attachment the traffic enters the transit gateway of the hop from.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "attachment_resource_type",
+				Description: "The resource type of the attachment the traffic enters from.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "attachment_resource_id",
+				Description: "The ID of the resource of the attachment the traffic enters from.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "transit_gateway_route_table_id",
+				Description: "The ID of the route table associated with the attachment, used to route the traffic.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("TransitGatewayRouteTableId").NullIfZero(),
+			},
+			{
+				Name:        "route_destination_cidr_block",
+				Description: "The destination CIDR block of the route chosen for the traffic.",
+				Type:        proto.ColumnType_CIDR,
+				Transform:   transform.FromField("Route.DestinationCidrBlock"),
+			},
+			{
+				Name:        "route_prefix_list_id",
+				Description: "The destination prefix list of the route chosen for the traffic.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Route.PrefixListId"),
+			},
+			{
+				Name:        "route_type",
+				Description: "The type of the route chosen for the traffic, i.e. static or propagated.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Route.Type"),
+			},
+			{
+				Name:        "route_state",
+				Description: "The state of the route chosen for the traffic.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Route.State"),
+			},
+			{
+				Name:        "target_attachment_id",
+				Description: "The ID of the attachment the traffic leaves the transit gateway of the hop through.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("TargetAttachment.TransitGatewayAttachmentId"),
+			},
+			{
+				Name:        "target_resource_type",
+				Description: "The resource type of the target attachment.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("TargetAttachment.ResourceType"),
+			},
+			{
+				Name:        "target_resource_id",
+				Description: "The ID of the resource of the target attachment.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("TargetAttachment.ResourceId"),
+			},
+			{
+				Name:        "target_attachments",
+				Description: "All the attachments of the route, when traffic is spread over several attachments with ECMP.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Route.TransitGatewayAttachments"),
+			},
+			{
+				Name:        "peer_region",
+				Description: "The region of the peer transit gateway, for traffic leaving through a peering attachment.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("PeerRegion").NullIfZero(),
+			},
+			{
+				Name:        "peer_transit_gateway_id",
+				Description: "The ID of the peer transit gateway, for traffic leaving through a peering attachment.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("PeerTransitGatewayId").NullIfZero(),
+			},
+			{
+				Name:        "outcome",
+				Description: "What happens to the traffic at the hop: delivered, peering, blackhole, no_route, no_association, peer_not_found, loop or incomplete_route_table.",
+				Type:        proto.ColumnType_STRING,
+			},
+		}),
+	}
+}
+
+type transitGatewayPathHop struct {
+	SourceAttachmentId         string
+	DestinationCidr            string
+	Hop                        int
+	HopRegion                  string
+	TransitGatewayId           string
+	AttachmentId               string
+	AttachmentResourceType     string
+	AttachmentResourceId       string
+	TransitGatewayRouteTableId string
+	Route                      *types.TransitGatewayRoute
+	TargetAttachment           *types.TransitGatewayRouteAttachment
+	PeerRegion                 string
+	PeerTransitGatewayId       string
+	Outcome                    string
+}
+
+//// LIST FUNCTION
+
+func listEc2TransitGatewayPath(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	sourceAttachmentId := d.EqualsQualString("source_attachment_id")
+	destinationCidr := d.EqualsQualString("destination_cidr")
+
+	destination, err := netip.ParsePrefix(destinationCidr)
+	if err != nil {
+		addr, addrErr := netip.ParseAddr(destinationCidr)
+		if addrErr != nil {
+			return nil, fmt.Errorf("destination_cidr must be an IP address or a CIDR block: %s", destinationCidr)
+		}
+		destination = netip.PrefixFrom(addr, addr.BitLen())
+	}
+	destination = destination.Masked()
+
+	network, err := getTransitGatewayNetworkForRegion(ctx, d, d.EqualsQualString(matrixKeyRegion))
+	if err != nil {
+		return nil, err
+	}
+
+	// The path starts in the region of the source attachment. Peering
+	// attachments have the same ID in the regions of both transit gateways,
+	// their paths start on the requester side.
+	attachment := network.transitGatewayAttachmentById(sourceAttachmentId)
+	if attachment == nil {
+		return nil, nil
+	}
+	if peering, ok := network.TransitGatewayPeerings[sourceAttachmentId]; ok && peering.RequesterTgwInfo != nil && aws.ToString(peering.RequesterTgwInfo.Region) != network.Region {
+		return nil, nil
+	}
+
+	hops := traceTransitGatewayPath(ctx, d, network, attachment, destination)
+	for _, hop := range hops {
+		hop.SourceAttachmentId = sourceAttachmentId
+		hop.DestinationCidr = destinationCidr
+		d.StreamListItem(ctx, hop)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+
+	return nil, nil
+}
+
+//// PATH EVALUATION
+
+// traceTransitGatewayPath follows traffic to the destination from the
+// attachment it enters a transit gateway from, through the route tables of
+// the transit gateways, and across peering attachments into the regions of
+// the peer transit gateways
+func traceTransitGatewayPath(ctx context.Context, d *plugin.QueryData, network *vpcNetwork, attachment *types.TransitGatewayAttachment, destination netip.Prefix) []*transitGatewayPathHop {
+	hops := []*transitGatewayPathHop{}
+	visited := map[string]bool{}
+
+	for {
+		hop := &transitGatewayPathHop{
+			Hop:                    len(hops) + 1,
+			HopRegion:              network.Region,
+			TransitGatewayId:       aws.ToString(attachment.TransitGatewayId),
+			AttachmentId:           aws.ToString(attachment.TransitGatewayAttachmentId),
+			AttachmentResourceType: string(attachment.ResourceType),
+			AttachmentResourceId:   aws.ToString(attachment.ResourceId),
+		}
+		hops = append(hops, hop)
+
+		if attachment.Association == nil || attachment.Association.State != types.TransitGatewayAssociationStateAssociated {
+			hop.Outcome = transitGatewayPathNoAssociation
+			return hops
+		}
+		hop.TransitGatewayRouteTableId = aws.ToString(attachment.Association.TransitGatewayRouteTableId)
+
+		// A route table reached a second time means routes send the traffic
+		// back and forth between peered transit gateways
+		if visited[hop.TransitGatewayRouteTableId] {
+			hop.Outcome = transitGatewayPathLoop
+			return hops
+		}
+		visited[hop.TransitGatewayRouteTableId] = true
+
+		// Routes of a route table with more routes than could be loaded may be
+		// missing, so the chosen route can't be trusted
+		if network.IncompleteTransitGatewayRouteTables[hop.TransitGatewayRouteTableId] {
+			hop.Outcome = transitGatewayPathIncompleteRouteTable
+			return hops
+		}
+
+		hop.Route = network.selectTransitGatewayRouteForPrefix(hop.TransitGatewayRouteTableId, destination)
+		if hop.Route == nil {
+			hop.Outcome = transitGatewayPathNoRoute
+			return hops
+		}
+		if hop.Route.State == types.TransitGatewayRouteStateBlackhole || len(hop.Route.TransitGatewayAttachments) == 0 {
+			hop.Outcome = transitGatewayPathBlackhole
+			return hops
+		}
+
+		hop.TargetAttachment = &hop.Route.TransitGatewayAttachments[0]
+		if hop.TargetAttachment.ResourceType != types.TransitGatewayAttachmentResourceTypePeering && hop.TargetAttachment.ResourceType != types.TransitGatewayAttachmentResourceTypeTgwPeering {
+			hop.Outcome = transitGatewayPathDelivered
+			return hops
+		}
+
+		// Continue on the other side of the peering
+		hop.Outcome = transitGatewayPathPeering
+		peeringAttachmentId := aws.ToString(hop.TargetAttachment.TransitGatewayAttachmentId)
+		peering, ok := network.TransitGatewayPeerings[peeringAttachmentId]
+		if !ok {
+			hop.Outcome = transitGatewayPathPeerNotFound
+			return hops
+		}
+		peer := peering.AccepterTgwInfo
+		if peer == nil || (aws.ToString(peer.TransitGatewayId) == hop.TransitGatewayId && aws.ToString(peer.Region) == network.Region) {
+			peer = peering.RequesterTgwInfo
+		}
+		if peer == nil {
+			hop.Outcome = transitGatewayPathPeerNotFound
+			return hops
+		}
+		hop.PeerRegion = aws.ToString(peer.Region)
+		hop.PeerTransitGatewayId = aws.ToString(peer.TransitGatewayId)
+
+		peerNetwork, err := getTransitGatewayNetworkForRegion(ctx, d, hop.PeerRegion)
+		if err != nil {
+			// The peer region may not be enabled, or the peer may be owned by
+			// another account
+			plugin.Logger(ctx).Warn("aws_ec2_transit_gateway_path.traceTransitGatewayPath", "peer_region_error", err, "region", hop.PeerRegion)
+			hop.Outcome = transitGatewayPathPeerNotFound
+			return hops
+		}
+		peerAttachment := peerNetwork.transitGatewayAttachmentById(peeringAttachmentId)
+		if peerAttachment == nil && peering.AccepterTransitGatewayAttachmentId != nil {
+			peerAttachment = peerNetwork.transitGatewayAttachmentById(*peering.AccepterTransitGatewayAttachmentId)
+		}
+		if peerAttachment == nil || aws.ToString(peerAttachment.TransitGatewayId) != hop.PeerTransitGatewayId {
+			hop.Outcome = transitGatewayPathPeerNotFound
+			return hops
+		}
+
+		network, attachment = peerNetwork, peerAttachment
+	}
+}
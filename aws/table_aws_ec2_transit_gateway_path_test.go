@@ -0,0 +1,45 @@
+package aws
+
+import (
+	"context"
+	"net/netip"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+func TestTraceTransitGatewayPath(t *testing.T) {
+	tests := []struct {
+		name        string
+		destination string
+		setup       func(network *vpcNetwork)
+		expected    string
+	}{
+		{"delivered", "10.2.0.10/32", nil, transitGatewayPathDelivered},
+		{"no route", "10.9.0.0/24", nil, transitGatewayPathNoRoute},
+		{"incomplete route table", "10.2.0.10/32", func(network *vpcNetwork) {
+			network.IncompleteTransitGatewayRouteTables["tgw-rtb-1"] = true
+		}, transitGatewayPathIncompleteRouteTable},
+		{"tgw peering", "10.2.0.10/32", func(network *vpcNetwork) {
+			// The peering is not loaded, so the peer can't be found
+			network.TransitGatewayRoutes["tgw-rtb-1"][0].TransitGatewayAttachments = []types.TransitGatewayRouteAttachment{
+				{TransitGatewayAttachmentId: aws.String("tgw-attach-peer"), ResourceType: types.TransitGatewayAttachmentResourceTypeTgwPeering},
+			}
+		}, transitGatewayPathPeerNotFound},
+	}
+	for _, test := range tests {
+		network := testVpcNetwork()
+		if test.setup != nil {
+			test.setup(network)
+		}
+		attachment := network.transitGatewayAttachmentById("tgw-attach-a")
+		hops := traceTransitGatewayPath(context.Background(), nil, network, attachment, netip.MustParsePrefix(test.destination))
+		if len(hops) != 1 || hops[0].Outcome != test.expected {
+			t.Errorf("%s: expected a single hop with outcome %s, got %d hops", test.name, test.expected, len(hops))
+			for _, hop := range hops {
+				t.Logf("%s: hop %d outcome %s", test.name, hop.Hop, hop.Outcome)
+			}
+		}
+	}
+}
@@ -0,0 +1,337 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	ec2v1 "github.com/aws/aws-sdk-go/service/ec2"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+func tableAwsEc2TransitGatewayPeeringAttachment(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_ec2_transit_gateway_peering_attachment",
+		Description: "AWS EC2 Transit Gateway Peering Attachment",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("transit_gateway_attachment_id"),
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"InvalidTransitGatewayAttachmentID.NotFound", "InvalidTransitGatewayAttachmentID.Unavailable", "InvalidTransitGatewayAttachmentID.Malformed", "InvalidAction"}),
+			},
+			Hydrate: getEc2TransitGatewayPeeringAttachment,
+			Tags:    map[string]string{"service": "ec2", "action": "DescribeTransitGatewayPeeringAttachments"},
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listEc2TransitGatewayPeeringAttachments,
+			Tags:    map[string]string{"service": "ec2", "action": "DescribeTransitGatewayPeeringAttachments"},
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "state", Require: plugin.Optional},
+				{Name: "transit_gateway_id", Require: plugin.Optional},
+			},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"InvalidAction"}),
+			},
+		},
+		GetMatrixItemFunc: SupportedRegionMatrix(ec2v1.EndpointsID),
+		Columns: awsRegionalColumns([]*plugin.Column{
+			{
+				Name:        "transit_gateway_attachment_id",
+				Description: "The ID of the transit gateway peering attachment.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "transit_gateway_id",
+				Description: "The ID of the transit gateway of the region that the attachment is listed in, whichever side of the peering it is.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.From(transitGatewayPeeringAttachmentLocalTransitGatewayId),
+			},
+			{
+				Name:        "state",
+				Description: "The state of the transit gateway peering attachment.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "status_code",
+				Description: "The status code of the transit gateway peering attachment.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Status.Code"),
+			},
+			{
+				Name:        "status_message",
+				Description: "The status message of the transit gateway peering attachment.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Status.Message"),
+			},
+			{
+				Name:        "creation_time",
+				Description: "The time the transit gateway peering attachment was created.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "requester_transit_gateway_id",
+				Description: "The ID of the transit gateway that requested the peering.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("RequesterTgwInfo.TransitGatewayId"),
+			},
+			{
+				Name:        "requester_owner_id",
+				Description: "The ID of the AWS account that owns the requester transit gateway.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("RequesterTgwInfo.OwnerId"),
+			},
+			{
+				Name:        "requester_region",
+				Description: "The region of the requester transit gateway.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("RequesterTgwInfo.Region"),
+			},
+			{
+				Name:        "requester_core_network_id",
+				Description: "The ID of the core network, if the requester is an AWS Cloud WAN core network.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("RequesterTgwInfo.CoreNetworkId"),
+			},
+			{
+				Name:        "accepter_transit_gateway_id",
+				Description: "The ID of the transit gateway that accepted the peering.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("AccepterTgwInfo.TransitGatewayId"),
+			},
+			{
+				Name:        "accepter_owner_id",
+				Description: "The ID of the AWS account that owns the accepter transit gateway.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("AccepterTgwInfo.OwnerId"),
+			},
+			{
+				Name:        "accepter_region",
+				Description: "The region of the accepter transit gateway.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("AccepterTgwInfo.Region"),
+			},
+			{
+				Name:        "accepter_core_network_id",
+				Description: "The ID of the core network, if the accepter is an AWS Cloud WAN core network.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("AccepterTgwInfo.CoreNetworkId"),
+			},
+			{
+				Name:        "accepter_transit_gateway_attachment_id",
+				Description: "The ID of the accepter transit gateway attachment.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "dynamic_routing",
+				Description: "Indicates whether dynamic routing is enabled or disabled.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Options.DynamicRouting"),
+			},
+			{
+				Name:        "tags_src",
+				Description: "A list of tags assigned.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Tags"),
+			},
+
+			/// Standard columns
+			{
+				Name:        "tags",
+				Description: resourceInterfaceDescription("tags"),
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Tags").Transform(ec2TransitGatewayTagsToTurbotTags),
+			},
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Tags").Transform(ec2TransitGatewayTagsToTitle),
+			},
+			{
+				Name:        "akas",
+				Description: resourceInterfaceDescription("akas"),
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getEc2TransitGatewayAttachmentAkas,
+				Transform:   transform.FromValue(),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listEc2TransitGatewayPeeringAttachments(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// Create Session
+	svc, err := EC2Client(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_ec2_transit_gateway_peering_attachment.listEc2TransitGatewayPeeringAttachments", "connection_error", err)
+		return nil, err
+	}
+
+	// Limiting the results
+	maxLimit := int32(1000)
+	if d.QueryContext.Limit != nil {
+		limit := int32(*d.QueryContext.Limit)
+		if limit < maxLimit {
+			if limit < 5 {
+				maxLimit = 5
+			} else {
+				maxLimit = limit
+			}
+		}
+	}
+
+	input := &ec2.DescribeTransitGatewayPeeringAttachmentsInput{
+		MaxResults: aws.Int32(maxLimit),
+	}
+
+	filterQuals := map[string]string{
+		"state":              "state",
+		"transit_gateway_id": "transit-gateway-id",
+	}
+	for columnName, filterName := range filterQuals {
+		if d.EqualsQualString(columnName) != "" {
+			input.Filters = append(input.Filters, types.Filter{
+				Name:   aws.String(filterName),
+				Values: []string{d.EqualsQualString(columnName)},
+			})
+		}
+	}
+
+	paginator := ec2.NewDescribeTransitGatewayPeeringAttachmentsPaginator(svc, input, func(o *ec2.DescribeTransitGatewayPeeringAttachmentsPaginatorOptions) {
+		o.Limit = maxLimit
+		o.StopOnDuplicateToken = true
+	})
+
+	// List call
+	for paginator.HasMorePages() {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_ec2_transit_gateway_peering_attachment.listEc2TransitGatewayPeeringAttachments", "api_error", err)
+			return nil, err
+		}
+
+		for _, items := range output.TransitGatewayPeeringAttachments {
+			d.StreamListItem(ctx, items)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getEc2TransitGatewayPeeringAttachment(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	transitGatewayAttachmentID := d.EqualsQuals["transit_gateway_attachment_id"].GetStringValue()
+
+	// Create Session
+	svc, err := EC2Client(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_ec2_transit_gateway_peering_attachment.getEc2TransitGatewayPeeringAttachment", "connection_error", err)
+		return nil, err
+	}
+
+	// Build params
+	params := &ec2.DescribeTransitGatewayPeeringAttachmentsInput{
+		TransitGatewayAttachmentIds: []string{transitGatewayAttachmentID},
+	}
+
+	op, err := svc.DescribeTransitGatewayPeeringAttachments(ctx, params)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_ec2_transit_gateway_peering_attachment.getEc2TransitGatewayPeeringAttachment", "api_error", err)
+		return nil, err
+	}
+
+	if len(op.TransitGatewayPeeringAttachments) > 0 {
+		return op.TransitGatewayPeeringAttachments[0], nil
+	}
+	return nil, nil
+}
+
+// getEc2TransitGatewayAttachmentAkas returns the ARN of the transit gateway
+// attachment of the row, whichever attachment type its data is
+func getEc2TransitGatewayAttachmentAkas(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	region := d.EqualsQualString(matrixKeyRegion)
+
+	var attachmentId string
+	switch item := h.Item.(type) {
+	case types.TransitGatewayPeeringAttachment:
+		attachmentId = aws.ToString(item.TransitGatewayAttachmentId)
+	case types.TransitGatewayConnect:
+		attachmentId = aws.ToString(item.TransitGatewayAttachmentId)
+	}
+
+	commonData, err := getCommonColumns(ctx, d, h)
+	if err != nil {
+		return nil, err
+	}
+	commonColumnData := commonData.(*awsCommonColumnData)
+
+	// Get the resource akas
+	akas := []string{"arn:" + commonColumnData.Partition + ":ec2:" + region + ":" + commonColumnData.AccountId + ":transit-gateway-attachment/" + attachmentId}
+
+	return akas, nil
+}
+
+//// TRANSFORM FUNCTIONS
+
+// transitGatewayPeeringAttachmentLocalTransitGatewayId returns the transit
+// gateway of the peering in the region of the row
+func transitGatewayPeeringAttachmentLocalTransitGatewayId(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	data := d.HydrateItem.(types.TransitGatewayPeeringAttachment)
+	region := d.MatrixItem[matrixKeyRegion]
+
+	if data.AccepterTgwInfo != nil && aws.ToString(data.AccepterTgwInfo.Region) == region {
+		return data.AccepterTgwInfo.TransitGatewayId, nil
+	}
+	if data.RequesterTgwInfo != nil {
+		return data.RequesterTgwInfo.TransitGatewayId, nil
+	}
+	return nil, nil
+}
+
+func ec2TransitGatewayTagsToTurbotTags(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	tags, ok := d.Value.([]types.Tag)
+	if !ok || tags == nil {
+		return nil, nil
+	}
+
+	turbotTagsMap := map[string]string{}
+	for _, i := range tags {
+		turbotTagsMap[*i.Key] = *i.Value
+	}
+
+	return &turbotTagsMap, nil
+}
+
+// ec2TransitGatewayTagsToTitle returns the Name tag of a transit gateway
+// resource, or its attachment ID
+func ec2TransitGatewayTagsToTitle(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	var title *string
+	switch item := d.HydrateItem.(type) {
+	case types.TransitGatewayPeeringAttachment:
+		title = item.TransitGatewayAttachmentId
+	case types.TransitGatewayConnect:
+		title = item.TransitGatewayAttachmentId
+	}
+
+	tags, _ := d.Value.([]types.Tag)
+	for _, i := range tags {
+		if aws.ToString(i.Key) == "Name" {
+			title = i.Value
+		}
+	}
+	return title, nil
+}
@@ -0,0 +1,173 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	ec2v1 "github.com/aws/aws-sdk-go/service/ec2"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+type transitGatewayRouteTableAssociation struct {
+	types.TransitGatewayRouteTableAssociation
+	TransitGatewayRouteTableId *string
+	TransitGatewayId           *string
+}
+
+//// TABLE DEFINITION
+
+func tableAwsEc2TransitGatewayRouteTableAssociation(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_ec2_transit_gateway_route_table_association",
+		Description: "AWS EC2 Transit Gateway Route Table Association",
+		List: &plugin.ListConfig{
+			ParentHydrate: listEc2TransitGatewayRouteTable,
+			Hydrate:       listEc2TransitGatewayRouteTableAssociations,
+			Tags:          map[string]string{"service": "ec2", "action": "GetTransitGatewayRouteTableAssociations"},
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "transit_gateway_route_table_id", Require: plugin.Optional},
+				{Name: "transit_gateway_attachment_id", Require: plugin.Optional},
+				{Name: "resource_id", Require: plugin.Optional},
+				{Name: "resource_type", Require: plugin.Optional},
+			},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"InvalidAction", "InvalidRouteTableID.NotFound"}),
+			},
+		},
+		GetMatrixItemFunc: SupportedRegionMatrix(ec2v1.EndpointsID),
+		Columns: awsRegionalColumns([]*plugin.Column{
+			{
+				Name:        "transit_gateway_route_table_id",
+				Description: "The ID of the transit gateway route table.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "transit_gateway_id",
+				Description: "The ID of the transit gateway of the route table.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "transit_gateway_attachment_id",
+				Description: "The ID of the attachment associated with the route table.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "resource_id",
+				Description: "The ID of the resource of the attachment.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "resource_type",
+				Description: "The resource type of the attachment. Note that the tgw-peering resource type has been deprecated.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "state",
+				Description: "The state of the association.",
+				Type:        proto.ColumnType_STRING,
+			},
+
+			/// Standard columns
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("TransitGatewayAttachmentId"),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listEc2TransitGatewayRouteTableAssociations(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	routeTable := h.Item.(types.TransitGatewayRouteTable)
+
+	// check if the provided transit_gateway_route_table_id is not matching with the parentHydrate
+	if d.EqualsQualString("transit_gateway_route_table_id") != "" && d.EqualsQualString("transit_gateway_route_table_id") != aws.ToString(routeTable.TransitGatewayRouteTableId) {
+		return nil, nil
+	}
+
+	// Create Session
+	svc, err := EC2Client(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_ec2_transit_gateway_route_table_association.listEc2TransitGatewayRouteTableAssociations", "connection_error", err)
+		return nil, err
+	}
+
+	// Limiting the results
+	maxLimit := int32(1000)
+	if d.QueryContext.Limit != nil {
+		limit := int32(*d.QueryContext.Limit)
+		if limit < maxLimit {
+			if limit < 5 {
+				maxLimit = 5
+			} else {
+				maxLimit = limit
+			}
+		}
+	}
+
+	input := &ec2.GetTransitGatewayRouteTableAssociationsInput{
+		TransitGatewayRouteTableId: routeTable.TransitGatewayRouteTableId,
+		MaxResults:                 aws.Int32(maxLimit),
+		Filters:                    buildEc2TransitGatewayRouteTableAttachmentFilter(d),
+	}
+
+	paginator := ec2.NewGetTransitGatewayRouteTableAssociationsPaginator(svc, input, func(o *ec2.GetTransitGatewayRouteTableAssociationsPaginatorOptions) {
+		o.Limit = maxLimit
+		o.StopOnDuplicateToken = true
+	})
+
+	// List call
+	for paginator.HasMorePages() {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_ec2_transit_gateway_route_table_association.listEc2TransitGatewayRouteTableAssociations", "api_error", err)
+			return nil, err
+		}
+
+		for _, association := range output.Associations {
+			d.StreamListItem(ctx, transitGatewayRouteTableAssociation{association, routeTable.TransitGatewayRouteTableId, routeTable.TransitGatewayId})
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+//// UTILITY FUNCTIONS
+
+// buildEc2TransitGatewayRouteTableAttachmentFilter builds the filters on the
+// attachments of the associations and propagations of a route table
+func buildEc2TransitGatewayRouteTableAttachmentFilter(d *plugin.QueryData) []types.Filter {
+	var filters []types.Filter
+
+	filterQuals := map[string]string{
+		"resource_id":                   "resource-id",
+		"resource_type":                 "resource-type",
+		"transit_gateway_attachment_id": "transit-gateway-attachment-id",
+	}
+	for columnName, filterName := range filterQuals {
+		if d.EqualsQualString(columnName) != "" {
+			filters = append(filters, types.Filter{
+				Name:   aws.String(filterName),
+				Values: []string{d.EqualsQualString(columnName)},
+			})
+		}
+	}
+	return filters
+}
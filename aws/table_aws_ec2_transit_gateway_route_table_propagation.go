@@ -0,0 +1,155 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	ec2v1 "github.com/aws/aws-sdk-go/service/ec2"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+type transitGatewayRouteTablePropagation struct {
+	types.TransitGatewayRouteTablePropagation
+	TransitGatewayRouteTableId *string
+	TransitGatewayId           *string
+}
+
+//// TABLE DEFINITION
+
+func tableAwsEc2TransitGatewayRouteTablePropagation(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_ec2_transit_gateway_route_table_propagation",
+		Description: "AWS EC2 Transit Gateway Route Table Propagation",
+		List: &plugin.ListConfig{
+			ParentHydrate: listEc2TransitGatewayRouteTable,
+			Hydrate:       listEc2TransitGatewayRouteTablePropagations,
+			Tags:          map[string]string{"service": "ec2", "action": "GetTransitGatewayRouteTablePropagations"},
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "transit_gateway_route_table_id", Require: plugin.Optional},
+				{Name: "transit_gateway_attachment_id", Require: plugin.Optional},
+				{Name: "resource_id", Require: plugin.Optional},
+				{Name: "resource_type", Require: plugin.Optional},
+			},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"InvalidAction", "InvalidRouteTableID.NotFound"}),
+			},
+		},
+		GetMatrixItemFunc: SupportedRegionMatrix(ec2v1.EndpointsID),
+		Columns: awsRegionalColumns([]*plugin.Column{
+			{
+				Name:        "transit_gateway_route_table_id",
+				Description: "The ID of the transit gateway route table.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "transit_gateway_id",
+				Description: "The ID of the transit gateway of the route table.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "transit_gateway_attachment_id",
+				Description: "The ID of the attachment propagating routes to the route table.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "resource_id",
+				Description: "The ID of the resource of the attachment.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "resource_type",
+				Description: "The resource type of the attachment. Note that the tgw-peering resource type has been deprecated.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "state",
+				Description: "The state of the propagation.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "transit_gateway_route_table_announcement_id",
+				Description: "The ID of the transit gateway route table announcement.",
+				Type:        proto.ColumnType_STRING,
+			},
+
+			/// Standard columns
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("TransitGatewayAttachmentId"),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listEc2TransitGatewayRouteTablePropagations(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	routeTable := h.Item.(types.TransitGatewayRouteTable)
+
+	// check if the provided transit_gateway_route_table_id is not matching with the parentHydrate
+	if d.EqualsQualString("transit_gateway_route_table_id") != "" && d.EqualsQualString("transit_gateway_route_table_id") != aws.ToString(routeTable.TransitGatewayRouteTableId) {
+		return nil, nil
+	}
+
+	// Create Session
+	svc, err := EC2Client(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_ec2_transit_gateway_route_table_propagation.listEc2TransitGatewayRouteTablePropagations", "connection_error", err)
+		return nil, err
+	}
+
+	// Limiting the results
+	maxLimit := int32(1000)
+	if d.QueryContext.Limit != nil {
+		limit := int32(*d.QueryContext.Limit)
+		if limit < maxLimit {
+			if limit < 5 {
+				maxLimit = 5
+			} else {
+				maxLimit = limit
+			}
+		}
+	}
+
+	input := &ec2.GetTransitGatewayRouteTablePropagationsInput{
+		TransitGatewayRouteTableId: routeTable.TransitGatewayRouteTableId,
+		MaxResults:                 aws.Int32(maxLimit),
+		Filters:                    buildEc2TransitGatewayRouteTableAttachmentFilter(d),
+	}
+
+	paginator := ec2.NewGetTransitGatewayRouteTablePropagationsPaginator(svc, input, func(o *ec2.GetTransitGatewayRouteTablePropagationsPaginatorOptions) {
+		o.Limit = maxLimit
+		o.StopOnDuplicateToken = true
+	})
+
+	// List call
+	for paginator.HasMorePages() {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_ec2_transit_gateway_route_table_propagation.listEc2TransitGatewayRouteTablePropagations", "api_error", err)
+			return nil, err
+		}
+
+		for _, propagation := range output.TransitGatewayRouteTablePropagations {
+			d.StreamListItem(ctx, transitGatewayRouteTablePropagation{propagation, routeTable.TransitGatewayRouteTableId, routeTable.TransitGatewayId})
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,242 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	ec2v1 "github.com/aws/aws-sdk-go/service/ec2"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+func tableAwsEc2TransitGatewayVpnAttachment(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_ec2_transit_gateway_vpn_attachment",
+		Description: "AWS EC2 Transit Gateway VPN Attachment",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("transit_gateway_attachment_id"),
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"InvalidTransitGatewayAttachmentID.NotFound", "InvalidTransitGatewayAttachmentID.Unavailable", "InvalidTransitGatewayAttachmentID.Malformed", "InvalidAction"}),
+			},
+			Hydrate: getEc2TransitGatewayVpnAttachment,
+			Tags:    map[string]string{"service": "ec2", "action": "DescribeTransitGatewayAttachments"},
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listEc2TransitGatewayVpnAttachments,
+			Tags:    map[string]string{"service": "ec2", "action": "DescribeTransitGatewayAttachments"},
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "association_state", Require: plugin.Optional},
+				{Name: "association_transit_gateway_route_table_id", Require: plugin.Optional},
+				{Name: "vpn_connection_id", Require: plugin.Optional},
+				{Name: "state", Require: plugin.Optional},
+				{Name: "transit_gateway_id", Require: plugin.Optional},
+			},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"InvalidAction"}),
+			},
+		},
+		GetMatrixItemFunc: SupportedRegionMatrix(ec2v1.EndpointsID),
+		Columns: awsRegionalColumns([]*plugin.Column{
+			{
+				Name:        "transit_gateway_attachment_id",
+				Description: "The ID of the transit gateway attachment.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "vpn_connection_id",
+				Description: "The ID of the Site-to-Site VPN connection.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ResourceId"),
+			},
+			{
+				Name:        "transit_gateway_id",
+				Description: "The ID of the transit gateway.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "transit_gateway_owner_id",
+				Description: "The ID of the AWS account that owns the transit gateway.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "resource_owner_id",
+				Description: "The ID of the AWS account that owns the VPN connection.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "state",
+				Description: "The attachment state of the transit gateway attachment.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "creation_time",
+				Description: "The creation time of the transit gateway attachment.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "association_state",
+				Description: "The state of the association.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Association.State"),
+			},
+			{
+				Name:        "association_transit_gateway_route_table_id",
+				Description: "The ID of the route table for the transit gateway.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Association.TransitGatewayRouteTableId"),
+			},
+			{
+				Name:        "tags_src",
+				Description: "A list of tags assigned.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Tags"),
+			},
+
+			/// Standard columns
+			{
+				Name:        "tags",
+				Description: resourceInterfaceDescription("tags"),
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.From(transitGatewayAttachmentRawTagsToTurbotTags),
+			},
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.From(getEc2TransitGatewayAttachmentTitle),
+			},
+			{
+				Name:        "akas",
+				Description: resourceInterfaceDescription("akas"),
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getAwsEc2TransitGatewayVpcAttachmentAkas,
+				Transform:   transform.FromValue(),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listEc2TransitGatewayVpnAttachments(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	return listEc2TransitGatewayAttachmentsOfType(ctx, d, "aws_ec2_transit_gateway_vpn_attachment", types.TransitGatewayAttachmentResourceTypeVpn, "vpn_connection_id")
+}
+
+// listEc2TransitGatewayAttachmentsOfType lists the transit gateway
+// attachments of a resource type, with the resource ID filtered by the
+// resource column
+func listEc2TransitGatewayAttachmentsOfType(ctx context.Context, d *plugin.QueryData, tableName string, resourceType types.TransitGatewayAttachmentResourceType, resourceColumn string) (interface{}, error) {
+	// Create Session
+	svc, err := EC2Client(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error(tableName+".listEc2TransitGatewayAttachmentsOfType", "connection_error", err)
+		return nil, err
+	}
+
+	// Limiting the results
+	maxLimit := int32(1000)
+	if d.QueryContext.Limit != nil {
+		limit := int32(*d.QueryContext.Limit)
+		if limit < maxLimit {
+			if limit < 5 {
+				maxLimit = 5
+			} else {
+				maxLimit = limit
+			}
+		}
+	}
+
+	input := &ec2.DescribeTransitGatewayAttachmentsInput{
+		MaxResults: aws.Int32(maxLimit),
+		Filters: []types.Filter{
+			{
+				Name:   aws.String("resource-type"),
+				Values: []string{string(resourceType)},
+			},
+		},
+	}
+
+	filterQuals := map[string]string{
+		"association_state":                          "association.state",
+		"association_transit_gateway_route_table_id": "association.transit-gateway-route-table-id",
+		resourceColumn:                               "resource-id",
+		"state":                                      "state",
+		"transit_gateway_id":                         "transit-gateway-id",
+	}
+	for columnName, filterName := range filterQuals {
+		if d.EqualsQualString(columnName) != "" {
+			input.Filters = append(input.Filters, types.Filter{
+				Name:   aws.String(filterName),
+				Values: []string{d.EqualsQualString(columnName)},
+			})
+		}
+	}
+
+	paginator := ec2.NewDescribeTransitGatewayAttachmentsPaginator(svc, input, func(o *ec2.DescribeTransitGatewayAttachmentsPaginatorOptions) {
+		o.Limit = maxLimit
+		o.StopOnDuplicateToken = true
+	})
+
+	// List call
+	for paginator.HasMorePages() {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error(tableName+".listEc2TransitGatewayAttachmentsOfType", "api_error", err)
+			return nil, err
+		}
+
+		for _, items := range output.TransitGatewayAttachments {
+			d.StreamListItem(ctx, items)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getEc2TransitGatewayVpnAttachment(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	return getEc2TransitGatewayAttachmentOfType(ctx, d, "aws_ec2_transit_gateway_vpn_attachment", types.TransitGatewayAttachmentResourceTypeVpn)
+}
+
+// getEc2TransitGatewayAttachmentOfType returns the transit gateway attachment
+// of the transit_gateway_attachment_id qual, if it is of the resource type
+func getEc2TransitGatewayAttachmentOfType(ctx context.Context, d *plugin.QueryData, tableName string, resourceType types.TransitGatewayAttachmentResourceType) (interface{}, error) {
+	transitGatewayAttachmentID := d.EqualsQuals["transit_gateway_attachment_id"].GetStringValue()
+
+	// Create Session
+	svc, err := EC2Client(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error(tableName+".getEc2TransitGatewayAttachmentOfType", "connection_error", err)
+		return nil, err
+	}
+
+	// Build params
+	params := &ec2.DescribeTransitGatewayAttachmentsInput{
+		TransitGatewayAttachmentIds: []string{transitGatewayAttachmentID},
+	}
+
+	op, err := svc.DescribeTransitGatewayAttachments(ctx, params)
+	if err != nil {
+		plugin.Logger(ctx).Error(tableName+".getEc2TransitGatewayAttachmentOfType", "api_error", err)
+		return nil, err
+	}
+
+	if len(op.TransitGatewayAttachments) > 0 && op.TransitGatewayAttachments[0].ResourceType == resourceType {
+		return op.TransitGatewayAttachments[0], nil
+	}
+	return nil, nil
+}
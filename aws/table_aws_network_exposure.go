@@ -0,0 +1,444 @@
+package aws
+
+import (
+	"context"
+	"net/netip"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	ec2v1 "github.com/aws/aws-sdk-go/service/ec2"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// The resource types of network interfaces, by the prefix of their
+// description. The rest of the description identifies the resource.
+var networkInterfaceResourceTypes = []struct {
+	Prefix       string
+	ResourceType string
+}{
+	{"ELB app/", "application_load_balancer"},
+	{"ELB net/", "network_load_balancer"},
+	{"ELB gwy/", "gateway_load_balancer"},
+	{"ELB ", "classic_load_balancer"},
+	{"RDSNetworkInterface", "rds_instance"},
+	{"ElastiCache ", "elasticache_cluster"},
+	{"ES ", "opensearch_domain"},
+	{"AWS Lambda VPC ENI-", "lambda_function"},
+	{"Amazon EKS ", "eks_cluster"},
+	{"Interface for NAT Gateway ", "nat_gateway"},
+	{"VPC Endpoint Interface ", "vpc_endpoint"},
+	{"EFS mount target for ", "efs_mount_target"},
+	{"Network Interface for Transit Gateway Attachment ", "transit_gateway_attachment"},
+	{"RedshiftNetworkInterface", "redshift_cluster"},
+	{"DMSNetworkInterface", "dms_replication_instance"},
+	{"arn:aws:ecs:", "ecs_task"},
+}
+
+//// TABLE DEFINITION
+
+func tableAwsNetworkExposure(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_network_exposure",
+		Description: "AWS Network Exposure",
+		List: &plugin.ListConfig{
+			Hydrate: listNetworkExposures,
+			Tags:    map[string]string{"service": "ec2", "action": "DescribeNetworkInterfaces"},
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "network_interface_id", Require: plugin.Optional},
+				{Name: "vpc_id", Require: plugin.Optional},
+				{Name: "resource_type", Require: plugin.Optional},
+			},
+		},
+		GetMatrixItemFunc: SupportedRegionMatrix(ec2v1.EndpointsID),
+		Columns: awsRegionalColumns([]*plugin.Column{
+			{
+				Name:        "network_interface_id",
+				Description: "The ID of the network interface.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "resource_type",
+				Description: "The type of the resource of the network interface, e.g. ec2_instance, application_load_balancer, rds_instance or lambda_function.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "resource_id",
+				Description: "The ID or name of the resource of the network interface, as far as it can be told from the network interface.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ResourceId").NullIfZero(),
+			},
+			{
+				Name:        "exposed",
+				Description: "True if the network interface is reachable from the internet, over IPv4 or IPv6.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "ipv4_exposed",
+				Description: "True if the network interface is reachable from 0.0.0.0/0.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "ipv6_exposed",
+				Description: "True if the network interface is reachable from ::/0.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "ipv4_exposed_ports",
+				Description: "The protocols and port ranges the network interface is reachable on from 0.0.0.0/0, with the security group rule allowing them.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "ipv6_exposed_ports",
+				Description: "The protocols and port ranges the network interface is reachable on from ::/0, with the security group rule allowing them.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "public_ips",
+				Description: "The public IPv4 addresses of the network interface, including Elastic IP addresses.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "ipv6_addresses",
+				Description: "The IPv6 addresses of the network interface.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "internet_gateway_id",
+				Description: "The ID of the internet gateway the default route of the route table of the subnet leads to.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("InternetGatewayId").NullIfZero(),
+			},
+			{
+				Name:        "network_acl_id",
+				Description: "The ID of the network ACL of the subnet.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("NetworkAclId").NullIfZero(),
+			},
+			{
+				Name:        "security_group_ids",
+				Description: "The IDs of the security groups of the network interface.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "interface_type",
+				Description: "The type of the network interface.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "description",
+				Description: "The description of the network interface.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "private_ip_address",
+				Description: "The primary private IPv4 address of the network interface.",
+				Type:        proto.ColumnType_IPADDR,
+			},
+			{
+				Name:        "vpc_id",
+				Description: "The ID of the VPC of the network interface.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "subnet_id",
+				Description: "The ID of the subnet of the network interface.",
+				Type:        proto.ColumnType_STRING,
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("NetworkInterfaceId"),
+			},
+		}),
+	}
+}
+
+type networkExposure struct {
+	NetworkInterfaceId string
+	ResourceType       string
+	ResourceId         string
+	Exposed            bool
+	Ipv4Exposed        bool
+	Ipv6Exposed        bool
+	Ipv4ExposedPorts   []networkExposurePort
+	Ipv6ExposedPorts   []networkExposurePort
+	PublicIps          []string
+	Ipv6Addresses      []string
+	InternetGatewayId  string
+	NetworkAclId       string
+	SecurityGroupIds   []string
+	InterfaceType      types.NetworkInterfaceType
+	Description        *string
+	PrivateIpAddress   *string
+	VpcId              *string
+	SubnetId           *string
+}
+
+// networkExposurePort is a protocol and port range a network interface is
+// reachable on. Ports are unset for protocols without ports, e.g. ICMP.
+type networkExposurePort struct {
+	Protocol            string
+	FromPort            *int32
+	ToPort              *int32
+	SecurityGroupRuleId *string
+}
+
+//// LIST FUNCTION
+
+func listNetworkExposures(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	network, err := getVpcNetwork(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, networkInterface := range network.NetworkInterfaces {
+		if d.EqualsQualString("network_interface_id") != "" && d.EqualsQualString("network_interface_id") != aws.ToString(networkInterface.NetworkInterfaceId) {
+			continue
+		}
+		if d.EqualsQualString("vpc_id") != "" && d.EqualsQualString("vpc_id") != aws.ToString(networkInterface.VpcId) {
+			continue
+		}
+
+		exposure := newNetworkExposure(network, networkInterface)
+		if d.EqualsQualString("resource_type") != "" && d.EqualsQualString("resource_type") != exposure.ResourceType {
+			continue
+		}
+		d.StreamListItem(ctx, exposure)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func newNetworkExposure(network *vpcNetwork, networkInterface types.NetworkInterface) *networkExposure {
+	exposure := &networkExposure{
+		NetworkInterfaceId: aws.ToString(networkInterface.NetworkInterfaceId),
+		InterfaceType:      networkInterface.InterfaceType,
+		Description:        networkInterface.Description,
+		PrivateIpAddress:   networkInterface.PrivateIpAddress,
+		VpcId:              networkInterface.VpcId,
+		SubnetId:           networkInterface.SubnetId,
+	}
+	exposure.ResourceType, exposure.ResourceId = networkInterfaceResource(networkInterface)
+	for _, group := range networkInterface.Groups {
+		exposure.SecurityGroupIds = append(exposure.SecurityGroupIds, aws.ToString(group.GroupId))
+	}
+	for _, addr := range networkInterfacePublicAddresses(networkInterface) {
+		exposure.PublicIps = append(exposure.PublicIps, addr.String())
+	}
+	for _, addr := range networkInterfaceAddresses(networkInterface) {
+		if addr.Is6() {
+			exposure.Ipv6Addresses = append(exposure.Ipv6Addresses, addr.String())
+		}
+	}
+
+	vpcId, subnetId := aws.ToString(networkInterface.VpcId), aws.ToString(networkInterface.SubnetId)
+	networkAcl := network.subnetNetworkAcl(subnetId)
+	if networkAcl != nil {
+		exposure.NetworkAclId = aws.ToString(networkAcl.NetworkAclId)
+	}
+	if network.vpcInternetGateway(vpcId) == nil || networkAcl == nil {
+		return exposure
+	}
+
+	// Responses to any address must be routed back to the internet gateway,
+	// i.e. by a default route
+	ipv4Anywhere, ipv6Anywhere := netip.MustParsePrefix("0.0.0.0/0"), netip.MustParsePrefix("::/0")
+	var ipv4Routed, ipv6Routed bool
+	if routeTable := network.subnetRouteTable(vpcId, subnetId); routeTable != nil {
+		for _, route := range routeTable.Routes {
+			if !strings.HasPrefix(aws.ToString(route.GatewayId), "igw-") || route.State == types.RouteStateBlackhole {
+				continue
+			}
+			prefixes := network.prefixes(route.DestinationPrefixListId, route.DestinationCidrBlock, route.DestinationIpv6CidrBlock)
+			if slices.Contains(prefixes, ipv4Anywhere) || slices.Contains(prefixes, ipv6Anywhere) {
+				exposure.InternetGatewayId = aws.ToString(route.GatewayId)
+			}
+			ipv4Routed = ipv4Routed || slices.Contains(prefixes, ipv4Anywhere)
+			ipv6Routed = ipv6Routed || slices.Contains(prefixes, ipv6Anywhere)
+		}
+	}
+
+	if ipv4Routed && len(exposure.PublicIps) > 0 {
+		exposure.Ipv4ExposedPorts = networkExposurePorts(network, networkInterface, networkAcl, ipv4Anywhere)
+		exposure.Ipv4Exposed = len(exposure.Ipv4ExposedPorts) > 0
+	}
+	if ipv6Routed && len(exposure.Ipv6Addresses) > 0 {
+		exposure.Ipv6ExposedPorts = networkExposurePorts(network, networkInterface, networkAcl, ipv6Anywhere)
+		exposure.Ipv6Exposed = len(exposure.Ipv6ExposedPorts) > 0
+	}
+	exposure.Exposed = exposure.Ipv4Exposed || exposure.Ipv6Exposed
+
+	return exposure
+}
+
+// networkExposurePorts returns the protocols and port ranges both the
+// security groups of a network interface and the network ACL of its subnet
+// allow from any address, i.e. from 0.0.0.0/0 or ::/0. The network ACL must
+// also allow the responses. Network and gateway load balancers without
+// security groups allow all traffic, so only the network ACL applies.
+func networkExposurePorts(network *vpcNetwork, networkInterface types.NetworkInterface, networkAcl *types.NetworkAcl, anywhere netip.Prefix) []networkExposurePort {
+	var rules []types.SecurityGroupRule
+	for _, group := range networkInterface.Groups {
+		rules = append(rules, network.SecurityGroupRules[aws.ToString(group.GroupId)]...)
+	}
+	if len(networkInterface.Groups) == 0 {
+		switch resourceType, _ := networkInterfaceResource(networkInterface); resourceType {
+		case "network_load_balancer", "gateway_load_balancer":
+			rules = []types.SecurityGroupRule{{
+				IsEgress:   aws.Bool(false),
+				IpProtocol: aws.String("-1"),
+				CidrIpv4:   aws.String("0.0.0.0/0"),
+				CidrIpv6:   aws.String("::/0"),
+			}}
+		}
+	}
+
+	var ports []networkExposurePort
+	for _, rule := range rules {
+		if aws.ToBool(rule.IsEgress) || !slices.Contains(network.prefixes(rule.PrefixListId, rule.CidrIpv4, rule.CidrIpv6), anywhere) {
+			continue
+		}
+
+		// Rules for all protocols are evaluated for TCP, UDP and ICMP
+		protocols := []string{vpcNetworkProtocol(aws.ToString(rule.IpProtocol))}
+		if protocols[0] == "-1" {
+			protocols = []string{"6", "17", "1"}
+			if anywhere.Addr().Is6() {
+				protocols[2] = "58"
+			}
+		}
+
+		for _, protocol := range protocols {
+			if !networkAclAllowsResponses(networkAcl, protocol, anywhere) {
+				continue
+			}
+			if protocol != "6" && protocol != "17" {
+				if networkAclAllowsAnywhere(networkAcl, false, protocol, anywhere) {
+					ports = append(ports, networkExposurePort{Protocol: networkExposureProtocolName(protocol), SecurityGroupRuleId: rule.SecurityGroupRuleId})
+				}
+				continue
+			}
+
+			fromPort, toPort := int32(0), int32(65535)
+			if rule.FromPort != nil && *rule.FromPort != -1 {
+				fromPort, toPort = aws.ToInt32(rule.FromPort), aws.ToInt32(rule.ToPort)
+			}
+			for _, portRange := range networkAclAllowedPortRanges(networkAcl, false, protocol, anywhere, fromPort, toPort) {
+				ports = append(ports, networkExposurePort{
+					Protocol:            networkExposureProtocolName(protocol),
+					FromPort:            aws.Int32(portRange[0]),
+					ToPort:              aws.Int32(portRange[1]),
+					SecurityGroupRuleId: rule.SecurityGroupRuleId,
+				})
+			}
+		}
+	}
+	return ports
+}
+
+// networkAclAllowedPortRanges returns the parts of a port range the inbound
+// or outbound entries of a network ACL for any address allow. Entries for
+// narrower CIDRs are ignored, as there are addresses outside of them.
+func networkAclAllowedPortRanges(networkAcl *types.NetworkAcl, egress bool, protocol string, anywhere netip.Prefix, fromPort int32, toPort int32) [][2]int32 {
+	return networkAclPortRanges(networkAcl, func(entry types.NetworkAclEntry) bool {
+		return networkAclEntryMatchesAnywhere(entry, egress, protocol, anywhere)
+	}, fromPort, toPort)
+}
+
+// networkAclAllowsResponses returns whether the outbound entries of a network
+// ACL for any address allow the responses to traffic of a protocol. Network
+// ACLs are stateless, and TCP and UDP responses are sent to the ephemeral port
+// of the client, which must be allowed for the whole ephemeral range.
+func networkAclAllowsResponses(networkAcl *types.NetworkAcl, protocol string, anywhere netip.Prefix) bool {
+	if protocol != "6" && protocol != "17" {
+		return networkAclAllowsAnywhere(networkAcl, true, protocol, anywhere)
+	}
+	allowed := networkAclAllowedPortRanges(networkAcl, true, protocol, anywhere, vpcNetworkEphemeralFromPort, vpcNetworkEphemeralToPort)
+	return portRangesCover(allowed, vpcNetworkEphemeralFromPort, vpcNetworkEphemeralToPort)
+}
+
+// networkAclAllowsAnywhere returns whether the inbound or outbound entries of
+// a network ACL for any address allow a protocol without ports
+func networkAclAllowsAnywhere(networkAcl *types.NetworkAcl, egress bool, protocol string, anywhere netip.Prefix) bool {
+	for _, entry := range networkAcl.Entries {
+		if networkAclEntryMatchesAnywhere(entry, egress, protocol, anywhere) {
+			return entry.RuleAction == types.RuleActionAllow
+		}
+	}
+	return false
+}
+
+func networkAclEntryMatchesAnywhere(entry types.NetworkAclEntry, egress bool, protocol string, anywhere netip.Prefix) bool {
+	if aws.ToBool(entry.Egress) != egress || !vpcNetworkProtocolMatches(aws.ToString(entry.Protocol), protocol) {
+		return false
+	}
+	cidr := entry.CidrBlock
+	if anywhere.Addr().Is6() {
+		cidr = entry.Ipv6CidrBlock
+	}
+	prefix, err := netip.ParsePrefix(aws.ToString(cidr))
+	return err == nil && prefix == anywhere
+}
+
+//// UTILITY FUNCTIONS
+
+// networkInterfaceResource returns the type and ID of the resource of a
+// network interface
+func networkInterfaceResource(networkInterface types.NetworkInterface) (string, string) {
+	if attachment := networkInterface.Attachment; attachment != nil && attachment.InstanceId != nil {
+		return "ec2_instance", aws.ToString(attachment.InstanceId)
+	}
+
+	description := aws.ToString(networkInterface.Description)
+	for _, resource := range networkInterfaceResourceTypes {
+		id, ok := strings.CutPrefix(description, resource.Prefix)
+		if !ok {
+			continue
+		}
+		switch resource.ResourceType {
+		case "lambda_function":
+			// The function name is followed by a dash and an ID
+			if i := len(id) - 37; i > 0 && id[i] == '-' {
+				id = id[:i]
+			}
+		case "efs_mount_target":
+			id, _, _ = strings.Cut(id, " ")
+		case "ecs_task":
+			id = description
+		}
+		return resource.ResourceType, id
+	}
+
+	if networkInterface.InterfaceType != types.NetworkInterfaceTypeInterface {
+		return string(networkInterface.InterfaceType), ""
+	}
+	return "network_interface", aws.ToString(networkInterface.NetworkInterfaceId)
+}
+
+func networkExposureProtocolName(protocol string) string {
+	switch protocol {
+	case "6":
+		return "tcp"
+	case "17":
+		return "udp"
+	case "1":
+		return "icmp"
+	case "58":
+		return "icmpv6"
+	}
+	return protocol
+}
@@ -0,0 +1,165 @@
+package aws
+
+import (
+	"net/netip"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+func TestNetworkAclAllowedPortRanges(t *testing.T) {
+	anywhere := netip.MustParsePrefix("0.0.0.0/0")
+	tests := []struct {
+		name     string
+		entries  []types.NetworkAclEntry
+		egress   bool
+		fromPort int32
+		toPort   int32
+		expected [][2]int32
+	}{
+		{
+			name:     "allow all",
+			entries:  nil,
+			fromPort: 0, toPort: 65535,
+			expected: [][2]int32{{0, 65535}},
+		},
+		{
+			name: "deny before allow",
+			entries: []types.NetworkAclEntry{
+				testNetworkAclEntry(90, false, "6", "0.0.0.0/0", 22, 22, types.RuleActionDeny),
+			},
+			fromPort: 0, toPort: 100,
+			expected: [][2]int32{{0, 21}, {23, 100}},
+		},
+		{
+			name: "narrower cidr ignored",
+			entries: []types.NetworkAclEntry{
+				testNetworkAclEntry(90, false, "6", "10.0.0.0/8", 0, 65535, types.RuleActionDeny),
+			},
+			fromPort: 80, toPort: 80,
+			expected: [][2]int32{{80, 80}},
+		},
+		{
+			name: "other protocol ignored",
+			entries: []types.NetworkAclEntry{
+				testNetworkAclEntry(90, false, "17", "0.0.0.0/0", 0, 65535, types.RuleActionDeny),
+			},
+			fromPort: 80, toPort: 80,
+			expected: [][2]int32{{80, 80}},
+		},
+		{
+			name: "earlier allow wins",
+			entries: []types.NetworkAclEntry{
+				testNetworkAclEntry(80, false, "6", "0.0.0.0/0", 443, 443, types.RuleActionAllow),
+				testNetworkAclEntry(90, false, "6", "0.0.0.0/0", 0, 65535, types.RuleActionDeny),
+			},
+			fromPort: 0, toPort: 65535,
+			expected: [][2]int32{{443, 443}},
+		},
+		{
+			name: "egress entries",
+			entries: []types.NetworkAclEntry{
+				testNetworkAclEntry(90, true, "6", "0.0.0.0/0", 32768, 65535, types.RuleActionAllow),
+				testNetworkAclEntry(95, true, "6", "0.0.0.0/0", 0, 65535, types.RuleActionDeny),
+			},
+			egress:   true,
+			fromPort: 1024, toPort: 65535,
+			expected: [][2]int32{{32768, 65535}},
+		},
+	}
+	for _, test := range tests {
+		networkAcl := testNetworkAcl("acl-1", nil, test.entries...)
+		allowed := networkAclAllowedPortRanges(&networkAcl, test.egress, "6", anywhere, test.fromPort, test.toPort)
+		if !reflect.DeepEqual(allowed, test.expected) {
+			t.Errorf("%s: networkAclAllowedPortRanges() = %v, expected %v", test.name, allowed, test.expected)
+		}
+	}
+}
+
+func TestNetworkAclAllowsResponses(t *testing.T) {
+	anywhere := netip.MustParsePrefix("0.0.0.0/0")
+	tests := []struct {
+		name     string
+		entries  []types.NetworkAclEntry
+		protocol string
+		expected bool
+	}{
+		{"allow all", nil, "6", true},
+		{"whole ephemeral range", []types.NetworkAclEntry{
+			testNetworkAclEntry(90, true, "6", "0.0.0.0/0", 1024, 65535, types.RuleActionAllow),
+			testNetworkAclEntry(95, true, "-1", "0.0.0.0/0", -1, -1, types.RuleActionDeny),
+		}, "6", true},
+		{"part of the ephemeral range", []types.NetworkAclEntry{
+			testNetworkAclEntry(90, true, "6", "0.0.0.0/0", 1024, 30000, types.RuleActionAllow),
+			testNetworkAclEntry(95, true, "-1", "0.0.0.0/0", -1, -1, types.RuleActionDeny),
+		}, "6", false},
+		{"icmp denied", []types.NetworkAclEntry{
+			testNetworkAclEntry(90, true, "1", "0.0.0.0/0", -1, -1, types.RuleActionDeny),
+		}, "1", false},
+	}
+	for _, test := range tests {
+		networkAcl := testNetworkAcl("acl-1", nil, test.entries...)
+		if allowed := networkAclAllowsResponses(&networkAcl, test.protocol, anywhere); allowed != test.expected {
+			t.Errorf("%s: networkAclAllowsResponses() = %v, expected %v", test.name, allowed, test.expected)
+		}
+	}
+}
+
+func TestNewNetworkExposure(t *testing.T) {
+	tests := []struct {
+		name     string
+		route    string
+		entries  []types.NetworkAclEntry
+		expected bool
+	}{
+		{"default route", "0.0.0.0/0", nil, true},
+		{"host route", "198.51.100.10/32", nil, false},
+		{"responses denied", "0.0.0.0/0", []types.NetworkAclEntry{
+			testNetworkAclEntry(90, true, "6", "0.0.0.0/0", 0, 1023, types.RuleActionAllow),
+			testNetworkAclEntry(95, true, "-1", "0.0.0.0/0", -1, -1, types.RuleActionDeny),
+		}, false},
+	}
+	for _, test := range tests {
+		networkInterface := testNetworkInterface("eni-web", "vpc-a", "subnet-a2", "10.0.2.30", "sg-web")
+		networkInterface.PrivateIpAddresses[0].Association = &types.NetworkInterfaceAssociation{PublicIp: aws.String("203.0.113.30")}
+
+		network := testVpcNetwork()
+		network.RouteTables[1] = testRouteTable("rtb-a2", "vpc-a", "subnet-a2",
+			testRoute("10.0.0.0/16", "local"),
+			testRoute(test.route, "igw-1"),
+		)
+		network.NetworkAcls[0] = testNetworkAcl("acl-a", []string{"subnet-a1", "subnet-a2"}, test.entries...)
+		network.SecurityGroupRules["sg-web"] = []types.SecurityGroupRule{
+			{GroupId: aws.String("sg-web"), SecurityGroupRuleId: aws.String("sgr-web-https"), IsEgress: aws.Bool(false), IpProtocol: aws.String("tcp"), FromPort: aws.Int32(443), ToPort: aws.Int32(443), CidrIpv4: aws.String("0.0.0.0/0")},
+		}
+
+		exposure := newNetworkExposure(network, networkInterface)
+		if exposure.Ipv4Exposed != test.expected {
+			t.Errorf("%s: ipv4_exposed = %v, expected %v", test.name, exposure.Ipv4Exposed, test.expected)
+		}
+	}
+
+	// Network load balancers without security groups are only filtered by the
+	// network ACL
+	networkInterface := testNetworkInterface("eni-nlb", "vpc-a", "subnet-a2", "10.0.2.40")
+	networkInterface.Description = aws.String("ELB net/web/0123456789abcdef")
+	networkInterface.PrivateIpAddresses[0].Association = &types.NetworkInterfaceAssociation{PublicIp: aws.String("203.0.113.40")}
+
+	network := testVpcNetwork()
+	network.RouteTables[1] = testRouteTable("rtb-a2", "vpc-a", "subnet-a2",
+		testRoute("10.0.0.0/16", "local"),
+		testRoute("0.0.0.0/0", "igw-1"),
+	)
+	network.NetworkAcls[0] = testNetworkAcl("acl-a", []string{"subnet-a1", "subnet-a2"},
+		testNetworkAclEntry(90, false, "6", "0.0.0.0/0", 443, 443, types.RuleActionAllow),
+		testNetworkAclEntry(95, false, "-1", "0.0.0.0/0", -1, -1, types.RuleActionDeny),
+	)
+
+	exposure := newNetworkExposure(network, networkInterface)
+	expected := []networkExposurePort{{Protocol: "tcp", FromPort: aws.Int32(443), ToPort: aws.Int32(443)}}
+	if exposure.ResourceType != "network_load_balancer" || !exposure.Ipv4Exposed || !reflect.DeepEqual(exposure.Ipv4ExposedPorts, expected) {
+		t.Errorf("network load balancer without security groups: resource_type = %s, ipv4_exposed = %v, ipv4_exposed_ports = %v", exposure.ResourceType, exposure.Ipv4Exposed, exposure.Ipv4ExposedPorts)
+	}
+}
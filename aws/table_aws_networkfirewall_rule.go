@@ -0,0 +1,236 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/networkfirewall"
+	"github.com/aws/aws-sdk-go-v2/service/networkfirewall/types"
+
+	networkfirewallv1 "github.com/aws/aws-sdk-go/service/networkfirewall"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsNetworkFirewallRule(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_networkfirewall_rule",
+		Description: "AWS Network Firewall Rule",
+		List: &plugin.ListConfig{
+			ParentHydrate: listNetworkFirewallRuleGroups,
+			Hydrate:       listNetworkFirewallRules,
+			Tags:          map[string]string{"service": "network-firewall", "action": "DescribeRuleGroup"},
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "rule_group_name", Require: plugin.Optional},
+				{Name: "rule_group_arn", Require: plugin.Optional},
+			},
+		},
+		GetMatrixItemFunc: SupportedRegionMatrix(networkfirewallv1.EndpointsID),
+		Columns: awsRegionalColumns([]*plugin.Column{
+			{
+				Name:        "rule_group_name",
+				Description: "The name of the rule group that contains the rule.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "rule_group_arn",
+				Description: "The Amazon Resource Name (ARN) of the rule group that contains the rule.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "rule_group_type",
+				Description: "Indicates whether the rule group is stateless or stateful.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "rule_type",
+				Description: "How the rule is defined in the rule group. Possible values are: stateless, stateful, suricata, domain_list.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "rule_number",
+				Description: "The position of the rule in the rule group, starting at 1.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "priority",
+				Description: "The priority of a stateless rule. Network Firewall evaluates the stateless rules of a rule group starting with the lowest priority setting.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "action",
+				Description: "The action to take on a packet that matches the rule. Stateless rules use the standard action, such as aws:pass, aws:drop or aws:forward_to_sfe. Stateful rules use PASS, DROP, ALERT or REJECT.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Action").NullIfZero(),
+			},
+			{
+				Name:        "actions",
+				Description: "The standard and custom actions of a stateless rule.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "protocol",
+				Description: "The protocol to inspect for. Stateless rules list the matched protocol numbers.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Protocol").NullIfZero(),
+			},
+			{
+				Name:        "source",
+				Description: "The source IP addresses and address ranges to inspect for, in CIDR notation, as a variable such as $HOME_NET or as ANY.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Source").NullIfZero(),
+			},
+			{
+				Name:        "source_port",
+				Description: "The source ports and port ranges to inspect for, or ANY.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("SourcePort").NullIfZero(),
+			},
+			{
+				Name:        "direction",
+				Description: "The direction of traffic flow to inspect. FORWARD matches traffic from the source to the destination only, ANY matches both directions.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Direction").NullIfZero(),
+			},
+			{
+				Name:        "destination",
+				Description: "The destination IP addresses and address ranges to inspect for, in CIDR notation, as a variable such as $EXTERNAL_NET or as ANY.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Destination").NullIfZero(),
+			},
+			{
+				Name:        "destination_port",
+				Description: "The destination ports and port ranges to inspect for, or ANY.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("DestinationPort").NullIfZero(),
+			},
+			{
+				Name:        "sid",
+				Description: "The signature ID of a stateful rule.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "msg",
+				Description: "The message of a stateful rule.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "options",
+				Description: "The keywords and settings of the options of a stateful rule.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "match_attributes",
+				Description: "The criteria that a stateless rule uses to inspect packets.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "domain",
+				Description: "The domain of a domain list rule group.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "target_types",
+				Description: "The protocols a domain list rule group inspects for the domain. Possible values are: TLS_SNI, HTTP_HOST.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "generated_rules_type",
+				Description: "Whether the domain list allows or denies access to the domains. Possible values are: ALLOWLIST, DENYLIST.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("GeneratedRulesType").NullIfZero(),
+			},
+			{
+				Name:        "rule_string",
+				Description: "The Suricata compatible rule string the rule was parsed from.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "parse_error",
+				Description: "The reason the Suricata compatible rule string could not be parsed, if any.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "overly_broad_pass",
+				Description: "True if the rule passes traffic from any source to any destination and port without further conditions.",
+				Type:        proto.ColumnType_BOOL,
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.From(networkFirewallRuleTitle),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listNetworkFirewallRules(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	ruleGroup := h.Item.(types.RuleGroupMetadata)
+
+	// Skip the rule groups that do not match the provided quals
+	if d.EqualsQualString("rule_group_name") != "" && d.EqualsQualString("rule_group_name") != aws.ToString(ruleGroup.Name) {
+		return nil, nil
+	}
+	if d.EqualsQualString("rule_group_arn") != "" && d.EqualsQualString("rule_group_arn") != aws.ToString(ruleGroup.Arn) {
+		return nil, nil
+	}
+
+	// apply rate limiting
+	d.WaitForListRateLimit(ctx)
+
+	data, err := getNetworkFirewallRuleGroup(ctx, d, h)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_networkfirewall_rule.listNetworkFirewallRules", "api_error", err)
+		return nil, err
+	}
+	if data == nil {
+		return nil, nil
+	}
+	output := data.(*networkfirewall.DescribeRuleGroupOutput)
+	if output.RuleGroup == nil {
+		return nil, nil
+	}
+
+	var ruleGroupType types.RuleGroupType
+	if output.RuleGroupResponse != nil {
+		ruleGroupType = output.RuleGroupResponse.Type
+	}
+
+	for _, rule := range networkFirewallRulesOf(output.RuleGroup.RulesSource) {
+		rule.RuleGroupName = ruleGroup.Name
+		rule.RuleGroupArn = ruleGroup.Arn
+		rule.RuleGroupType = ruleGroupType
+		d.StreamListItem(ctx, rule)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+
+	return nil, nil
+}
+
+//// TRANSFORM FUNCTIONS
+
+func networkFirewallRuleTitle(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	rule := d.HydrateItem.(networkFirewallRule)
+
+	switch {
+	case rule.Sid != nil:
+		return fmt.Sprintf("%s sid %d", aws.ToString(rule.RuleGroupName), *rule.Sid), nil
+	case rule.Domain != nil:
+		return fmt.Sprintf("%s %s", aws.ToString(rule.RuleGroupName), *rule.Domain), nil
+	}
+	return fmt.Sprintf("%s rule %d", aws.ToString(rule.RuleGroupName), rule.RuleNumber), nil
+}
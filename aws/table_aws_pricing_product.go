@@ -56,6 +56,7 @@ func tableAwsPricingProduct(_ context.Context) *plugin.Table {
 
 type Product struct {
 	_             struct{} `type:"structure"`
+	Sku           *string
 	ProductFamily *string
 	Attributes    map[string]*string
 }
@@ -0,0 +1,452 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/pricing"
+	"github.com/aws/aws-sdk-go-v2/service/pricing/types"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// Hours per month used by the Pricing API for monthly prices
+const pricingHoursPerMonth = 730
+
+// Operating system and pre-installed software of EC2 instance platform details
+var ec2PricingPlatforms = map[string][2]string{
+	"Linux/UNIX":                         {"Linux", "NA"},
+	"Red Hat Enterprise Linux":           {"RHEL", "NA"},
+	"Red Hat Enterprise Linux with HA":   {"Red Hat Enterprise Linux with HA", "NA"},
+	"SUSE Linux":                         {"SUSE", "NA"},
+	"Ubuntu Pro":                         {"Ubuntu Pro", "NA"},
+	"Windows":                            {"Windows", "NA"},
+	"Windows with SQL Server Standard":   {"Windows", "SQL Std"},
+	"Windows with SQL Server Enterprise": {"Windows", "SQL Ent"},
+	"Windows with SQL Server Web":        {"Windows", "SQL Web"},
+	"Linux with SQL Server Standard":     {"Linux", "SQL Std"},
+	"Linux with SQL Server Enterprise":   {"Linux", "SQL Ent"},
+	"Linux with SQL Server Web":          {"Linux", "SQL Web"},
+}
+
+// rdsPricingEngine holds the product attributes of the price of an RDS engine
+type rdsPricingEngine struct {
+	DatabaseEngine  string
+	DatabaseEdition string
+	LicenseModel    string
+
+	// The deployment option of a Multi-AZ DB instance, which is empty if the
+	// engine has no Multi-AZ deployments. SQL Server Multi-AZ deployments are
+	// priced as mirrored instances, and Aurora replicas are instances of their
+	// own.
+	MultiAZDeploymentOption string
+}
+
+// Product attributes of RDS engines. Oracle Enterprise Edition is only offered
+// with a license of your own, and SQL Server Express and Web editions have no
+// Multi-AZ deployments.
+var rdsPricingEngines = map[string]rdsPricingEngine{
+	"aurora":            {"Aurora MySQL", "", "", "Single-AZ"},
+	"aurora-mysql":      {"Aurora MySQL", "", "", "Single-AZ"},
+	"aurora-postgresql": {"Aurora PostgreSQL", "", "", "Single-AZ"},
+	"mariadb":           {"MariaDB", "", "", "Multi-AZ"},
+	"mysql":             {"MySQL", "", "", "Multi-AZ"},
+	"postgres":          {"PostgreSQL", "", "", "Multi-AZ"},
+	"oracle-ee":         {"Oracle", "Enterprise", "Bring your own license", "Multi-AZ"},
+	"oracle-se2":        {"Oracle", "Standard Two", "License included", "Multi-AZ"},
+	"sqlserver-ee":      {"SQL Server", "Enterprise", "License included", "Multi-AZ (SQL Server Mirror)"},
+	"sqlserver-se":      {"SQL Server", "Standard", "License included", "Multi-AZ (SQL Server Mirror)"},
+	"sqlserver-ex":      {"SQL Server", "Express", "License included", ""},
+	"sqlserver-web":     {"SQL Server", "Web", "License included", ""},
+}
+
+//// TABLE DEFINITION
+
+func tableAwsResourcePriceEstimate(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_resource_price_estimate",
+		Description: "AWS Resource Price Estimate",
+		List: &plugin.ListConfig{
+			Hydrate: listResourcePriceEstimates,
+			Tags:    map[string]string{"service": "pricing", "action": "GetProducts"},
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "resource_type", Require: plugin.Required},
+				{Name: "region", Require: plugin.Required},
+				{Name: "instance_type", Require: plugin.Optional},
+				{Name: "platform", Require: plugin.Optional},
+				{Name: "tenancy", Require: plugin.Optional},
+				{Name: "engine", Require: plugin.Optional},
+				{Name: "multi_az", Require: plugin.Optional},
+				{Name: "volume_type", Require: plugin.Optional},
+				{Name: "volume_size", Require: plugin.Optional},
+			},
+		},
+		Columns: awsAccountColumns([]*plugin.Column{
+			{
+				Name:        "resource_type",
+				Description: "The table of the resource to estimate the price of. Possible values are: aws_ec2_instance|aws_ebs_volume|aws_rds_db_instance|aws_elasticache_cluster|aws_vpc_nat_gateway|aws_ec2_application_load_balancer.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("resource_type"),
+			},
+			{
+				Name:        "region",
+				Description: "The region of the resource.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("region"),
+			},
+			{
+				Name:        "instance_type",
+				Description: "The instance type of an EC2 instance, the class of an RDS DB instance, or the node type of an ElastiCache cluster.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("instance_type"),
+			},
+			{
+				Name:        "platform",
+				Description: "The platform details of an EC2 instance, e.g. Linux/UNIX or Windows. Defaults to Linux/UNIX.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("platform"),
+			},
+			{
+				Name:        "tenancy",
+				Description: "The placement tenancy of an EC2 instance. Possible values are: default|dedicated|host. Defaults to default.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("tenancy"),
+			},
+			{
+				Name:        "engine",
+				Description: "The engine of an RDS DB instance, e.g. postgres, or of an ElastiCache cluster, e.g. redis.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("engine"),
+			},
+			{
+				Name:        "multi_az",
+				Description: "Whether an RDS DB instance is a Multi-AZ deployment. Defaults to false.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromQual("multi_az"),
+			},
+			{
+				Name:        "volume_type",
+				Description: "The volume type of an EBS volume, e.g. gp3.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("volume_type"),
+			},
+			{
+				Name:        "volume_size",
+				Description: "The size of an EBS volume, in GiB. Without a size, the prices of an EBS volume are per GiB.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromQual("volume_size"),
+			},
+			{
+				Name:        "hourly_price",
+				Description: "The estimated on-demand price of the resource per hour.",
+				Type:        proto.ColumnType_DOUBLE,
+			},
+			{
+				Name:        "monthly_price",
+				Description: "The estimated on-demand price of the resource per month, of 730 hours.",
+				Type:        proto.ColumnType_DOUBLE,
+			},
+			{
+				Name:        "price_per_unit",
+				Description: "The on-demand list price per unit.",
+				Type:        proto.ColumnType_DOUBLE,
+			},
+			{
+				Name:        "unit",
+				Description: "The unit of the list price, e.g. Hrs or GB-Mo.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "currency",
+				Description: "The currency of the prices.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "description",
+				Description: "The description of the list price.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("PriceDimension.Description"),
+			},
+			{
+				Name:        "rate_code",
+				Description: "The rate code of the list price.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("PriceDimension.RateCode"),
+			},
+			{
+				Name:        "sku",
+				Description: "The SKU of the product.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Product.Sku"),
+			},
+			{
+				Name:        "effective_date",
+				Description: "The date the list price is effective from.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "attributes",
+				Description: "The attributes of the product.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Product.Attributes"),
+			},
+		}),
+	}
+}
+
+type resourcePriceEstimate struct {
+	Product        *Product
+	PriceDimension *PriceDimension
+	EffectiveDate  *time.Time
+	PricePerUnit   float64
+	Unit           string
+	Currency       string
+	HourlyPrice    float64
+	MonthlyPrice   float64
+}
+
+// resourcePriceQuery is the product filter and price unit of a resource
+type resourcePriceQuery struct {
+	ServiceCode string
+	Filters     map[string]string
+	Unit        string
+}
+
+//// LIST FUNCTION
+
+func listResourcePriceEstimates(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	query, err := buildResourcePriceQuery(d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_resource_price_estimate.listResourcePriceEstimates", "invalid_input", err)
+		return nil, err
+	}
+
+	priceLists, err := getResourcePriceLists(ctx, d, query)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_resource_price_estimate.listResourcePriceEstimates", "api_error", err)
+		return nil, err
+	}
+
+	var volumeSize int64
+	if d.EqualsQuals["volume_size"] != nil {
+		volumeSize = d.EqualsQuals["volume_size"].GetInt64Value()
+	}
+
+	for _, priceList := range priceLists {
+		onDemand := priceList.Terms["OnDemand"]
+		if onDemand == nil {
+			continue
+		}
+		for _, offer := range *onDemand {
+			for _, priceDimension := range offer.PriceDimensions {
+				if aws.ToString(priceDimension.Unit) != query.Unit {
+					continue
+				}
+				for currency, price := range priceDimension.PricePerUnit {
+					pricePerUnit, err := strconv.ParseFloat(aws.ToString(price), 64)
+					if err != nil {
+						plugin.Logger(ctx).Error("aws_resource_price_estimate.listResourcePriceEstimates", "parse_error", err, "price", aws.ToString(price))
+						return nil, err
+					}
+
+					item := &resourcePriceEstimate{
+						Product:        priceList.Product,
+						PriceDimension: priceDimension,
+						EffectiveDate:  offer.EffectiveDate,
+						PricePerUnit:   pricePerUnit,
+						Unit:           query.Unit,
+						Currency:       currency,
+						HourlyPrice:    pricePerUnit,
+						MonthlyPrice:   pricePerUnit * pricingHoursPerMonth,
+					}
+					// Storage is priced per GB-month
+					if query.Unit == "GB-Mo" {
+						item.MonthlyPrice = pricePerUnit
+						if volumeSize > 0 {
+							item.MonthlyPrice *= float64(volumeSize)
+						}
+						item.HourlyPrice = item.MonthlyPrice / pricingHoursPerMonth
+					}
+					d.StreamListItem(ctx, item)
+
+					// Context may get cancelled due to manual cancellation or if the limit has been reached
+					if d.RowsRemaining(ctx) == 0 {
+						return nil, nil
+					}
+				}
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+//// UTILITY FUNCTIONS
+
+// buildResourcePriceQuery maps the attributes of a resource to the product
+// filter of its on-demand price
+func buildResourcePriceQuery(d *plugin.QueryData) (*resourcePriceQuery, error) {
+	resourceType := d.EqualsQualString("resource_type")
+	instanceType := d.EqualsQualString("instance_type")
+	engine := strings.ToLower(d.EqualsQualString("engine"))
+
+	query := &resourcePriceQuery{
+		ServiceCode: "AmazonEC2",
+		Filters:     map[string]string{"regionCode": d.EqualsQualString("region")},
+		Unit:        "Hrs",
+	}
+
+	switch resourceType {
+	case "aws_ec2_instance":
+		if instanceType == "" {
+			return nil, fmt.Errorf("instance_type is required for %s", resourceType)
+		}
+		platform := d.EqualsQualString("platform")
+		if platform == "" {
+			platform = "Linux/UNIX"
+		}
+		platformAttributes, ok := ec2PricingPlatforms[platform]
+		if !ok {
+			return nil, fmt.Errorf("unsupported platform %s", platform)
+		}
+		tenancy := map[string]string{"": "Shared", "default": "Shared", "dedicated": "Dedicated", "host": "Host"}[d.EqualsQualString("tenancy")]
+		if tenancy == "" {
+			return nil, fmt.Errorf("unsupported tenancy %s", d.EqualsQualString("tenancy"))
+		}
+		query.Filters["productFamily"] = "Compute Instance"
+		query.Filters["instanceType"] = instanceType
+		query.Filters["operatingSystem"] = platformAttributes[0]
+		query.Filters["preInstalledSw"] = platformAttributes[1]
+		query.Filters["tenancy"] = tenancy
+		query.Filters["capacitystatus"] = "Used"
+		query.Filters["licenseModel"] = "No License required"
+
+	case "aws_ebs_volume":
+		volumeType := d.EqualsQualString("volume_type")
+		if volumeType == "" {
+			return nil, fmt.Errorf("volume_type is required for %s", resourceType)
+		}
+		query.Filters["productFamily"] = "Storage"
+		query.Filters["volumeApiName"] = volumeType
+		query.Unit = "GB-Mo"
+
+	case "aws_rds_db_instance":
+		databaseEngine, ok := rdsPricingEngines[engine]
+		if instanceType == "" || !ok {
+			return nil, fmt.Errorf("instance_type and a supported engine are required for %s", resourceType)
+		}
+		deploymentOption := "Single-AZ"
+		if d.EqualsQuals["multi_az"].GetBoolValue() {
+			if databaseEngine.MultiAZDeploymentOption == "" {
+				return nil, fmt.Errorf("engine %s has no Multi-AZ deployments", engine)
+			}
+			deploymentOption = databaseEngine.MultiAZDeploymentOption
+		}
+		query.ServiceCode = "AmazonRDS"
+		query.Filters["productFamily"] = "Database Instance"
+		query.Filters["instanceType"] = instanceType
+		query.Filters["databaseEngine"] = databaseEngine.DatabaseEngine
+		query.Filters["deploymentOption"] = deploymentOption
+		if databaseEngine.DatabaseEdition != "" {
+			query.Filters["databaseEdition"] = databaseEngine.DatabaseEdition
+		}
+		if databaseEngine.LicenseModel != "" {
+			query.Filters["licenseModel"] = databaseEngine.LicenseModel
+		}
+
+	case "aws_elasticache_cluster":
+		cacheEngine := map[string]string{"redis": "Redis", "memcached": "Memcached", "valkey": "Valkey"}[engine]
+		if instanceType == "" || cacheEngine == "" {
+			return nil, fmt.Errorf("instance_type and a supported engine are required for %s", resourceType)
+		}
+		query.ServiceCode = "AmazonElastiCache"
+		query.Filters["productFamily"] = "Cache Instance"
+		query.Filters["instanceType"] = instanceType
+		query.Filters["cacheEngine"] = cacheEngine
+
+	case "aws_vpc_nat_gateway":
+		query.Filters["productFamily"] = "NAT Gateway"
+
+	case "aws_ec2_application_load_balancer":
+		query.ServiceCode = "AWSELB"
+		query.Filters["productFamily"] = "Load Balancer-Application"
+
+	default:
+		return nil, fmt.Errorf("unsupported resource type %s", resourceType)
+	}
+
+	return query, nil
+}
+
+// getResourcePriceLists returns the products of a query, cached per query as
+// the price catalogue rarely changes and resources share a few price lists
+func getResourcePriceLists(ctx context.Context, d *plugin.QueryData, query *resourcePriceQuery) ([]PriceList, error) {
+	fields := make([]string, 0, len(query.Filters))
+	for field := range query.Filters {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	cacheKey := "getResourcePriceLists-" + query.ServiceCode
+	filters := make([]types.Filter, 0, len(fields))
+	for _, field := range fields {
+		cacheKey += "-" + field + "=" + query.Filters[field]
+		filters = append(filters, types.Filter{
+			Field: aws.String(field),
+			Type:  types.FilterTypeTermMatch,
+			Value: aws.String(query.Filters[field]),
+		})
+	}
+
+	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
+		return cachedData.([]PriceList), nil
+	}
+
+	svc, err := PricingClient(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+	if svc == nil {
+		// Unsupported region, return no data
+		return nil, nil
+	}
+
+	input := &pricing.GetProductsInput{
+		ServiceCode:   aws.String(query.ServiceCode),
+		FormatVersion: aws.String("aws_v1"),
+		Filters:       filters,
+	}
+	paginator := pricing.NewGetProductsPaginator(svc, input, func(o *pricing.GetProductsPaginatorOptions) {
+		o.StopOnDuplicateToken = true
+	})
+
+	var priceLists []PriceList
+	for paginator.HasMorePages() {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, priceList := range output.PriceList {
+			var priceListObject PriceList
+			if err := json.Unmarshal([]byte(priceList), &priceListObject); err != nil {
+				return nil, err
+			}
+			priceLists = append(priceLists, priceListObject)
+		}
+	}
+
+	d.ConnectionManager.Cache.Set(cacheKey, priceLists)
+	return priceLists, nil
+}
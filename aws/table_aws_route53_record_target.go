@@ -0,0 +1,318 @@
+package aws
+
+import (
+	"context"
+	"net/netip"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	route53Types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsRoute53RecordTarget(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_route53_record_target",
+		Description: "AWS Route53 Record Target",
+		List: &plugin.ListConfig{
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "zone_id", Require: plugin.Optional},
+			},
+			ParentHydrate: listHostedZones,
+			Hydrate:       listRoute53RecordTargets,
+			Tags:          map[string]string{"service": "route53", "action": "ListResourceRecordSets"},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"NoSuchHostedZone"}),
+			},
+		},
+		Columns: awsGlobalRegionColumns([]*plugin.Column{
+			{
+				Name:        "name",
+				Description: "The name of the record.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "zone_id",
+				Description: "The ID of the hosted zone of the record.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "private_zone",
+				Description: "True if the hosted zone of the record is private.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "type",
+				Description: "The record type.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "set_identifier",
+				Description: "Unique identifier to differentiate records with routing policies from one another.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "alias",
+				Description: "True if the record is an alias record.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "value",
+				Description: "The value of the record the row is of, or the DNS name of the alias target for alias records.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "target_type",
+				Description: "The kind of target the value points at. Possible values are: eip|elb|cloudfront|s3_website|api_gateway_domain|api_gateway|elastic_beanstalk|route53_record for resolved targets, ip for addresses of no elastic IP of the account, aws for other AWS host names, external for host names outside AWS.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "target_region",
+				Description: "The AWS Region of the target, for regional targets.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("TargetRegion").NullIfZero(),
+			},
+			{
+				Name:        "resource_id",
+				Description: "The ID of the resource of the account the value resolves to: the allocation ID of the elastic IP, the ARN or name of the load balancer, the ID of the distribution, the name of the bucket or the custom domain name, the ID of the API or the environment.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ResourceId").NullIfZero(),
+			},
+			{
+				Name:        "resource_exists",
+				Description: "True if the target is a resource of the account. Null if the target is not of a kind the table resolves, is in a region the connection does not query, or the resources of its kind could not be listed, e.g. as access is denied.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "dangling",
+				Description: "True if the value points at an AWS resource that does not exist in the account, which anyone creating the resource could take over.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Name"),
+			},
+		}),
+	}
+}
+
+type route53RecordTarget struct {
+	Name           *string
+	ZoneId         string
+	PrivateZone    bool
+	Type           route53Types.RRType
+	SetIdentifier  *string
+	Alias          bool
+	Value          string
+	TargetType     string
+	TargetRegion   string
+	ResourceId     string
+	ResourceExists *bool
+	Dangling       *bool
+}
+
+//// LIST FUNCTION
+
+func listRoute53RecordTargets(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	zone := h.Item.(HostedZoneResult)
+	hostedZoneID := strings.Split(*zone.Id, "/")[2]
+
+	// check if the provided zone_id is not matching with the parentHydrate
+	if d.EqualsQualString("zone_id") != "" && d.EqualsQualString("zone_id") != hostedZoneID {
+		return nil, nil
+	}
+
+	// Create session
+	svc, err := Route53Client(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_route53_record_target.listRoute53RecordTargets", "client_error", err)
+		return nil, err
+	}
+
+	regions, err := listQueryRegionsForConnection(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_route53_record_target.listRoute53RecordTargets", "regions_error", err)
+		return nil, err
+	}
+
+	// Alias records can point at other records of the zone, so all the
+	// records of the zone are needed before resolving any
+	var records []route53Types.ResourceRecordSet
+	input := &route53.ListResourceRecordSetsInput{
+		HostedZoneId: aws.String(hostedZoneID),
+		MaxItems:     aws.Int32(300),
+	}
+	for {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		op, err := svc.ListResourceRecordSets(ctx, input)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_route53_record_target.listRoute53RecordTargets", "api_error", err)
+			return nil, err
+		}
+		records = append(records, op.ResourceRecordSets...)
+
+		if !op.IsTruncated {
+			break
+		}
+		input.StartRecordName = op.NextRecordName
+		input.StartRecordType = op.NextRecordType
+		input.StartRecordIdentifier = op.NextRecordIdentifier
+	}
+
+	recordNames := map[string]bool{}
+	for _, record := range records {
+		recordNames[route53RecordTargetName(aws.ToString(record.Name))] = true
+	}
+
+	for _, record := range records {
+		item := route53RecordTarget{
+			Name:          record.Name,
+			ZoneId:        hostedZoneID,
+			Type:          record.Type,
+			SetIdentifier: record.SetIdentifier,
+		}
+		if zone.Config != nil {
+			item.PrivateZone = zone.Config.PrivateZone
+		}
+
+		var targets []route53RecordTarget
+		if record.AliasTarget != nil {
+			target := item
+			target.Alias = true
+			target.Value = aws.ToString(record.AliasTarget.DNSName)
+			if aws.ToString(record.AliasTarget.HostedZoneId) == hostedZoneID {
+				target.TargetType = "route53_record"
+				target.ResourceExists = aws.Bool(recordNames[route53RecordTargetName(target.Value)])
+				target.Dangling = aws.Bool(!*target.ResourceExists)
+			} else if err := resolveRoute53RecordTarget(ctx, d, regions, &target); err != nil {
+				return nil, err
+			}
+			targets = append(targets, target)
+		} else if record.Type == route53Types.RRTypeA || record.Type == route53Types.RRTypeAaaa || record.Type == route53Types.RRTypeCname {
+			for _, resourceRecord := range record.ResourceRecords {
+				target := item
+				target.Value = aws.ToString(resourceRecord.Value)
+				if err := resolveRoute53RecordTarget(ctx, d, regions, &target); err != nil {
+					return nil, err
+				}
+				targets = append(targets, target)
+			}
+		}
+
+		for _, target := range targets {
+			d.StreamListItem(ctx, target)
+
+			// Context may get cancelled due to manual cancellation or if the limit has been reached
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// resolveRoute53RecordTarget resolves the value of a record to the resource
+// of the account it points at, if any
+func resolveRoute53RecordTarget(ctx context.Context, d *plugin.QueryData, regions []string, target *route53RecordTarget) error {
+	if addr, err := netip.ParseAddr(target.Value); err == nil {
+		// Addresses of no elastic IP may be of anything, in or outside AWS
+		target.TargetType = "ip"
+		if !addr.Is4() {
+			return nil
+		}
+		for _, region := range regions {
+			resources, err := route53TargetResources(ctx, d, route53TargetElasticIp, region)
+			if err != nil {
+				return err
+			}
+			if id, ok := resources[addr.String()]; ok {
+				target.TargetType = route53TargetElasticIp
+				target.TargetRegion = region
+				target.ResourceId = id
+				target.ResourceExists = aws.Bool(true)
+				target.Dangling = aws.Bool(false)
+				return nil
+			}
+		}
+		return nil
+	}
+
+	endpoint := route53TargetEndpointOf(target.Value)
+	if endpoint == nil {
+		target.TargetType = "external"
+		return nil
+	}
+	if endpoint.Type == "" {
+		target.TargetType = "aws"
+		return nil
+	}
+	target.TargetType = endpoint.Type
+	target.TargetRegion = endpoint.Region
+
+	// Alias targets of website buckets are the website endpoint of the
+	// region, the bucket being named after the record
+	key := endpoint.Key
+	if endpoint.Type == route53TargetS3Website && key == "" {
+		key = route53RecordTargetName(aws.ToString(target.Name))
+	}
+
+	// Distributions and buckets are global, other resources can only be
+	// looked up in the regions the connection queries
+	region := endpoint.Region
+	if endpoint.Type == route53TargetCloudFront || endpoint.Type == route53TargetS3Website {
+		region = ""
+	} else if !slices.Contains(regions, region) {
+		return nil
+	}
+
+	resources, err := route53TargetResources(ctx, d, endpoint.Type, region)
+	if err != nil {
+		return err
+	}
+	id, ok := resources[key]
+	known := resources != nil
+
+	// Edge-optimized API Gateway custom domain names are served by
+	// distributions that are not of the account
+	if !ok && endpoint.Type == route53TargetCloudFront {
+		for _, region := range regions {
+			domains, err := route53TargetResources(ctx, d, route53TargetApiGatewayDomain, region)
+			if err != nil {
+				return err
+			}
+			known = known && domains != nil
+			if id, ok = domains[key]; ok {
+				target.TargetType = route53TargetApiGatewayDomain
+				target.TargetRegion = region
+				break
+			}
+		}
+	}
+
+	// Whether a target that wasn't found exists is unknown if the resources
+	// it could be couldn't all be listed
+	if !ok && !known {
+		return nil
+	}
+	target.ResourceId = id
+	target.ResourceExists = aws.Bool(ok)
+	target.Dangling = aws.Bool(!ok)
+	return nil
+}
+
+// route53RecordTargetName normalizes a DNS name for comparison
+func route53RecordTargetName(name string) string {
+	return strings.TrimSuffix(strings.ToLower(name), ".")
+}
@@ -0,0 +1,183 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53resolver"
+	"github.com/aws/aws-sdk-go-v2/service/route53resolver/types"
+
+	route53resolverv1 "github.com/aws/aws-sdk-go/service/route53resolver"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsRoute53ResolverFirewallRule(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_route53_resolver_firewall_rule",
+		Description: "AWS Route53 Resolver DNS Firewall Rule",
+		List: &plugin.ListConfig{
+			ParentHydrate: listRoute53ResolverFirewallRuleGroups,
+			Hydrate:       listRoute53ResolverFirewallRules,
+			Tags:          map[string]string{"service": "route53resolver", "action": "ListFirewallRules"},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"ResourceNotFoundException"}),
+			},
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "firewall_rule_group_id", Require: plugin.Optional},
+				{Name: "action", Require: plugin.Optional},
+				{Name: "priority", Require: plugin.Optional},
+			},
+		},
+		GetMatrixItemFunc: SupportedRegionMatrix(route53resolverv1.EndpointsID),
+		Columns: awsRegionalColumns([]*plugin.Column{
+			{
+				Name:        "name",
+				Description: "The name of the rule.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "firewall_rule_group_id",
+				Description: "The unique identifier of the firewall rule group of the rule.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "firewall_domain_list_id",
+				Description: "The ID of the domain list that's used in the rule.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "priority",
+				Description: "The priority of the rule in the rule group. DNS Firewall processes the rules in a rule group by order of priority, starting from the lowest setting.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "action",
+				Description: "The action that DNS Firewall should take on a DNS query when it matches one of the domains in the rule's domain list. Possible values are: ALLOW|BLOCK|ALERT.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "block_response",
+				Description: "The way that you want DNS Firewall to block the request, for rules with a BLOCK action. Possible values are: NODATA|NXDOMAIN|OVERRIDE.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("BlockResponse").NullIfZero(),
+			},
+			{
+				Name:        "block_override_domain",
+				Description: "The custom DNS record to send back in response to the query, for rules with a BLOCK action and an OVERRIDE block response.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "block_override_dns_type",
+				Description: "The DNS record's type, for rules with a BLOCK action and an OVERRIDE block response.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("BlockOverrideDnsType").NullIfZero(),
+			},
+			{
+				Name:        "block_override_ttl",
+				Description: "The recommended amount of time, in seconds, for the DNS resolver or web browser to cache the provided override record, for rules with a BLOCK action and an OVERRIDE block response.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "creator_request_id",
+				Description: "A unique string defined by you to identify the request.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "creation_time",
+				Description: "The date and time that the rule was created, in Unix time format and Coordinated Universal Time (UTC).",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "modification_time",
+				Description: "The date and time that the rule was last modified, in Unix time format and Coordinated Universal Time (UTC).",
+				Type:        proto.ColumnType_STRING,
+			},
+
+			// Standard columns for all tables
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Name"),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listRoute53ResolverFirewallRules(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	ruleGroup := h.Item.(types.FirewallRuleGroupMetadata)
+
+	// check if the provided firewall_rule_group_id is not matching with the parentHydrate
+	if d.EqualsQualString("firewall_rule_group_id") != "" && d.EqualsQualString("firewall_rule_group_id") != aws.ToString(ruleGroup.Id) {
+		return nil, nil
+	}
+
+	// Create session
+	svc, err := Route53ResolverClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_route53_resolver_firewall_rule.listRoute53ResolverFirewallRules", "client_error", err)
+		return nil, err
+	}
+	if svc == nil {
+		// Unsupported region, return no data
+		return nil, nil
+	}
+
+	maxItems := int32(100)
+	// Reduce the basic request limit down if the user has only requested a small number of rows
+	if d.QueryContext.Limit != nil {
+		limit := int32(*d.QueryContext.Limit)
+		if limit < maxItems {
+			if limit < 1 {
+				maxItems = int32(1)
+			} else {
+				maxItems = int32(limit)
+			}
+		}
+	}
+
+	input := &route53resolver.ListFirewallRulesInput{
+		FirewallRuleGroupId: ruleGroup.Id,
+		MaxResults:          aws.Int32(maxItems),
+	}
+	if d.EqualsQualString("action") != "" {
+		input.Action = types.Action(d.EqualsQualString("action"))
+	}
+	if d.EqualsQuals["priority"] != nil {
+		input.Priority = aws.Int32(int32(d.EqualsQuals["priority"].GetInt64Value()))
+	}
+
+	paginator := route53resolver.NewListFirewallRulesPaginator(svc, input, func(o *route53resolver.ListFirewallRulesPaginatorOptions) {
+		o.Limit = maxItems
+		o.StopOnDuplicateToken = true
+	})
+
+	for paginator.HasMorePages() {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_route53_resolver_firewall_rule.listRoute53ResolverFirewallRules", "api_error", err)
+			return nil, err
+		}
+
+		for _, rule := range output.FirewallRules {
+			d.StreamListItem(ctx, rule)
+
+			// Context may get cancelled due to manual cancellation or if the limit has been reached
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
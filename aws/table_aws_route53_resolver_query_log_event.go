@@ -0,0 +1,270 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+
+	cloudwatchlogsv1 "github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsRoute53ResolverQueryLogEvent(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_route53_resolver_query_log_event",
+		Description: "AWS Route53 Resolver query log events from CloudWatch log groups.",
+		List: &plugin.ListConfig{
+			Hydrate:    listRoute53ResolverQueryLogEvents,
+			Tags:       map[string]string{"service": "logs", "action": "FilterLogEvents"},
+			KeyColumns: tableAwsRoute53ResolverQueryLogEventListKeyColumns(),
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"ResourceNotFoundException"}),
+			},
+		},
+		GetMatrixItemFunc: SupportedRegionMatrix(cloudwatchlogsv1.EndpointsID),
+		Columns: awsRegionalColumns([]*plugin.Column{
+			// Top columns
+			{Name: "filter", Type: proto.ColumnType_STRING, Transform: transform.FromQual("filter"), Description: "The cloudwatch filter pattern for the search."},
+			{Name: "log_group_name", Type: proto.ColumnType_STRING, Transform: transform.FromQual("log_group_name"), Description: "The name of the log group to which this event belongs."},
+			{Name: "log_stream_name", Type: proto.ColumnType_STRING, Description: "The name of the log stream to which this event belongs."},
+			{Name: "timestamp", Type: proto.ColumnType_TIMESTAMP, Transform: transform.FromField("Timestamp").Transform(transform.UnixMsToTimestamp), Description: "The time when the event occurred."},
+			{Name: "timestamp_ms", Type: proto.ColumnType_INT, Transform: transform.FromField("Timestamp"), Description: "The time when the event occurred."},
+
+			// Query log fields
+			{Name: "version", Type: proto.ColumnType_STRING, Hydrate: getRoute53ResolverQueryLogMessageField, Description: "The version number of the query log format."},
+			{Name: "vpc_account_id", Type: proto.ColumnType_STRING, Hydrate: getRoute53ResolverQueryLogMessageField, Transform: transform.FromField("AccountId"), Description: "The ID of the AWS account that created the VPC."},
+			{Name: "vpc_region", Type: proto.ColumnType_STRING, Hydrate: getRoute53ResolverQueryLogMessageField, Transform: transform.FromField("Region"), Description: "The AWS Region where the VPC was created."},
+			{Name: "vpc_id", Type: proto.ColumnType_STRING, Hydrate: getRoute53ResolverQueryLogMessageField, Description: "The ID of the VPC that the query originated in."},
+			{Name: "query_timestamp", Type: proto.ColumnType_TIMESTAMP, Hydrate: getRoute53ResolverQueryLogMessageField, Description: "The date and time that the query was submitted, in coordinated universal time (UTC)."},
+			{Name: "query_name", Type: proto.ColumnType_STRING, Hydrate: getRoute53ResolverQueryLogMessageField, Description: "The domain name (example.com) or subdomain name (www.example.com) that was specified in the query."},
+			{Name: "query_type", Type: proto.ColumnType_STRING, Hydrate: getRoute53ResolverQueryLogMessageField, Description: "The DNS record type that was specified in the request, or ANY."},
+			{Name: "query_class", Type: proto.ColumnType_STRING, Hydrate: getRoute53ResolverQueryLogMessageField, Description: "The class of the query."},
+			{Name: "rcode", Type: proto.ColumnType_STRING, Hydrate: getRoute53ResolverQueryLogMessageField, Description: "The DNS response code that Resolver returned in response to the DNS query, such as NOERROR or NXDOMAIN."},
+			{Name: "src_addr", Type: proto.ColumnType_IPADDR, Hydrate: getRoute53ResolverQueryLogMessageField, Transform: transform.FromField("SrcAddr"), Description: "The IP address of the instance that the query originated from."},
+			{Name: "src_port", Type: proto.ColumnType_INT, Hydrate: getRoute53ResolverQueryLogMessageField, Transform: transform.FromField("SrcPort"), Description: "The port on the instance that the query originated from."},
+			{Name: "src_instance_id", Type: proto.ColumnType_STRING, Hydrate: getRoute53ResolverQueryLogMessageField, Transform: transform.FromField("SrcIds.instance"), Description: "The ID of the instance that the query originated from."},
+			{Name: "src_resolver_endpoint_id", Type: proto.ColumnType_STRING, Hydrate: getRoute53ResolverQueryLogMessageField, Transform: transform.FromField("SrcIds.resolver_endpoint"), Description: "The ID of the inbound Resolver endpoint that the query was forwarded through, for queries that originated on premises."},
+			{Name: "transport", Type: proto.ColumnType_STRING, Hydrate: getRoute53ResolverQueryLogMessageField, Description: "The protocol used to submit the DNS query."},
+			{Name: "firewall_rule_action", Type: proto.ColumnType_STRING, Hydrate: getRoute53ResolverQueryLogMessageField, Description: "The action specified by the DNS Firewall rule that matched the query, if any."},
+			{Name: "firewall_rule_group_id", Type: proto.ColumnType_STRING, Hydrate: getRoute53ResolverQueryLogMessageField, Description: "The ID of the DNS Firewall rule group that matched the query, if any."},
+			{Name: "firewall_domain_list_id", Type: proto.ColumnType_STRING, Hydrate: getRoute53ResolverQueryLogMessageField, Description: "The ID of the DNS Firewall domain list that matched the query, if any."},
+
+			// Json fields
+			{Name: "answers", Type: proto.ColumnType_JSON, Hydrate: getRoute53ResolverQueryLogMessageField, Description: "The answers that Resolver returned in response to the DNS query."},
+			{Name: "src_ids", Type: proto.ColumnType_JSON, Hydrate: getRoute53ResolverQueryLogMessageField, Transform: transform.FromField("SrcIds"), Description: "The IDs of the instance or inbound Resolver endpoint that the query originated from."},
+			{Name: "query_log_event", Type: proto.ColumnType_JSON, Transform: transform.FromField("Message").Transform(trim).Transform(cloudwatchLogsMesssageJson), Description: "The query log event in the json format."},
+		}),
+	}
+}
+
+// https://docs.aws.amazon.com/Route53/latest/DeveloperGuide/resolver-query-logs-format.html
+type route53ResolverQueryLogEvent struct {
+	// The version number of the query log format.
+	Version *string `json:"version"`
+
+	// The ID of the AWS account that created the VPC.
+	AccountId *string `json:"account_id"`
+
+	// The AWS Region that you created the VPC in.
+	Region *string `json:"region"`
+
+	// The ID of the VPC that the query originated in.
+	VpcId *string `json:"vpc_id"`
+
+	// The date and time that the query was submitted.
+	QueryTimestamp *time.Time `json:"query_timestamp"`
+
+	QueryName  *string `json:"query_name"`
+	QueryType  *string `json:"query_type"`
+	QueryClass *string `json:"query_class"`
+
+	// The DNS response code that Resolver returned in response to the DNS query.
+	Rcode *string `json:"rcode"`
+
+	// The answers that Resolver returned in response to the DNS query.
+	Answers []route53ResolverQueryLogAnswer `json:"answers"`
+
+	SrcAddr *string `json:"srcaddr"`
+	SrcPort *string `json:"srcport"`
+
+	// The protocol used to submit the DNS query.
+	Transport *string `json:"transport"`
+
+	// The ID of the instance or the inbound Resolver endpoint that the query originated from.
+	SrcIds map[string]string `json:"srcids"`
+
+	// Only present when a DNS Firewall rule matched the query.
+	FirewallRuleAction   *string `json:"firewall_rule_action"`
+	FirewallRuleGroupId  *string `json:"firewall_rule_group_id"`
+	FirewallDomainListId *string `json:"firewall_domain_list_id"`
+}
+
+type route53ResolverQueryLogAnswer struct {
+	Rdata *string `json:"Rdata"`
+	Type  *string `json:"Type"`
+	Class *string `json:"Class"`
+}
+
+func tableAwsRoute53ResolverQueryLogEventListKeyColumns() []*plugin.KeyColumn {
+	return []*plugin.KeyColumn{
+		// CloudWatch fields
+		{Name: "log_group_name"},
+		{Name: "log_stream_name", Require: plugin.Optional},
+		{Name: "filter", Require: plugin.Optional, CacheMatch: "exact"},
+		{Name: "region", Require: plugin.Optional},
+		{Name: "timestamp", Operators: []string{">", ">=", "=", "<", "<="}, Require: plugin.Optional},
+
+		// event fields
+		{Name: "vpc_id", Require: plugin.Optional},
+		{Name: "query_name", Require: plugin.Optional},
+		{Name: "query_type", Require: plugin.Optional},
+		{Name: "rcode", Require: plugin.Optional},
+		{Name: "transport", Require: plugin.Optional},
+		{Name: "src_addr", Require: plugin.Optional},
+		{Name: "src_instance_id", Require: plugin.Optional},
+		{Name: "firewall_rule_action", Require: plugin.Optional},
+		{Name: "firewall_rule_group_id", Require: plugin.Optional},
+	}
+}
+
+func listRoute53ResolverQueryLogEvents(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// Create session
+	svc, err := CloudWatchLogsClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_route53_resolver_query_log_event.listRoute53ResolverQueryLogEvents", "connection_error", err)
+		return nil, err
+	}
+
+	equalQuals := d.EqualsQuals
+
+	// Limiting the results
+	maxLimit := int32(10000)
+	if d.QueryContext.Limit != nil {
+		limit := int32(*d.QueryContext.Limit)
+		if limit < maxLimit {
+			if limit < 1 {
+				maxLimit = 1
+			} else {
+				maxLimit = limit
+			}
+		}
+	}
+
+	input := &cloudwatchlogs.FilterLogEventsInput{
+		LogGroupName: aws.String(equalQuals["log_group_name"].GetStringValue()),
+		// Default to the maximum allowed
+		Limit: aws.Int32(maxLimit),
+	}
+
+	if equalQuals["log_stream_name"] != nil {
+		input.LogStreamNames = []string{equalQuals["log_stream_name"].GetStringValue()}
+	}
+
+	queryFilter := ""
+	filter := buildRoute53ResolverQueryLogFilter(equalQuals)
+
+	if equalQuals["filter"] != nil {
+		queryFilter = equalQuals["filter"].GetStringValue()
+	}
+
+	if queryFilter != "" {
+		input.FilterPattern = aws.String(queryFilter)
+	} else if len(filter) > 0 {
+		input.FilterPattern = aws.String(fmt.Sprintf("{ %s }", strings.Join(filter, " && ")))
+	}
+
+	quals := d.Quals
+
+	if quals["timestamp"] != nil {
+		for _, q := range quals["timestamp"].Quals {
+			tsSecs := q.Value.GetTimestampValue().GetSeconds()
+			tsMs := tsSecs * 1000
+			switch q.Operator {
+			case "=":
+				input.StartTime = aws.Int64(tsMs)
+				input.EndTime = aws.Int64(tsMs)
+			case ">=", ">":
+				input.StartTime = aws.Int64(tsMs)
+			case "<", "<=":
+				input.EndTime = aws.Int64(tsMs)
+			}
+		}
+	}
+
+	paginator := cloudwatchlogs.NewFilterLogEventsPaginator(svc, input, func(o *cloudwatchlogs.FilterLogEventsPaginatorOptions) {
+		o.Limit = maxLimit
+		o.StopOnDuplicateToken = true
+	})
+
+	// List call
+	for paginator.HasMorePages() {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_route53_resolver_query_log_event.listRoute53ResolverQueryLogEvents", "api_error", err)
+			return nil, err
+		}
+
+		for _, event := range output.Events {
+			d.StreamListItem(ctx, event)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+func getRoute53ResolverQueryLogMessageField(ctx context.Context, _ *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	e := h.Item.(types.FilteredLogEvent)
+	event := route53ResolverQueryLogEvent{}
+	err := json.Unmarshal([]byte(*e.Message), &event)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_route53_resolver_query_log_event.getRoute53ResolverQueryLogMessageField", "unmarshal_error", err)
+		return nil, err
+	}
+	return event, nil
+}
+
+func buildRoute53ResolverQueryLogFilter(equalQuals plugin.KeyColumnEqualsQualMap) []string {
+	filters := []string{}
+
+	filterQuals := map[string]string{
+		"vpc_id":                 "vpc_id",
+		"query_name":             "query_name",
+		"query_type":             "query_type",
+		"rcode":                  "rcode",
+		"transport":              "transport",
+		"src_instance_id":        "srcids.instance",
+		"firewall_rule_action":   "firewall_rule_action",
+		"firewall_rule_group_id": "firewall_rule_group_id",
+	}
+
+	for qual, filterKey := range filterQuals {
+		if equalQuals[qual] != nil {
+			filters = append(filters, fmt.Sprintf("( $.%s = \"%s\" )", filterKey, equalQuals[qual].GetStringValue()))
+		}
+	}
+
+	if equalQuals["src_addr"] != nil {
+		filters = append(filters, fmt.Sprintf("( $.srcaddr = \"%s\" )", equalQuals["src_addr"].GetInetValue().GetAddr()))
+	}
+
+	return filters
+}
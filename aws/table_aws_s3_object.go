@@ -604,15 +604,20 @@ func getS3ObjectTagging(ctx context.Context, d *plugin.QueryData, h *plugin.Hydr
 }
 
 func getBucketLocationForObjects(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	bucketName := d.EqualsQuals["bucket_name"].GetStringValue()
+	return getBucketRegionByName(ctx, d, h, bucketName)
+}
+
+// getBucketRegionByName returns the region of a bucket owned by the connection's account.
+// The result is cached per bucket, since tables reading objects call it for every object.
+func getBucketRegionByName(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData, bucketName string) (string, error) {
 	c, err := getCommonColumns(ctx, d, h)
 	if err != nil {
 		plugin.Logger(ctx).Error("aws_s3_object.getBucketLocationForObjects", "get_common_columns_error", err)
-		return nil, err
+		return "", err
 	}
 	commonColumnData := c.(*awsCommonColumnData)
 
-	bucketName := d.EqualsQuals["bucket_name"].GetStringValue()
-
 	// have we already created and cached the session?
 	cacheKey := "getBucketLocationForObjects" + bucketName + commonColumnData.AccountId
 
@@ -0,0 +1,583 @@
+package aws
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// Expression used if the query has no expression qual
+const s3SelectDefaultExpression = "SELECT * FROM S3Object s"
+
+//// TABLE DEFINITION
+
+func tableAwsS3ObjectSelect(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_s3_object_select",
+		Description: "Query the contents of CSV, JSON and Parquet objects in S3 buckets using S3 Select.",
+		List: &plugin.ListConfig{
+			Hydrate: listS3ObjectSelectRecords,
+			Tags:    map[string]string{"service": "s3", "action": "GetObject"},
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "bucket_name", Require: plugin.Required, CacheMatch: "exact"},
+				{Name: "key", Require: plugin.Required, CacheMatch: "exact"},
+				{Name: "expression", Require: plugin.Optional, CacheMatch: "exact"},
+				{Name: "data", Operators: []string{"@>", "@@"}, Require: plugin.Optional, CacheMatch: "exact"},
+				{Name: "input_format", Require: plugin.Optional, CacheMatch: "exact"},
+				{Name: "compression_type", Require: plugin.Optional, CacheMatch: "exact"},
+				{Name: "csv_file_header_info", Require: plugin.Optional, CacheMatch: "exact"},
+				{Name: "csv_field_delimiter", Require: plugin.Optional, CacheMatch: "exact"},
+				{Name: "json_type", Require: plugin.Optional, CacheMatch: "exact"},
+			},
+		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{
+				Func: getBucketLocationForObjects,
+				Tags: map[string]string{"service": "s3", "action": "GetBucketLocation"},
+			},
+		},
+		Columns: awsAccountColumns([]*plugin.Column{
+			{
+				Name:        "bucket_name",
+				Description: "The name of the bucket containing the object.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("bucket_name"),
+			},
+			{
+				Name:        "key",
+				Description: "The key of the object to query.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("key"),
+			},
+			{
+				Name:        "row_number",
+				Description: "The position of the record in the results, starting at 1.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "data",
+				Description: "The record returned by S3 Select, as a JSON object. Containment (@>) and JSON path predicate (@@) conditions on top level fields are added to the WHERE clause of the default expression.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "expression",
+				Description: "The S3 Select SQL expression used to query the object. Defaults to SELECT * FROM S3Object s.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("expression"),
+			},
+			{
+				Name:        "input_format",
+				Description: "The format of the object, either CSV, JSON or Parquet. Defaults to the format matching the extension of the key.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("input_format"),
+			},
+			{
+				Name:        "compression_type",
+				Description: "The compression of a CSV or JSON object, either NONE, GZIP or BZIP2. Defaults to the compression matching the extension of the key.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("compression_type"),
+			},
+			{
+				Name:        "csv_file_header_info",
+				Description: "Describes the first line of a CSV object, either USE, IGNORE or NONE. Defaults to USE, which allows columns to be referenced by their header name.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("csv_file_header_info"),
+			},
+			{
+				Name:        "csv_field_delimiter",
+				Description: "The character separating fields of a CSV object. Defaults to a comma, or a tab for keys ending in .tsv.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("csv_field_delimiter"),
+			},
+			{
+				Name:        "json_type",
+				Description: "The type of a JSON object, either LINES or DOCUMENT. Defaults to LINES.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("json_type"),
+			},
+			{
+				Name:        "region",
+				Description: "The AWS Region in which the object is located.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getBucketLocationForObjects,
+				Transform:   transform.FromValue(),
+			},
+		}),
+	}
+}
+
+type s3SelectRecord struct {
+	RowNumber int64
+	Data      interface{}
+}
+
+//// LIST FUNCTION
+
+func listS3ObjectSelectRecords(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	// Bucket location will be empty if getBucketLocationForObjects returned an error but
+	// was ignored through ignore_error_codes config arg
+	location, err := getBucketLocationForObjects(ctx, d, h)
+	if err != nil {
+		return nil, err
+	} else if location == "" {
+		return nil, nil
+	}
+
+	svc, err := S3Client(ctx, d, fmt.Sprint(location))
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_s3_object_select.listS3ObjectSelectRecords", "get_client_error", err)
+		return nil, err
+	}
+
+	key := d.EqualsQualString("key")
+	inputSerialization, err := buildS3SelectInputSerialization(d, key)
+	if err != nil {
+		return nil, err
+	}
+
+	// Conditions on the records and the query limit can only be pushed down
+	// into the default expression. A custom expression is run as is. Either
+	// way, Postgres rechecks the quals on the records returned.
+	expression := d.EqualsQualString("expression")
+	if expression == "" {
+		expression = s3SelectDefaultExpression
+		conditions, complete := s3SelectConditionsFromQuals(d)
+		where, exact := buildS3SelectWhereClause(conditions, inputSerialization.CSV != nil)
+		if where != "" {
+			expression += " WHERE " + where
+		}
+		if complete && exact && d.QueryContext.Limit != nil {
+			expression += fmt.Sprintf(" LIMIT %d", *d.QueryContext.Limit)
+		}
+	}
+
+	input := &s3.SelectObjectContentInput{
+		Bucket:              aws.String(d.EqualsQualString("bucket_name")),
+		Key:                 aws.String(key),
+		Expression:          aws.String(expression),
+		ExpressionType:      types.ExpressionTypeSql,
+		InputSerialization:  inputSerialization,
+		OutputSerialization: &types.OutputSerialization{JSON: &types.JSONOutput{RecordDelimiter: aws.String("\n")}},
+	}
+
+	d.WaitForListRateLimit(ctx)
+	output, err := svc.SelectObjectContent(ctx, input)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_s3_object_select.listS3ObjectSelectRecords", "api_error", err)
+		return nil, err
+	}
+
+	stream := output.GetStream()
+	defer stream.Close()
+
+	// Records events hold chunks of the output, which may end part way through
+	// a record, so incomplete records are kept until the next chunk arrives.
+	var pending []byte
+	var rowNumber int64
+	streamRecord := func(line []byte) (bool, error) {
+		if len(bytes.TrimSpace(line)) == 0 {
+			return false, nil
+		}
+		var data interface{}
+		decoder := json.NewDecoder(bytes.NewReader(line))
+		decoder.UseNumber()
+		if err := decoder.Decode(&data); err != nil {
+			plugin.Logger(ctx).Error("aws_s3_object_select.listS3ObjectSelectRecords", "unmarshal_error", err)
+			return false, err
+		}
+		rowNumber++
+		d.StreamListItem(ctx, &s3SelectRecord{RowNumber: rowNumber, Data: data})
+
+		// Context may get cancelled due to manual cancellation or if the limit has been reached
+		return d.RowsRemaining(ctx) == 0, nil
+	}
+
+	for event := range stream.Events() {
+		switch e := event.(type) {
+		case *types.SelectObjectContentEventStreamMemberRecords:
+			pending = append(pending, e.Value.Payload...)
+			for {
+				i := bytes.IndexByte(pending, '\n')
+				if i < 0 {
+					break
+				}
+				done, err := streamRecord(pending[:i])
+				if err != nil {
+					return nil, err
+				}
+				if done {
+					return nil, nil
+				}
+				pending = pending[i+1:]
+			}
+		case *types.SelectObjectContentEventStreamMemberEnd:
+			if _, err := streamRecord(pending); err != nil {
+				return nil, err
+			}
+			return nil, nil
+		}
+	}
+
+	if err := stream.Err(); err != nil {
+		plugin.Logger(ctx).Error("aws_s3_object_select.listS3ObjectSelectRecords", "stream_error", err)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// buildS3SelectInputSerialization describes the format of the object from the
+// input quals, falling back to the extension of the key, e.g. data.csv.gz.
+func buildS3SelectInputSerialization(d *plugin.QueryData, key string) (*types.InputSerialization, error) {
+	name := strings.ToLower(key)
+	compressionType := types.CompressionTypeNone
+	switch {
+	case strings.HasSuffix(name, ".gz"):
+		compressionType = types.CompressionTypeGzip
+		name = strings.TrimSuffix(name, ".gz")
+	case strings.HasSuffix(name, ".bz2"):
+		compressionType = types.CompressionTypeBzip2
+		name = strings.TrimSuffix(name, ".bz2")
+	}
+	if d.EqualsQuals["compression_type"] != nil {
+		compressionType = types.CompressionType(strings.ToUpper(d.EqualsQualString("compression_type")))
+	}
+
+	var format string
+	switch {
+	case strings.HasSuffix(name, ".csv"), strings.HasSuffix(name, ".tsv"):
+		format = "CSV"
+	case strings.HasSuffix(name, ".json"), strings.HasSuffix(name, ".jsonl"), strings.HasSuffix(name, ".ndjson"):
+		format = "JSON"
+	case strings.HasSuffix(name, ".parquet"):
+		format = "PARQUET"
+	}
+	if d.EqualsQuals["input_format"] != nil {
+		format = strings.ToUpper(d.EqualsQualString("input_format"))
+	}
+
+	input := &types.InputSerialization{CompressionType: compressionType}
+	switch format {
+	case "CSV":
+		input.CSV = &types.CSVInput{
+			FileHeaderInfo: types.FileHeaderInfoUse,
+			FieldDelimiter: aws.String(","),
+		}
+		if strings.HasSuffix(name, ".tsv") {
+			input.CSV.FieldDelimiter = aws.String("\t")
+		}
+		if d.EqualsQuals["csv_file_header_info"] != nil {
+			input.CSV.FileHeaderInfo = types.FileHeaderInfo(strings.ToUpper(d.EqualsQualString("csv_file_header_info")))
+		}
+		if d.EqualsQuals["csv_field_delimiter"] != nil {
+			input.CSV.FieldDelimiter = aws.String(d.EqualsQualString("csv_field_delimiter"))
+		}
+	case "JSON":
+		input.JSON = &types.JSONInput{Type: types.JSONTypeLines}
+		if d.EqualsQuals["json_type"] != nil {
+			input.JSON.Type = types.JSONType(strings.ToUpper(d.EqualsQualString("json_type")))
+		}
+	case "PARQUET":
+		// Parquet objects are compressed internally, by column
+		input.Parquet = &types.ParquetInput{}
+		input.CompressionType = types.CompressionTypeNone
+	case "":
+		return nil, fmt.Errorf("input_format must be specified for key %s, as it has no recognized extension", key)
+	default:
+		return nil, fmt.Errorf("unsupported input_format %s, must be one of CSV, JSON or Parquet", format)
+	}
+
+	return input, nil
+}
+
+// s3SelectCondition is a comparison of a field of the records with a scalar
+// value, i.e. nil, a json.Number, a bool or a string.
+type s3SelectCondition struct {
+	Path     []string
+	Operator string
+	Value    interface{}
+}
+
+// s3SelectConditionsFromQuals translates the quals on the data column into
+// conditions. It also returns whether every qual could be translated.
+func s3SelectConditionsFromQuals(d *plugin.QueryData) ([]s3SelectCondition, bool) {
+	if d.Quals["data"] == nil {
+		return nil, true
+	}
+
+	var conditions []s3SelectCondition
+	complete := true
+	for _, q := range d.Quals["data"].Quals {
+		// The value of a jsonpath qual may be passed as a string
+		value := q.Value.GetJsonbValue()
+		if value == "" {
+			value = q.Value.GetStringValue()
+		}
+
+		var qualConditions []s3SelectCondition
+		var ok bool
+		switch q.Operator {
+		case "@>":
+			qualConditions, ok = s3SelectContainsConditions(value)
+		case "@@":
+			qualConditions, ok = s3SelectJsonPathConditions(value)
+		}
+		conditions = append(conditions, qualConditions...)
+		complete = complete && ok
+	}
+	return conditions, complete
+}
+
+// s3SelectContainsConditions translates a containment qual, e.g.
+// data @> '{"level": "ERROR"}', into an equality condition per field. Nested
+// objects and arrays can't be compared in S3 Select, so are left to Postgres.
+func s3SelectContainsConditions(value string) ([]s3SelectCondition, bool) {
+	var fields map[string]interface{}
+	decoder := json.NewDecoder(strings.NewReader(value))
+	decoder.UseNumber()
+	if err := decoder.Decode(&fields); err != nil {
+		return nil, false
+	}
+
+	var conditions []s3SelectCondition
+	complete := true
+	for name, v := range fields {
+		switch v.(type) {
+		case nil, json.Number, bool, string:
+			conditions = append(conditions, s3SelectCondition{Path: []string{name}, Operator: "=", Value: v})
+		default:
+			complete = false
+		}
+	}
+	return conditions, complete
+}
+
+// s3SelectJsonPathOperators maps JSON path comparison operators to S3 Select
+var s3SelectJsonPathOperators = map[string]string{
+	"==": "=",
+	"!=": "<>",
+	"<>": "<>",
+	"<":  "<",
+	"<=": "<=",
+	">":  ">",
+	">=": ">=",
+}
+
+// s3SelectJsonPathConditions translates a JSON path predicate qual made of
+// comparisons of a field with a literal joined by &&, e.g.
+// data @@ '$.status >= 500 && $.level == "ERROR"'. Postgres passes the path
+// in its normalized form, i.e. ($."status" >= 500 && $."level" == "ERROR").
+// Any other predicate is left to Postgres.
+func s3SelectJsonPathConditions(value string) ([]s3SelectCondition, bool) {
+	tokens, ok := tokenizeS3SelectJsonPath(value)
+	if !ok {
+		return nil, false
+	}
+
+	// Parentheses don't change the meaning of a conjunction
+	var terms []s3SelectJsonPathToken
+	for _, t := range tokens {
+		if t.Kind != "(" && t.Kind != ")" {
+			terms = append(terms, t)
+		}
+	}
+
+	var conditions []s3SelectCondition
+	for i := 0; i < len(terms); i += 4 {
+		if len(terms) < i+3 || (len(terms) > i+3 && terms[i+3].Kind != "&&") {
+			return nil, false
+		}
+		path, op, literal := terms[i], terms[i+1], terms[i+2]
+		if path.Kind != "path" || op.Kind != "op" || literal.Kind != "literal" {
+			return nil, false
+		}
+		conditions = append(conditions, s3SelectCondition{Path: path.Path, Operator: s3SelectJsonPathOperators[op.Text], Value: literal.Value})
+	}
+	return conditions, len(conditions) > 0
+}
+
+type s3SelectJsonPathToken struct {
+	Kind  string // path, op, literal, (, ) or &&
+	Text  string
+	Path  []string
+	Value interface{}
+}
+
+// tokenizeS3SelectJsonPath splits a JSON path predicate into tokens, returning
+// false for any syntax outside of simple comparisons, e.g. filters, methods or
+// array accessors.
+func tokenizeS3SelectJsonPath(value string) ([]s3SelectJsonPathToken, bool) {
+	var tokens []s3SelectJsonPathToken
+	s := strings.TrimSpace(value)
+	for len(s) > 0 {
+		switch {
+		case s[0] == ' ':
+			s = s[1:]
+		case s[0] == '(' || s[0] == ')':
+			tokens = append(tokens, s3SelectJsonPathToken{Kind: s[:1]})
+			s = s[1:]
+		case strings.HasPrefix(s, "&&"):
+			tokens = append(tokens, s3SelectJsonPathToken{Kind: "&&"})
+			s = s[2:]
+		case s[0] == '$':
+			var path []string
+			s = s[1:]
+			for len(s) > 0 && s[0] == '.' {
+				var name string
+				var ok bool
+				name, s, ok = readS3SelectJsonPathName(s[1:])
+				if !ok {
+					return nil, false
+				}
+				path = append(path, name)
+			}
+			if len(path) == 0 {
+				return nil, false
+			}
+			tokens = append(tokens, s3SelectJsonPathToken{Kind: "path", Path: path})
+		case s[0] == '"':
+			str, rest, ok := readS3SelectJsonPathString(s)
+			if !ok {
+				return nil, false
+			}
+			tokens = append(tokens, s3SelectJsonPathToken{Kind: "literal", Value: str})
+			s = rest
+		default:
+			if op := s3SelectJsonPathOperatorPrefix(s); op != "" {
+				tokens = append(tokens, s3SelectJsonPathToken{Kind: "op", Text: op})
+				s = s[len(op):]
+				continue
+			}
+			end := strings.IndexAny(s, " ()&=!<>")
+			if end < 0 {
+				end = len(s)
+			}
+			word := s[:end]
+			s = s[end:]
+			switch word {
+			case "null":
+				tokens = append(tokens, s3SelectJsonPathToken{Kind: "literal", Value: nil})
+			case "true", "false":
+				tokens = append(tokens, s3SelectJsonPathToken{Kind: "literal", Value: word == "true"})
+			default:
+				if _, err := json.Number(word).Float64(); err != nil {
+					return nil, false
+				}
+				tokens = append(tokens, s3SelectJsonPathToken{Kind: "literal", Value: json.Number(word)})
+			}
+		}
+	}
+	return tokens, true
+}
+
+func s3SelectJsonPathOperatorPrefix(s string) string {
+	for _, op := range []string{"==", "!=", "<>", "<=", ">=", "<", ">"} {
+		if strings.HasPrefix(s, op) {
+			return op
+		}
+	}
+	return ""
+}
+
+// readS3SelectJsonPathName reads a key of a path, either quoted or not
+func readS3SelectJsonPathName(s string) (string, string, bool) {
+	if strings.HasPrefix(s, `"`) {
+		return readS3SelectJsonPathString(s)
+	}
+	end := 0
+	for end < len(s) && (s[end] == '_' || s[end] >= '0' && s[end] <= '9' || s[end] >= 'a' && s[end] <= 'z' || s[end] >= 'A' && s[end] <= 'Z') {
+		end++
+	}
+	if end == 0 {
+		return "", s, false
+	}
+	return s[:end], s[end:], true
+}
+
+// readS3SelectJsonPathString reads a double quoted string, which uses the same
+// escapes as JSON
+func readS3SelectJsonPathString(s string) (string, string, bool) {
+	for end := 1; end < len(s); end++ {
+		switch s[end] {
+		case '\\':
+			end++
+		case '"':
+			var str string
+			if err := json.Unmarshal([]byte(s[:end+1]), &str); err != nil {
+				return "", s, false
+			}
+			return str, s[end+1:], true
+		}
+	}
+	return "", s, false
+}
+
+// buildS3SelectWhereClause translates conditions into an S3 Select condition,
+// e.g. s."level" = 'ERROR' AND s."status" >= 500. It also returns whether the
+// records S3 Select returns are exactly those matching the conditions, and not
+// a superset that Postgres will filter further.
+//
+// CSV fields are always strings, so never equal to a number or bool in
+// Postgres. For CSV objects, equality with those values is compared as a
+// string, and other comparisons with them are not pushed down.
+func buildS3SelectWhereClause(conditions []s3SelectCondition, isCSV bool) (string, bool) {
+	var clauses []string
+	exact := true
+	for _, c := range conditions {
+		if isCSV && len(c.Path) > 1 {
+			exact = false
+			continue
+		}
+		parts := make([]string, len(c.Path))
+		for i, name := range c.Path {
+			parts[i] = `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+		}
+		field := "s." + strings.Join(parts, ".")
+
+		switch value := c.Value.(type) {
+		case nil:
+			switch c.Operator {
+			case "=":
+				// Also matches records without the field
+				clauses = append(clauses, field+" IS NULL")
+				exact = false
+			case "<>":
+				clauses = append(clauses, field+" IS NOT NULL")
+			default:
+				exact = false
+			}
+		case json.Number, bool:
+			literal := fmt.Sprint(value)
+			if isCSV {
+				exact = false
+				if c.Operator != "=" {
+					continue
+				}
+				literal = s3SelectStringLiteral(literal)
+			}
+			clauses = append(clauses, field+" "+c.Operator+" "+literal)
+		case string:
+			clauses = append(clauses, field+" "+c.Operator+" "+s3SelectStringLiteral(value))
+		default:
+			exact = false
+		}
+	}
+	// Keep the expression stable, so identical queries hit the cache
+	sort.Strings(clauses)
+	return strings.Join(clauses, " AND "), exact
+}
+
+func s3SelectStringLiteral(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
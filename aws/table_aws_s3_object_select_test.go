@@ -0,0 +1,45 @@
+package aws
+
+import (
+	"testing"
+)
+
+func TestS3SelectJsonPathConditions(t *testing.T) {
+	tests := []struct {
+		path     string
+		isCSV    bool
+		where    string
+		complete bool
+		exact    bool
+	}{
+		{`$."status" >= 500`, false, `s."status" >= 500`, true, true},
+		{`($."status" >= 500 && $."level" == "ERROR")`, false, `s."level" = 'ERROR' AND s."status" >= 500`, true, true},
+		{`$.user.name != "O'Brien"`, false, `s."user"."name" <> 'O''Brien'`, true, true},
+		{`$."enabled" == true`, false, `s."enabled" = true`, true, true},
+		{`$."deleted_at" == null`, false, `s."deleted_at" IS NULL`, true, false},
+		{`$."status" >= 500`, true, ``, true, false},
+		{`$."status" == 500`, true, `s."status" = '500'`, true, false},
+		{`$."say \"hi\"" == "x"`, false, `s."say ""hi""" = 'x'`, true, true},
+		{`($."a" == 1 || $."b" == 2)`, false, ``, false, true},
+		{`$."tags"[*] == "x"`, false, ``, false, true},
+		{`exists($."a")`, false, ``, false, true},
+	}
+	for _, test := range tests {
+		conditions, complete := s3SelectJsonPathConditions(test.path)
+		where, exact := buildS3SelectWhereClause(conditions, test.isCSV)
+		if where != test.where || complete != test.complete || exact != test.exact {
+			t.Errorf("%s: got %q, complete %v, exact %v, expected %q, complete %v, exact %v", test.path, where, complete, exact, test.where, test.complete, test.exact)
+		}
+	}
+}
+
+func TestS3SelectContainsConditions(t *testing.T) {
+	conditions, complete := s3SelectContainsConditions(`{"level": "ERROR", "status": 500, "tags": ["a"]}`)
+	if complete {
+		t.Errorf("expected nested values to not be translated")
+	}
+	where, exact := buildS3SelectWhereClause(conditions, false)
+	if where != `s."level" = 'ERROR' AND s."status" = 500` || !exact {
+		t.Errorf("unexpected where clause %q, exact %v", where, exact)
+	}
+}
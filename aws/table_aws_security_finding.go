@@ -0,0 +1,1186 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecr"
+	ecrTypes "github.com/aws/aws-sdk-go-v2/service/ecr/types"
+	"github.com/aws/aws-sdk-go-v2/service/guardduty"
+	guarddutyTypes "github.com/aws/aws-sdk-go-v2/service/guardduty/types"
+	"github.com/aws/aws-sdk-go-v2/service/inspector"
+	inspectorTypes "github.com/aws/aws-sdk-go-v2/service/inspector/types"
+	"github.com/aws/aws-sdk-go-v2/service/inspector2"
+	inspector2Types "github.com/aws/aws-sdk-go-v2/service/inspector2/types"
+	"github.com/aws/aws-sdk-go-v2/service/securityhub"
+	securityhubTypes "github.com/aws/aws-sdk-go-v2/service/securityhub/types"
+
+	guarddutyv1 "github.com/aws/aws-sdk-go/service/guardduty"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// Sources of aws_security_finding, in the order they are queried
+const (
+	securityFindingSourceGuardDuty   = "guardduty"
+	securityFindingSourceSecurityHub = "securityhub"
+	securityFindingSourceInspector2  = "inspector2"
+	securityFindingSourceInspector   = "inspector"
+	securityFindingSourceECR         = "ecr"
+)
+
+// OCSF finding classes
+const (
+	securityFindingClassSecurity      = 2001
+	securityFindingClassVulnerability = 2002
+	securityFindingClassCompliance    = 2003
+	securityFindingClassDetection     = 2004
+)
+
+var securityFindingClassNames = map[int32]string{
+	securityFindingClassSecurity:      "Security Finding",
+	securityFindingClassVulnerability: "Vulnerability Finding",
+	securityFindingClassCompliance:    "Compliance Finding",
+	securityFindingClassDetection:     "Detection Finding",
+}
+
+// OCSF severity and status captions, keyed by their id
+var securityFindingSeverityIds = map[string]int32{
+	"Unknown":       0,
+	"Informational": 1,
+	"Low":           2,
+	"Medium":        3,
+	"High":          4,
+	"Critical":      5,
+}
+
+var securityFindingStatusIds = map[string]int32{
+	"Unknown":     0,
+	"New":         1,
+	"In Progress": 2,
+	"Suppressed":  3,
+	"Resolved":    4,
+}
+
+// Native severity and status values of each source, mapped to OCSF captions.
+// Pushed down filters are built by reversing these maps.
+var (
+	securityHubSeverities = map[string]string{
+		"INFORMATIONAL": "Informational",
+		"LOW":           "Low",
+		"MEDIUM":        "Medium",
+		"HIGH":          "High",
+		"CRITICAL":      "Critical",
+	}
+	securityHubStatuses = map[string]string{
+		"NEW":        "New",
+		"NOTIFIED":   "In Progress",
+		"SUPPRESSED": "Suppressed",
+		"RESOLVED":   "Resolved",
+	}
+	inspector2Severities = map[string]string{
+		"INFORMATIONAL": "Informational",
+		"LOW":           "Low",
+		"MEDIUM":        "Medium",
+		"HIGH":          "High",
+		"CRITICAL":      "Critical",
+		"UNTRIAGED":     "Unknown",
+	}
+	inspector2Statuses = map[string]string{
+		"ACTIVE":     "New",
+		"SUPPRESSED": "Suppressed",
+		"CLOSED":     "Resolved",
+	}
+	inspectorSeverities = map[string]string{
+		"Informational": "Informational",
+		"Low":           "Low",
+		"Medium":        "Medium",
+		"High":          "High",
+		"Undefined":     "Unknown",
+	}
+	ecrSeverities = map[string]string{
+		"INFORMATIONAL": "Informational",
+		"LOW":           "Low",
+		"MEDIUM":        "Medium",
+		"HIGH":          "High",
+		"CRITICAL":      "Critical",
+		"UNDEFINED":     "Unknown",
+	}
+	// GuardDuty findings are either active or archived
+	guardDutyStatuses = map[string]string{
+		"false": "New",
+		"true":  "Suppressed",
+	}
+)
+
+//// TABLE DEFINITION
+
+func tableAwsSecurityFinding(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_security_finding",
+		Description: "AWS Security Finding, normalized across GuardDuty, Security Hub, Inspector and ECR image scanning.",
+		List: &plugin.ListConfig{
+			Hydrate: listSecurityFindings,
+			Tags:    map[string]string{"service": "securityhub", "action": "GetFindings"},
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "source", Require: plugin.Optional},
+				{Name: "severity", Require: plugin.Optional},
+				{Name: "severity_id", Require: plugin.Optional, Operators: []string{"=", ">", ">=", "<", "<="}},
+				{Name: "status", Require: plugin.Optional},
+				{Name: "last_seen_time", Require: plugin.Optional, Operators: []string{"=", ">", ">=", "<", "<="}},
+			},
+		},
+		GetMatrixItemFunc: SupportedRegionMatrix(guarddutyv1.EndpointsID),
+		Columns: awsRegionalColumns([]*plugin.Column{
+			{
+				Name:        "id",
+				Description: "The identifier of the finding in its source.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "source",
+				Description: "The service that reported the finding, one of guardduty, securityhub, inspector2, inspector or ecr.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "title",
+				Description: "The title of the finding.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "description",
+				Description: "The description of the finding.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "type",
+				Description: "The type of the finding in its source, e.g. a GuardDuty finding type or a CVE for ECR image scan findings.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "class_uid",
+				Description: "The OCSF class of the finding, e.g. 2002 for a vulnerability finding.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "class_name",
+				Description: "The name of the OCSF class of the finding, e.g. Vulnerability Finding.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "severity",
+				Description: "The OCSF severity of the finding, one of Unknown, Informational, Low, Medium, High or Critical.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "severity_id",
+				Description: "The OCSF severity id of the finding, from 0 (Unknown) to 5 (Critical).",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "source_severity",
+				Description: "The severity of the finding as reported by its source.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "status",
+				Description: "The OCSF status of the finding, one of New, In Progress, Suppressed or Resolved.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "status_id",
+				Description: "The OCSF status id of the finding, from 1 (New) to 4 (Resolved).",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "source_status",
+				Description: "The status of the finding as reported by its source.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "resource_arns",
+				Description: "The ARNs, or identifiers if no ARN is available, of the resources affected by the finding.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "resource_type",
+				Description: "The type of the primary resource affected by the finding.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "finding_account_id",
+				Description: "The ID of the AWS account the finding belongs to. This can differ from account_id for findings of member accounts.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "first_seen_time",
+				Description: "The time the activity or vulnerability of the finding was first observed.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "last_seen_time",
+				Description: "The time the activity or vulnerability of the finding was most recently observed.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "created_time",
+				Description: "The time the finding was created.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "modified_time",
+				Description: "The time the finding was last updated.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "remediation",
+				Description: "The recommended steps to remediate the finding.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "remediation_references",
+				Description: "URLs with more information about the remediation of the finding.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "raw_data",
+				Description: "The finding as returned by its source.",
+				Type:        proto.ColumnType_JSON,
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "akas",
+				Description: resourceInterfaceDescription("akas"),
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Arn").Transform(transform.EnsureStringArray),
+			},
+		}),
+	}
+}
+
+type securityFinding struct {
+	Id                    *string
+	Arn                   *string
+	Source                string
+	Title                 *string
+	Description           *string
+	Type                  *string
+	ClassUid              int32
+	ClassName             string
+	Severity              string
+	SeverityId            int32
+	SourceSeverity        *string
+	Status                string
+	StatusId              int32
+	SourceStatus          *string
+	ResourceArns          []string
+	ResourceType          *string
+	FindingAccountId      *string
+	FirstSeenTime         *time.Time
+	LastSeenTime          *time.Time
+	CreatedTime           *time.Time
+	ModifiedTime          *time.Time
+	Remediation           *string
+	RemediationReferences []string
+	RawData               interface{}
+}
+
+// securityFindingQuals holds the quals of a query, normalized so they can be
+// translated into the filters of each source.
+type securityFindingQuals struct {
+	sources    map[string]bool
+	severities map[string]bool
+	statuses   map[string]bool
+	start, end *time.Time
+}
+
+//// LIST FUNCTION
+
+func listSecurityFindings(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	quals := buildSecurityFindingQuals(d)
+
+	sources := []struct {
+		name string
+		list func(context.Context, *plugin.QueryData, *securityFindingQuals) (bool, error)
+	}{
+		{securityFindingSourceGuardDuty, listSecurityFindingsGuardDuty},
+		{securityFindingSourceSecurityHub, listSecurityFindingsSecurityHub},
+		{securityFindingSourceInspector2, listSecurityFindingsInspector2},
+		{securityFindingSourceInspector, listSecurityFindingsInspector},
+		{securityFindingSourceECR, listSecurityFindingsECR},
+	}
+
+	for _, source := range sources {
+		if !quals.sources[source.name] {
+			continue
+		}
+		done, err := source.list(ctx, d, quals)
+		if err != nil {
+			// A service that is not enabled in the region should not fail the whole query
+			if shouldIgnoreErrors([]string{"AccessDeniedException", "InvalidAccessException", "BadRequestException"})(ctx, d, nil, err) {
+				plugin.Logger(ctx).Warn("aws_security_finding.listSecurityFindings", "source", source.name, "ignored_error", err)
+				continue
+			}
+			return nil, err
+		}
+		if done {
+			return nil, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// streamSecurityFinding streams a finding, unless it does not match the quals.
+// It returns true once no more rows are required by the query.
+func streamSecurityFinding(ctx context.Context, d *plugin.QueryData, quals *securityFindingQuals, finding *securityFinding) bool {
+	if !quals.severities[finding.Severity] || !quals.statuses[finding.Status] {
+		return false
+	}
+	d.StreamListItem(ctx, finding)
+
+	// Context may get cancelled due to manual cancellation or if the limit has been reached
+	return d.RowsRemaining(ctx) == 0
+}
+
+func listSecurityFindingsGuardDuty(ctx context.Context, d *plugin.QueryData, quals *securityFindingQuals) (bool, error) {
+	svc, err := GuardDutyClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_security_finding.listSecurityFindingsGuardDuty", "connection_error", err)
+		return false, err
+	}
+
+	criterion := map[string]guarddutyTypes.Condition{}
+
+	// GuardDuty has a numeric severity, where Low is 1.0 to 3.9, Medium is 4.0
+	// to 6.9, High is 7.0 to 8.9 and Critical is 9.0 and above.
+	var minSeverity, maxSeverity int32 = 6, 0
+	for label := range quals.severities {
+		id := securityFindingSeverityIds[label]
+		if id < 2 {
+			// GuardDuty findings are at least Low
+			continue
+		}
+		minSeverity = min(minSeverity, id)
+		maxSeverity = max(maxSeverity, id)
+	}
+	if minSeverity > maxSeverity {
+		return false, nil
+	}
+	severityBounds := map[int32]int64{2: 0, 3: 4, 4: 7, 5: 9, 6: 11}
+	severityCondition := guarddutyTypes.Condition{}
+	if minSeverity > 2 {
+		severityCondition.GreaterThanOrEqual = aws.Int64(severityBounds[minSeverity])
+	}
+	if maxSeverity < 5 {
+		severityCondition.LessThan = aws.Int64(severityBounds[maxSeverity+1])
+	}
+	if severityCondition.GreaterThanOrEqual != nil || severityCondition.LessThan != nil {
+		criterion["severity"] = severityCondition
+	}
+
+	archived, all := securityFindingNativeValues(guardDutyStatuses, quals.statuses)
+	if len(archived) == 0 {
+		return false, nil
+	}
+	if !all {
+		criterion["service.archived"] = guarddutyTypes.Condition{Equals: archived}
+	}
+
+	// GuardDuty filters on the update time of findings, in epoch milliseconds.
+	// A finding is updated whenever its activity is seen again, so only the
+	// start of the range can be pushed down.
+	if quals.start != nil {
+		criterion["updatedAt"] = guarddutyTypes.Condition{GreaterThanOrEqual: aws.Int64(quals.start.UnixMilli())}
+	}
+
+	detectorPaginator := guardduty.NewListDetectorsPaginator(svc, &guardduty.ListDetectorsInput{})
+	for detectorPaginator.HasMorePages() {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		detectors, err := detectorPaginator.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_security_finding.listSecurityFindingsGuardDuty", "api_error", err)
+			return false, err
+		}
+
+		for _, detectorId := range detectors.DetectorIds {
+			input := &guardduty.ListFindingsInput{
+				DetectorId: aws.String(detectorId),
+				MaxResults: aws.Int32(50),
+			}
+			if len(criterion) > 0 {
+				input.FindingCriteria = &guarddutyTypes.FindingCriteria{Criterion: criterion}
+			}
+
+			paginator := guardduty.NewListFindingsPaginator(svc, input, func(o *guardduty.ListFindingsPaginatorOptions) {
+				o.StopOnDuplicateToken = true
+			})
+			for paginator.HasMorePages() {
+				// apply rate limiting
+				d.WaitForListRateLimit(ctx)
+
+				output, err := paginator.NextPage(ctx)
+				if err != nil {
+					plugin.Logger(ctx).Error("aws_security_finding.listSecurityFindingsGuardDuty", "api_error", err)
+					return false, err
+				}
+				if len(output.FindingIds) == 0 {
+					continue
+				}
+
+				// apply rate limiting
+				d.WaitForListRateLimit(ctx)
+
+				result, err := svc.GetFindings(ctx, &guardduty.GetFindingsInput{
+					DetectorId: aws.String(detectorId),
+					FindingIds: output.FindingIds,
+				})
+				if err != nil {
+					plugin.Logger(ctx).Error("aws_security_finding.listSecurityFindingsGuardDuty", "api_error", err)
+					return false, err
+				}
+				for _, finding := range result.Findings {
+					if streamSecurityFinding(ctx, d, quals, securityFindingFromGuardDuty(finding)) {
+						return true, nil
+					}
+				}
+			}
+		}
+	}
+
+	return false, nil
+}
+
+func listSecurityFindingsSecurityHub(ctx context.Context, d *plugin.QueryData, quals *securityFindingQuals) (bool, error) {
+	svc, err := SecurityHubClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_security_finding.listSecurityFindingsSecurityHub", "client_error", err)
+		return false, err
+	}
+	if svc == nil {
+		// Unsupported region, return no data
+		return false, nil
+	}
+
+	filters := &securityhubTypes.AwsSecurityFindingFilters{}
+
+	severities, all := securityFindingNativeValues(securityHubSeverities, quals.severities)
+	if len(severities) == 0 {
+		return false, nil
+	}
+	if !all {
+		for _, severity := range severities {
+			filters.SeverityLabel = append(filters.SeverityLabel, securityhubTypes.StringFilter{Comparison: securityhubTypes.StringFilterComparisonEquals, Value: aws.String(severity)})
+		}
+	}
+
+	statuses, all := securityFindingNativeValues(securityHubStatuses, quals.statuses)
+	if len(statuses) == 0 {
+		return false, nil
+	}
+	if !all {
+		for _, status := range statuses {
+			filters.WorkflowStatus = append(filters.WorkflowStatus, securityhubTypes.StringFilter{Comparison: securityhubTypes.StringFilterComparisonEquals, Value: aws.String(status)})
+		}
+	}
+
+	if quals.start != nil || quals.end != nil {
+		dateFilter := securityhubTypes.DateFilter{}
+		if quals.start != nil {
+			dateFilter.Start = aws.String(quals.start.Format(time.RFC3339))
+		}
+		if quals.end != nil {
+			dateFilter.End = aws.String(quals.end.Format(time.RFC3339))
+		}
+		filters.LastObservedAt = []securityhubTypes.DateFilter{dateFilter}
+	}
+
+	// Security Hub aggregates GuardDuty and Inspector findings, which are
+	// already read from those services
+	if quals.sources[securityFindingSourceGuardDuty] {
+		filters.ProductName = append(filters.ProductName, securityhubTypes.StringFilter{Comparison: securityhubTypes.StringFilterComparisonNotEquals, Value: aws.String("GuardDuty")})
+	}
+	if quals.sources[securityFindingSourceInspector2] || quals.sources[securityFindingSourceInspector] {
+		filters.ProductName = append(filters.ProductName, securityhubTypes.StringFilter{Comparison: securityhubTypes.StringFilterComparisonNotEquals, Value: aws.String("Inspector")})
+	}
+
+	paginator := securityhub.NewGetFindingsPaginator(svc, &securityhub.GetFindingsInput{Filters: filters, MaxResults: 100}, func(o *securityhub.GetFindingsPaginatorOptions) {
+		o.StopOnDuplicateToken = true
+	})
+	for paginator.HasMorePages() {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			// Handle error for accounts that are not subscribed to AWS Security Hub
+			if strings.Contains(err.Error(), "not subscribed") {
+				return false, nil
+			}
+			plugin.Logger(ctx).Error("aws_security_finding.listSecurityFindingsSecurityHub", "api_error", err)
+			return false, err
+		}
+
+		for _, finding := range output.Findings {
+			if streamSecurityFinding(ctx, d, quals, securityFindingFromSecurityHub(finding)) {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+func listSecurityFindingsInspector2(ctx context.Context, d *plugin.QueryData, quals *securityFindingQuals) (bool, error) {
+	svc, err := Inspector2Client(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_security_finding.listSecurityFindingsInspector2", "connection_error", err)
+		return false, err
+	}
+	if svc == nil {
+		// Unsupported region, return no data
+		return false, nil
+	}
+
+	criteria := &inspector2Types.FilterCriteria{}
+
+	severities, all := securityFindingNativeValues(inspector2Severities, quals.severities)
+	if len(severities) == 0 {
+		return false, nil
+	}
+	if !all {
+		for _, severity := range severities {
+			criteria.Severity = append(criteria.Severity, inspector2Types.StringFilter{Comparison: inspector2Types.StringComparisonEquals, Value: aws.String(severity)})
+		}
+	}
+
+	statuses, all := securityFindingNativeValues(inspector2Statuses, quals.statuses)
+	if len(statuses) == 0 {
+		return false, nil
+	}
+	if !all {
+		for _, status := range statuses {
+			criteria.FindingStatus = append(criteria.FindingStatus, inspector2Types.StringFilter{Comparison: inspector2Types.StringComparisonEquals, Value: aws.String(status)})
+		}
+	}
+
+	if quals.start != nil || quals.end != nil {
+		criteria.LastObservedAt = []inspector2Types.DateFilter{{StartInclusive: quals.start, EndInclusive: quals.end}}
+	}
+
+	paginator := inspector2.NewListFindingsPaginator(svc, &inspector2.ListFindingsInput{FilterCriteria: criteria, MaxResults: aws.Int32(100)}, func(o *inspector2.ListFindingsPaginatorOptions) {
+		o.StopOnDuplicateToken = true
+	})
+	for paginator.HasMorePages() {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_security_finding.listSecurityFindingsInspector2", "api_error", err)
+			return false, err
+		}
+
+		for _, finding := range output.Findings {
+			if streamSecurityFinding(ctx, d, quals, securityFindingFromInspector2(finding)) {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+func listSecurityFindingsInspector(ctx context.Context, d *plugin.QueryData, quals *securityFindingQuals) (bool, error) {
+	// Inspector Classic findings have no workflow status, so are always New
+	if !quals.statuses["New"] {
+		return false, nil
+	}
+
+	svc, err := InspectorClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_security_finding.listSecurityFindingsInspector", "connection_error", err)
+		return false, err
+	}
+	if svc == nil {
+		// Unsupported region, return no data
+		return false, nil
+	}
+
+	commonData, err := getCommonColumns(ctx, d, nil)
+	if err != nil {
+		return false, err
+	}
+	commonColumnData := commonData.(*awsCommonColumnData)
+
+	filter := &inspectorTypes.FindingFilter{}
+	severities, all := securityFindingNativeValues(inspectorSeverities, quals.severities)
+	if len(severities) == 0 {
+		return false, nil
+	}
+	if !all {
+		for _, severity := range severities {
+			filter.Severities = append(filter.Severities, inspectorTypes.Severity(severity))
+		}
+	}
+
+	// Only the creation time can be filtered on. A finding created after the
+	// end of the range can not have been seen within it.
+	if quals.end != nil {
+		filter.CreationTimeRange = &inspectorTypes.TimestampRange{EndDate: quals.end}
+	}
+
+	paginator := inspector.NewListFindingsPaginator(svc, &inspector.ListFindingsInput{Filter: filter, MaxResults: aws.Int32(100)}, func(o *inspector.ListFindingsPaginatorOptions) {
+		o.StopOnDuplicateToken = true
+	})
+	for paginator.HasMorePages() {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_security_finding.listSecurityFindingsInspector", "api_error", err)
+			return false, err
+		}
+		if len(output.FindingArns) == 0 {
+			continue
+		}
+
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		// DescribeFindings accepts up to 100 ARNs, matching the page size
+		result, err := svc.DescribeFindings(ctx, &inspector.DescribeFindingsInput{FindingArns: output.FindingArns})
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_security_finding.listSecurityFindingsInspector", "api_error", err)
+			return false, err
+		}
+		for _, finding := range result.Findings {
+			item := securityFindingFromInspector(finding, commonColumnData)
+			if !securityFindingInTimeRange(item, quals) {
+				continue
+			}
+			if streamSecurityFinding(ctx, d, quals, item) {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+func listSecurityFindingsECR(ctx context.Context, d *plugin.QueryData, quals *securityFindingQuals) (bool, error) {
+	// Image scan findings have no workflow status, so are always New
+	if !quals.statuses["New"] {
+		return false, nil
+	}
+
+	svc, err := ECRClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_security_finding.listSecurityFindingsECR", "connection_error", err)
+		return false, err
+	}
+
+	// With enhanced scanning, image scan findings are reported by Inspector
+	registryScanning, err := svc.GetRegistryScanningConfiguration(ctx, &ecr.GetRegistryScanningConfigurationInput{})
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_security_finding.listSecurityFindingsECR", "api_error", err)
+		return false, err
+	}
+	if registryScanning.ScanningConfiguration != nil && registryScanning.ScanningConfiguration.ScanType == ecrTypes.ScanTypeEnhanced {
+		return false, nil
+	}
+
+	repositories := ecr.NewDescribeRepositoriesPaginator(svc, &ecr.DescribeRepositoriesInput{})
+	for repositories.HasMorePages() {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		repositoryOutput, err := repositories.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_security_finding.listSecurityFindingsECR", "api_error", err)
+			return false, err
+		}
+
+		for _, repository := range repositoryOutput.Repositories {
+			images := ecr.NewDescribeImagesPaginator(svc, &ecr.DescribeImagesInput{RepositoryName: repository.RepositoryName})
+			for images.HasMorePages() {
+				// apply rate limiting
+				d.WaitForListRateLimit(ctx)
+
+				imageOutput, err := images.NextPage(ctx)
+				if err != nil {
+					plugin.Logger(ctx).Error("aws_security_finding.listSecurityFindingsECR", "api_error", err)
+					return false, err
+				}
+
+				for _, image := range imageOutput.ImageDetails {
+					// Skip images without findings matching the quals, using the scan summary
+					if !ecrImageHasSecurityFindings(image, quals) {
+						continue
+					}
+					done, err := listSecurityFindingsECRImage(ctx, d, svc, quals, repository, image)
+					if err != nil || done {
+						return done, err
+					}
+				}
+			}
+		}
+	}
+
+	return false, nil
+}
+
+func listSecurityFindingsECRImage(ctx context.Context, d *plugin.QueryData, svc *ecr.Client, quals *securityFindingQuals, repository ecrTypes.Repository, image ecrTypes.ImageDetail) (bool, error) {
+	input := &ecr.DescribeImageScanFindingsInput{
+		RepositoryName: repository.RepositoryName,
+		ImageId:        &ecrTypes.ImageIdentifier{ImageDigest: image.ImageDigest},
+		MaxResults:     aws.Int32(1000),
+	}
+	paginator := ecr.NewDescribeImageScanFindingsPaginator(svc, input, func(o *ecr.DescribeImageScanFindingsPaginatorOptions) {
+		o.StopOnDuplicateToken = true
+	})
+	for paginator.HasMorePages() {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_security_finding.listSecurityFindingsECRImage", "api_error", err)
+			return false, err
+		}
+		// If the scan is in progress and no findings are available yet, ImageScanFindings is nil
+		if output.ImageScanFindings == nil {
+			return false, nil
+		}
+
+		for _, finding := range output.ImageScanFindings.Findings {
+			item := securityFindingFromECR(finding, repository, image, output.ImageScanFindings.ImageScanCompletedAt)
+			if streamSecurityFinding(ctx, d, quals, item) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+//// UTILITY FUNCTIONS
+
+func buildSecurityFindingQuals(d *plugin.QueryData) *securityFindingQuals {
+	quals := &securityFindingQuals{
+		sources:    map[string]bool{},
+		severities: map[string]bool{},
+		statuses:   map[string]bool{},
+	}
+
+	for _, source := range []string{securityFindingSourceGuardDuty, securityFindingSourceSecurityHub, securityFindingSourceInspector2, securityFindingSourceInspector, securityFindingSourceECR} {
+		quals.sources[source] = true
+	}
+	if values := securityFindingStringQualValues(d, "source"); values != nil {
+		quals.sources = map[string]bool{}
+		for _, value := range values {
+			quals.sources[strings.ToLower(value)] = true
+		}
+	}
+
+	// Severity quals are applied to every severity, so the matching native
+	// severities of each source can be worked out
+	severityValues := securityFindingStringQualValues(d, "severity")
+	for label, id := range securityFindingSeverityIds {
+		if severityValues != nil && !securityFindingContainsFold(severityValues, label) {
+			continue
+		}
+		if d.Quals["severity_id"] != nil && !securityFindingIntQualsMatch(d.Quals["severity_id"], int64(id)) {
+			continue
+		}
+		quals.severities[label] = true
+	}
+
+	statusValues := securityFindingStringQualValues(d, "status")
+	for label := range securityFindingStatusIds {
+		if statusValues != nil && !securityFindingContainsFold(statusValues, label) {
+			continue
+		}
+		quals.statuses[label] = true
+	}
+
+	if d.Quals["last_seen_time"] != nil {
+		for _, q := range d.Quals["last_seen_time"].Quals {
+			ts := q.Value.GetTimestampValue().AsTime()
+			switch q.Operator {
+			case "=":
+				quals.start, quals.end = &ts, &ts
+			case ">=", ">":
+				quals.start = &ts
+			case "<", "<=":
+				quals.end = &ts
+			}
+		}
+	}
+
+	return quals
+}
+
+func securityFindingStringQualValues(d *plugin.QueryData, column string) []string {
+	if d.Quals[column] == nil {
+		return nil
+	}
+	switch value := getQualsValueByColumn(d.Quals, column, "string").(type) {
+	case string:
+		return []string{value}
+	case []*string:
+		return aws.ToStringSlice(value)
+	}
+	return nil
+}
+
+func securityFindingContainsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func securityFindingIntQualsMatch(keyColumnQuals *plugin.KeyColumnQuals, value int64) bool {
+	for _, q := range keyColumnQuals.Quals {
+		v := q.Value.GetInt64Value()
+		switch q.Operator {
+		case "=":
+			if value != v {
+				return false
+			}
+		case ">":
+			if value <= v {
+				return false
+			}
+		case ">=":
+			if value < v {
+				return false
+			}
+		case "<":
+			if value >= v {
+				return false
+			}
+		case "<=":
+			if value > v {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// securityFindingNativeValues returns the native values of a source that map
+// to the allowed OCSF values, and whether all native values are allowed, in
+// which case no filter is needed.
+func securityFindingNativeValues(mapping map[string]string, allowed map[string]bool) ([]string, bool) {
+	var values []string
+	for native, ocsf := range mapping {
+		if allowed[ocsf] {
+			values = append(values, native)
+		}
+	}
+	sort.Strings(values)
+	return values, len(values) == len(mapping)
+}
+
+func securityFindingInTimeRange(finding *securityFinding, quals *securityFindingQuals) bool {
+	if finding.LastSeenTime == nil {
+		return quals.start == nil && quals.end == nil
+	}
+	if quals.start != nil && finding.LastSeenTime.Before(*quals.start) {
+		return false
+	}
+	if quals.end != nil && finding.LastSeenTime.After(*quals.end) {
+		return false
+	}
+	return true
+}
+
+func ecrImageHasSecurityFindings(image ecrTypes.ImageDetail, quals *securityFindingQuals) bool {
+	summary := image.ImageScanFindingsSummary
+	if summary == nil {
+		return false
+	}
+	if quals.start != nil && (summary.ImageScanCompletedAt == nil || summary.ImageScanCompletedAt.Before(*quals.start)) {
+		return false
+	}
+	if quals.end != nil && (summary.ImageScanCompletedAt == nil || summary.ImageScanCompletedAt.After(*quals.end)) {
+		return false
+	}
+	for severity, count := range summary.FindingSeverityCounts {
+		if count > 0 && quals.severities[ecrSeverities[severity]] {
+			return true
+		}
+	}
+	return false
+}
+
+func parseSecurityFindingTime(value *string) *time.Time {
+	if value == nil {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, *value)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
+func newSecurityFinding(source string, classUid int32, severity string, sourceSeverity string, status string, sourceStatus string) *securityFinding {
+	item := &securityFinding{
+		Source:         source,
+		ClassUid:       classUid,
+		ClassName:      securityFindingClassNames[classUid],
+		Severity:       severity,
+		SeverityId:     securityFindingSeverityIds[severity],
+		SourceSeverity: aws.String(sourceSeverity),
+		Status:         status,
+		StatusId:       securityFindingStatusIds[status],
+	}
+	if sourceStatus != "" {
+		item.SourceStatus = aws.String(sourceStatus)
+	}
+	return item
+}
+
+func securityFindingFromGuardDuty(finding guarddutyTypes.Finding) *securityFinding {
+	severity := aws.ToFloat64(finding.Severity)
+	label := "Low"
+	switch {
+	case severity >= 9:
+		label = "Critical"
+	case severity >= 7:
+		label = "High"
+	case severity >= 4:
+		label = "Medium"
+	}
+
+	archived := "false"
+	if finding.Service != nil && aws.ToBool(finding.Service.Archived) {
+		archived = "true"
+	}
+
+	item := newSecurityFinding(securityFindingSourceGuardDuty, securityFindingClassDetection, label, fmt.Sprint(severity), guardDutyStatuses[archived], map[string]string{"false": "ACTIVE", "true": "ARCHIVED"}[archived])
+	item.Id = finding.Id
+	item.Arn = finding.Arn
+	item.Title = finding.Title
+	item.Description = finding.Description
+	item.Type = finding.Type
+	item.FindingAccountId = finding.AccountId
+	item.CreatedTime = parseSecurityFindingTime(finding.CreatedAt)
+	item.ModifiedTime = parseSecurityFindingTime(finding.UpdatedAt)
+	item.FirstSeenTime = item.CreatedTime
+	item.LastSeenTime = item.ModifiedTime
+	if finding.Service != nil {
+		if t := parseSecurityFindingTime(finding.Service.EventFirstSeen); t != nil {
+			item.FirstSeenTime = t
+		}
+		if t := parseSecurityFindingTime(finding.Service.EventLastSeen); t != nil {
+			item.LastSeenTime = t
+		}
+	}
+	item.Remediation = aws.String("See the GuardDuty documentation for remediating a finding of type " + aws.ToString(finding.Type) + ".")
+	item.RemediationReferences = []string{"https://docs.aws.amazon.com/guardduty/latest/ug/guardduty_remediate.html"}
+	item.RawData = finding
+
+	if resource := finding.Resource; resource != nil {
+		item.ResourceType = resource.ResourceType
+		partition, region, account := aws.ToString(finding.Partition), aws.ToString(finding.Region), aws.ToString(finding.AccountId)
+		if resource.InstanceDetails != nil && resource.InstanceDetails.InstanceId != nil {
+			item.ResourceArns = append(item.ResourceArns, fmt.Sprintf("arn:%s:ec2:%s:%s:instance/%s", partition, region, account, *resource.InstanceDetails.InstanceId))
+		}
+		for _, bucket := range resource.S3BucketDetails {
+			if bucket.Arn != nil {
+				item.ResourceArns = append(item.ResourceArns, *bucket.Arn)
+			}
+		}
+		if resource.EksClusterDetails != nil && resource.EksClusterDetails.Arn != nil {
+			item.ResourceArns = append(item.ResourceArns, *resource.EksClusterDetails.Arn)
+		}
+		if resource.EcsClusterDetails != nil && resource.EcsClusterDetails.Arn != nil {
+			item.ResourceArns = append(item.ResourceArns, *resource.EcsClusterDetails.Arn)
+		}
+		if resource.RdsDbInstanceDetails != nil && resource.RdsDbInstanceDetails.DbInstanceArn != nil {
+			item.ResourceArns = append(item.ResourceArns, *resource.RdsDbInstanceDetails.DbInstanceArn)
+		}
+		if resource.LambdaDetails != nil && resource.LambdaDetails.FunctionArn != nil {
+			item.ResourceArns = append(item.ResourceArns, *resource.LambdaDetails.FunctionArn)
+		}
+		if resource.AccessKeyDetails != nil && resource.AccessKeyDetails.AccessKeyId != nil {
+			item.ResourceArns = append(item.ResourceArns, *resource.AccessKeyDetails.AccessKeyId)
+		}
+	}
+
+	return item
+}
+
+func securityFindingFromSecurityHub(finding securityhubTypes.AwsSecurityFinding) *securityFinding {
+	var sourceSeverity, sourceStatus string
+	if finding.Severity != nil {
+		sourceSeverity = string(finding.Severity.Label)
+	}
+	if finding.Workflow != nil {
+		sourceStatus = string(finding.Workflow.Status)
+	}
+	severity, ok := securityHubSeverities[sourceSeverity]
+	if !ok {
+		severity = "Unknown"
+	}
+	status, ok := securityHubStatuses[sourceStatus]
+	if !ok {
+		status = "Unknown"
+	}
+
+	classUid := int32(securityFindingClassSecurity)
+	switch {
+	case finding.Compliance != nil:
+		classUid = securityFindingClassCompliance
+	case len(finding.Vulnerabilities) > 0:
+		classUid = securityFindingClassVulnerability
+	case len(finding.Types) > 0 && strings.HasPrefix(finding.Types[0], "TTPs"):
+		classUid = securityFindingClassDetection
+	}
+
+	item := newSecurityFinding(securityFindingSourceSecurityHub, classUid, severity, sourceSeverity, status, sourceStatus)
+	item.Id = finding.Id
+	item.Title = finding.Title
+	item.Description = finding.Description
+	if len(finding.Types) > 0 {
+		item.Type = aws.String(finding.Types[0])
+	}
+	item.FindingAccountId = finding.AwsAccountId
+	item.CreatedTime = parseSecurityFindingTime(finding.CreatedAt)
+	item.ModifiedTime = parseSecurityFindingTime(finding.UpdatedAt)
+	item.FirstSeenTime = parseSecurityFindingTime(finding.FirstObservedAt)
+	item.LastSeenTime = parseSecurityFindingTime(finding.LastObservedAt)
+	if finding.Remediation != nil && finding.Remediation.Recommendation != nil {
+		item.Remediation = finding.Remediation.Recommendation.Text
+		if finding.Remediation.Recommendation.Url != nil {
+			item.RemediationReferences = []string{*finding.Remediation.Recommendation.Url}
+		}
+	}
+	item.RawData = finding
+
+	// Security Hub finding ids are ARNs for findings of AWS services
+	if strings.HasPrefix(aws.ToString(finding.Id), "arn:") {
+		item.Arn = finding.Id
+	}
+	for i, resource := range finding.Resources {
+		if i == 0 {
+			item.ResourceType = resource.Type
+		}
+		if resource.Id != nil {
+			item.ResourceArns = append(item.ResourceArns, *resource.Id)
+		}
+	}
+
+	return item
+}
+
+func securityFindingFromInspector2(finding inspector2Types.Finding) *securityFinding {
+	severity, ok := inspector2Severities[string(finding.Severity)]
+	if !ok {
+		severity = "Unknown"
+	}
+	status, ok := inspector2Statuses[string(finding.Status)]
+	if !ok {
+		status = "Unknown"
+	}
+
+	item := newSecurityFinding(securityFindingSourceInspector2, securityFindingClassVulnerability, severity, string(finding.Severity), status, string(finding.Status))
+	item.Id = finding.FindingArn
+	item.Arn = finding.FindingArn
+	item.Title = finding.Title
+	item.Description = finding.Description
+	item.Type = aws.String(string(finding.Type))
+	item.FindingAccountId = finding.AwsAccountId
+	item.CreatedTime = finding.FirstObservedAt
+	item.ModifiedTime = finding.UpdatedAt
+	item.FirstSeenTime = finding.FirstObservedAt
+	item.LastSeenTime = finding.LastObservedAt
+	if finding.Remediation != nil && finding.Remediation.Recommendation != nil {
+		item.Remediation = finding.Remediation.Recommendation.Text
+		if finding.Remediation.Recommendation.Url != nil {
+			item.RemediationReferences = []string{*finding.Remediation.Recommendation.Url}
+		}
+	}
+	item.RawData = finding
+
+	for i, resource := range finding.Resources {
+		if i == 0 {
+			item.ResourceType = aws.String(string(resource.Type))
+		}
+		if resource.Id != nil {
+			item.ResourceArns = append(item.ResourceArns, *resource.Id)
+		}
+	}
+
+	return item
+}
+
+func securityFindingFromInspector(finding inspectorTypes.Finding, commonColumnData *awsCommonColumnData) *securityFinding {
+	severity, ok := inspectorSeverities[string(finding.Severity)]
+	if !ok {
+		severity = "Unknown"
+	}
+
+	item := newSecurityFinding(securityFindingSourceInspector, securityFindingClassVulnerability, severity, string(finding.Severity), "New", "")
+	item.Id = finding.Arn
+	item.Arn = finding.Arn
+	item.Title = finding.Title
+	item.Description = finding.Description
+	item.Type = finding.Id
+	item.FindingAccountId = aws.String(commonColumnData.AccountId)
+	item.CreatedTime = finding.CreatedAt
+	item.ModifiedTime = finding.UpdatedAt
+	item.FirstSeenTime = finding.CreatedAt
+	item.LastSeenTime = finding.UpdatedAt
+	item.Remediation = finding.Recommendation
+	item.RawData = finding
+
+	item.ResourceType = aws.String(string(finding.AssetType))
+	if finding.AssetAttributes != nil && strings.HasPrefix(aws.ToString(finding.AssetAttributes.AgentId), "i-") {
+		item.ResourceArns = []string{fmt.Sprintf("arn:%s:ec2:%s:%s:instance/%s", commonColumnData.Partition, commonColumnData.Region, commonColumnData.AccountId, *finding.AssetAttributes.AgentId)}
+	}
+
+	return item
+}
+
+func securityFindingFromECR(finding ecrTypes.ImageScanFinding, repository ecrTypes.Repository, image ecrTypes.ImageDetail, scanCompletedAt *time.Time) *securityFinding {
+	severity, ok := ecrSeverities[string(finding.Severity)]
+	if !ok {
+		severity = "Unknown"
+	}
+
+	item := newSecurityFinding(securityFindingSourceECR, securityFindingClassVulnerability, severity, string(finding.Severity), "New", "")
+	item.Id = aws.String(aws.ToString(repository.RepositoryArn) + "@" + aws.ToString(image.ImageDigest) + "/" + aws.ToString(finding.Name))
+	item.Title = finding.Name
+	item.Description = finding.Description
+	item.Type = finding.Name
+	item.FindingAccountId = repository.RegistryId
+	item.CreatedTime = scanCompletedAt
+	item.ModifiedTime = scanCompletedAt
+	item.FirstSeenTime = scanCompletedAt
+	item.LastSeenTime = scanCompletedAt
+	if finding.Uri != nil {
+		item.RemediationReferences = []string{*finding.Uri}
+	}
+	item.RawData = finding
+
+	item.ResourceType = aws.String("AwsEcrContainerImage")
+	item.ResourceArns = []string{aws.ToString(repository.RepositoryArn) + "@" + aws.ToString(image.ImageDigest)}
+
+	return item
+}
@@ -0,0 +1,335 @@
+package aws
+
+import (
+	"context"
+	"net/netip"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	ec2v1 "github.com/aws/aws-sdk-go/service/ec2"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// AWS reserves the first four and the last address of each subnet CIDR block
+const vpcSubnetReservedAddressCount = 5
+
+//// TABLE DEFINITION
+
+func tableAwsVpcCidrUtilization(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_vpc_cidr_utilization",
+		Description: "AWS VPC CIDR Utilization",
+		List: &plugin.ListConfig{
+			Hydrate: listVpcCidrUtilizations,
+			Tags:    map[string]string{"service": "ec2", "action": "DescribeSubnets"},
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "vpc_id", Require: plugin.Optional},
+				{Name: "resource_type", Require: plugin.Optional},
+			},
+		},
+		GetMatrixItemFunc: SupportedRegionMatrix(ec2v1.EndpointsID),
+		Columns: awsRegionalColumns([]*plugin.Column{
+			{
+				Name:        "cidr_block",
+				Description: "The IPv4 CIDR block of the VPC or subnet.",
+				Type:        proto.ColumnType_CIDR,
+			},
+			{
+				Name:        "resource_type",
+				Description: "The type of the resource the CIDR block is of. Possible values are: vpc|subnet.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "vpc_id",
+				Description: "The ID of the VPC.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "subnet_id",
+				Description: "The ID of the subnet, for subnet CIDR blocks.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("SubnetId").NullIfZero(),
+			},
+			{
+				Name:        "availability_zone",
+				Description: "The Availability Zone of the subnet, for subnet CIDR blocks.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("AvailabilityZone").NullIfZero(),
+			},
+			{
+				Name:        "owner_id",
+				Description: "The ID of the AWS account that owns the VPC or subnet.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "total_address_count",
+				Description: "The number of addresses in the CIDR block.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "reserved_address_count",
+				Description: "The number of addresses AWS reserves in the CIDR block: the first four and the last address of subnet CIDR blocks.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "used_address_count",
+				Description: "For subnets, the number of addresses in use, excluding reserved addresses. For VPCs, the number of addresses of the CIDR block allocated to subnets.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "free_address_count",
+				Description: "For subnets, the number of available addresses. For VPCs, the number of addresses of the CIDR block not allocated to any subnet.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "utilization_percent",
+				Description: "The percentage of usable addresses of the CIDR block that are used.",
+				Type:        proto.ColumnType_DOUBLE,
+			},
+			{
+				Name:        "subnet_count",
+				Description: "The number of subnets in the CIDR block, for VPC CIDR blocks.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("SubnetCount").NullIfZero(),
+			},
+			{
+				Name:        "largest_free_block",
+				Description: "The largest CIDR block of the VPC CIDR block not allocated to any subnet.",
+				Type:        proto.ColumnType_CIDR,
+				Transform:   transform.FromField("LargestFreeBlock").NullIfZero(),
+			},
+			{
+				Name:        "free_blocks",
+				Description: "The CIDR blocks of the VPC CIDR block not allocated to any subnet, largest first.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "overlapping_cidr_blocks",
+				Description: "The CIDR blocks of other VPCs of the same connection and region that overlap the VPC CIDR block, which prevent peering the VPCs or routing between them through a transit gateway. Overlaps with VPCs of other accounts or regions require joining the table to itself on cidr_block over an aggregator connection.",
+				Type:        proto.ColumnType_JSON,
+			},
+		}),
+	}
+}
+
+type vpcCidrUtilization struct {
+	CidrBlock             string
+	ResourceType          string
+	VpcId                 string
+	SubnetId              string
+	AvailabilityZone      string
+	OwnerId               string
+	TotalAddressCount     int64
+	ReservedAddressCount  int64
+	UsedAddressCount      int64
+	FreeAddressCount      int64
+	UtilizationPercent    float64
+	SubnetCount           int
+	LargestFreeBlock      string
+	FreeBlocks            []string
+	OverlappingCidrBlocks []vpcCidrBlockOverlap
+}
+
+type vpcCidrBlockOverlap struct {
+	VpcId     string
+	CidrBlock string
+}
+
+// vpcCidrBlock is an associated IPv4 CIDR block of a VPC
+type vpcCidrBlock struct {
+	Vpc    types.Vpc
+	Prefix netip.Prefix
+}
+
+//// LIST FUNCTION
+
+func listVpcCidrUtilizations(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	svc, err := EC2Client(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_vpc_cidr_utilization.listVpcCidrUtilizations", "client_error", err)
+		return nil, err
+	}
+
+	// The CIDR blocks of all the VPCs of the region are needed to find
+	// overlaps, so only the subnets are filtered by VPC
+	var cidrBlocks []vpcCidrBlock
+	vpcs := ec2.NewDescribeVpcsPaginator(svc, &ec2.DescribeVpcsInput{})
+	for vpcs.HasMorePages() {
+		d.WaitForListRateLimit(ctx)
+		output, err := vpcs.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_vpc_cidr_utilization.listVpcCidrUtilizations", "api_error", err)
+			return nil, err
+		}
+		for _, vpc := range output.Vpcs {
+			for _, association := range vpc.CidrBlockAssociationSet {
+				if association.CidrBlockState == nil || association.CidrBlockState.State != types.VpcCidrBlockStateCodeAssociated {
+					continue
+				}
+				prefix, err := netip.ParsePrefix(aws.ToString(association.CidrBlock))
+				if err != nil {
+					continue
+				}
+				cidrBlocks = append(cidrBlocks, vpcCidrBlock{Vpc: vpc, Prefix: prefix.Masked()})
+			}
+		}
+	}
+
+	input := &ec2.DescribeSubnetsInput{}
+	if d.EqualsQualString("vpc_id") != "" {
+		input.Filters = []types.Filter{{Name: aws.String("vpc-id"), Values: []string{d.EqualsQualString("vpc_id")}}}
+	}
+	var subnets []types.Subnet
+	subnetPrefixes := map[string][]netip.Prefix{}
+	paginator := ec2.NewDescribeSubnetsPaginator(svc, input)
+	for paginator.HasMorePages() {
+		d.WaitForListRateLimit(ctx)
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_vpc_cidr_utilization.listVpcCidrUtilizations", "api_error", err)
+			return nil, err
+		}
+		for _, subnet := range output.Subnets {
+			// IPv6 only subnets have no IPv4 CIDR block
+			prefix, err := netip.ParsePrefix(aws.ToString(subnet.CidrBlock))
+			if err != nil {
+				continue
+			}
+			subnets = append(subnets, subnet)
+			subnetPrefixes[aws.ToString(subnet.VpcId)] = append(subnetPrefixes[aws.ToString(subnet.VpcId)], prefix.Masked())
+		}
+	}
+
+	if d.EqualsQualString("resource_type") == "" || d.EqualsQualString("resource_type") == "vpc" {
+		for _, cidrBlock := range cidrBlocks {
+			vpcId := aws.ToString(cidrBlock.Vpc.VpcId)
+			if d.EqualsQualString("vpc_id") != "" && d.EqualsQualString("vpc_id") != vpcId {
+				continue
+			}
+
+			item := vpcCidrUtilization{
+				CidrBlock:             cidrBlock.Prefix.String(),
+				ResourceType:          "vpc",
+				VpcId:                 vpcId,
+				OwnerId:               aws.ToString(cidrBlock.Vpc.OwnerId),
+				TotalAddressCount:     prefixAddressCount(cidrBlock.Prefix),
+				FreeBlocks:            []string{},
+				OverlappingCidrBlocks: []vpcCidrBlockOverlap{},
+			}
+
+			var allocated []netip.Prefix
+			for _, prefix := range subnetPrefixes[vpcId] {
+				if cidrBlock.Prefix.Contains(prefix.Addr()) {
+					allocated = append(allocated, prefix)
+					item.UsedAddressCount += prefixAddressCount(prefix)
+				}
+			}
+			item.SubnetCount = len(allocated)
+			item.FreeAddressCount = item.TotalAddressCount - item.UsedAddressCount
+			item.UtilizationPercent = float64(item.UsedAddressCount) * 100 / float64(item.TotalAddressCount)
+
+			for _, block := range cidrFreeBlocks(cidrBlock.Prefix, allocated) {
+				item.FreeBlocks = append(item.FreeBlocks, block.String())
+			}
+			if len(item.FreeBlocks) > 0 {
+				item.LargestFreeBlock = item.FreeBlocks[0]
+			}
+
+			for _, other := range cidrBlocks {
+				if aws.ToString(other.Vpc.VpcId) != vpcId && other.Prefix.Overlaps(cidrBlock.Prefix) {
+					item.OverlappingCidrBlocks = append(item.OverlappingCidrBlocks, vpcCidrBlockOverlap{
+						VpcId:     aws.ToString(other.Vpc.VpcId),
+						CidrBlock: other.Prefix.String(),
+					})
+				}
+			}
+
+			d.StreamListItem(ctx, item)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	if d.EqualsQualString("resource_type") == "" || d.EqualsQualString("resource_type") == "subnet" {
+		for _, subnet := range subnets {
+			prefix := netip.MustParsePrefix(aws.ToString(subnet.CidrBlock)).Masked()
+			item := vpcCidrUtilization{
+				CidrBlock:            prefix.String(),
+				ResourceType:         "subnet",
+				VpcId:                aws.ToString(subnet.VpcId),
+				SubnetId:             aws.ToString(subnet.SubnetId),
+				AvailabilityZone:     aws.ToString(subnet.AvailabilityZone),
+				OwnerId:              aws.ToString(subnet.OwnerId),
+				TotalAddressCount:    prefixAddressCount(prefix),
+				ReservedAddressCount: vpcSubnetReservedAddressCount,
+				FreeAddressCount:     int64(aws.ToInt32(subnet.AvailableIpAddressCount)),
+			}
+			usable := item.TotalAddressCount - item.ReservedAddressCount
+			item.UsedAddressCount = usable - item.FreeAddressCount
+			if usable > 0 {
+				item.UtilizationPercent = float64(item.UsedAddressCount) * 100 / float64(usable)
+			}
+
+			d.StreamListItem(ctx, item)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// prefixAddressCount returns the number of addresses of an IPv4 prefix
+func prefixAddressCount(prefix netip.Prefix) int64 {
+	return int64(1) << (32 - prefix.Bits())
+}
+
+// cidrFreeBlocks returns the largest CIDR blocks of a prefix that do not
+// overlap any of the allocated prefixes, largest first
+func cidrFreeBlocks(prefix netip.Prefix, allocated []netip.Prefix) []netip.Prefix {
+	var blocks []netip.Prefix
+	var split func(block netip.Prefix)
+	split = func(block netip.Prefix) {
+		overlapped := false
+		for _, other := range allocated {
+			if other.Bits() <= block.Bits() && other.Contains(block.Addr()) {
+				return
+			}
+			if other.Overlaps(block) {
+				overlapped = true
+			}
+		}
+		if !overlapped {
+			blocks = append(blocks, block)
+			return
+		}
+		// Halve a partially allocated block
+		bits := block.Bits() + 1
+		lower := netip.PrefixFrom(block.Addr(), bits)
+		upper := block.Addr().As4()
+		upper[(bits-1)/8] |= 0x80 >> ((bits - 1) % 8)
+		split(lower)
+		split(netip.PrefixFrom(netip.AddrFrom4(upper), bits))
+	}
+	split(prefix)
+
+	sort.SliceStable(blocks, func(i, j int) bool {
+		if blocks[i].Bits() != blocks[j].Bits() {
+			return blocks[i].Bits() < blocks[j].Bits()
+		}
+		return blocks[i].Addr().Less(blocks[j].Addr())
+	})
+	return blocks
+}
@@ -111,6 +111,13 @@ func tableAwsVpcEndpointService(_ context.Context) *plugin.Table {
 				Hydrate:     listVpcEndpointServicePermissions,
 				Transform:   transform.FromValue(),
 			},
+			{
+				Name:        "allowed_principals",
+				Description: "The ARNs of the principals allowed to discover and connect to the endpoint service.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     listVpcEndpointServicePermissions,
+				Transform:   transform.FromValue().Transform(vpcEndpointServiceAllowedPrincipalArns),
+			},
 			{
 				Name:        "tags_src",
 				Description: "A list of tags assigned to the service.",
@@ -258,16 +265,14 @@ func listVpcEndpointServicePermissions(ctx context.Context, d *plugin.QueryData,
 
 		output, err := paginator.NextPage(ctx)
 		if err != nil {
-			if err != nil {
-				if strings.Contains(err.Error(), "NotFound") {
-					return nil, nil
-				}
-				plugin.Logger(ctx).Error("aws_vpc_endpoint_service.listVpcEndpointServicePermissions", "api_error", err)
-				return nil, err
+			if strings.Contains(err.Error(), "NotFound") {
+				return nil, nil
 			}
-
-			allowedPrincipals = append(allowedPrincipals, output.AllowedPrincipals...)
+			plugin.Logger(ctx).Error("aws_vpc_endpoint_service.listVpcEndpointServicePermissions", "api_error", err)
+			return nil, err
 		}
+
+		allowedPrincipals = append(allowedPrincipals, output.AllowedPrincipals...)
 	}
 	return allowedPrincipals, nil
 }
@@ -340,3 +345,17 @@ func getVpcEndpointServiceTurbotTags(_ context.Context, d *transform.TransformDa
 
 	return turbotTagsMap, nil
 }
+
+func vpcEndpointServiceAllowedPrincipalArns(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	allowedPrincipals, ok := d.Value.([]types.AllowedPrincipal)
+	if !ok || len(allowedPrincipals) == 0 {
+		return nil, nil
+	}
+
+	principals := []string{}
+	for _, allowedPrincipal := range allowedPrincipals {
+		principals = append(principals, aws.ToString(allowedPrincipal.Principal))
+	}
+
+	return principals, nil
+}
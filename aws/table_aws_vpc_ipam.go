@@ -0,0 +1,248 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	ec2v1 "github.com/aws/aws-sdk-go/service/ec2"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsVpcIpam(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_vpc_ipam",
+		Description: "AWS VPC IPAM",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("ipam_id"),
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"InvalidIpamId.NotFound", "InvalidIpamId.Malformed", "InvalidAction"}),
+			},
+			Hydrate: getVpcIpam,
+			Tags:    map[string]string{"service": "ec2", "action": "DescribeIpams"},
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listVpcIpams,
+			Tags:    map[string]string{"service": "ec2", "action": "DescribeIpams"},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"InvalidAction", "UnsupportedOperation"}),
+			},
+		},
+		GetMatrixItemFunc: SupportedRegionMatrix(ec2v1.EndpointsID),
+		Columns: awsRegionalColumns([]*plugin.Column{
+			{
+				Name:        "ipam_id",
+				Description: "The ID of the IPAM.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "arn",
+				Description: "The Amazon Resource Name (ARN) of the IPAM.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("IpamArn"),
+			},
+			{
+				Name:        "state",
+				Description: "The state of the IPAM.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "description",
+				Description: "The description for the IPAM.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "ipam_region",
+				Description: "The AWS Region of the IPAM.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "owner_id",
+				Description: "The AWS account ID of the owner of the IPAM.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "private_default_scope_id",
+				Description: "The ID of the IPAM's default private scope.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "public_default_scope_id",
+				Description: "The ID of the IPAM's default public scope.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "scope_count",
+				Description: "The number of scopes in the IPAM.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "default_resource_discovery_id",
+				Description: "The IPAM's default resource discovery ID.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "default_resource_discovery_association_id",
+				Description: "The IPAM's default resource discovery association ID.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "resource_discovery_association_count",
+				Description: "The IPAM's resource discovery association count.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "operating_regions",
+				Description: "The operating Regions for the IPAM, the Regions in which the IPAM can manage IP address CIDRs.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "tags_src",
+				Description: "A list of tags assigned to the IPAM.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Tags"),
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.From(vpcIpamTitle),
+			},
+			{
+				Name:        "tags",
+				Description: resourceInterfaceDescription("tags"),
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.From(vpcIpamTurbotTags),
+			},
+			{
+				Name:        "akas",
+				Description: resourceInterfaceDescription("akas"),
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("IpamArn").Transform(transform.EnsureStringArray),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listVpcIpams(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// Create session
+	svc, err := EC2Client(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_vpc_ipam.listVpcIpams", "connection_error", err)
+		return nil, err
+	}
+
+	// Limiting the results
+	maxLimit := int32(1000)
+	if d.QueryContext.Limit != nil {
+		limit := int32(*d.QueryContext.Limit)
+		if limit < maxLimit {
+			if limit < 5 {
+				maxLimit = 5
+			} else {
+				maxLimit = limit
+			}
+		}
+	}
+
+	input := &ec2.DescribeIpamsInput{
+		MaxResults: aws.Int32(maxLimit),
+	}
+
+	paginator := ec2.NewDescribeIpamsPaginator(svc, input, func(o *ec2.DescribeIpamsPaginatorOptions) {
+		o.Limit = maxLimit
+		o.StopOnDuplicateToken = true
+	})
+
+	// List call
+	for paginator.HasMorePages() {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_vpc_ipam.listVpcIpams", "api_error", err)
+			return nil, err
+		}
+
+		for _, ipam := range output.Ipams {
+			d.StreamListItem(ctx, ipam)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getVpcIpam(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	ipamId := d.EqualsQualString("ipam_id")
+
+	// Empty check
+	if ipamId == "" {
+		return nil, nil
+	}
+
+	// Create session
+	svc, err := EC2Client(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_vpc_ipam.getVpcIpam", "connection_error", err)
+		return nil, err
+	}
+
+	// Get call
+	op, err := svc.DescribeIpams(ctx, &ec2.DescribeIpamsInput{
+		IpamIds: []string{ipamId},
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_vpc_ipam.getVpcIpam", "api_error", err)
+		return nil, err
+	}
+
+	if len(op.Ipams) > 0 {
+		return op.Ipams[0], nil
+	}
+	return nil, nil
+}
+
+//// TRANSFORM FUNCTIONS
+
+func vpcIpamTurbotTags(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	ipam := d.HydrateItem.(types.Ipam)
+
+	var turbotTagsMap map[string]string
+	if ipam.Tags != nil {
+		turbotTagsMap = map[string]string{}
+		for _, i := range ipam.Tags {
+			turbotTagsMap[*i.Key] = *i.Value
+		}
+	}
+	return turbotTagsMap, nil
+}
+
+func vpcIpamTitle(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	ipam := d.HydrateItem.(types.Ipam)
+
+	for _, tag := range ipam.Tags {
+		if aws.ToString(tag.Key) == "Name" {
+			return tag.Value, nil
+		}
+	}
+	return ipam.IpamId, nil
+}
@@ -0,0 +1,333 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	ec2v1 "github.com/aws/aws-sdk-go/service/ec2"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsVpcIpamPool(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_vpc_ipam_pool",
+		Description: "AWS VPC IPAM Pool",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("ipam_pool_id"),
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"InvalidIpamPoolId.NotFound", "InvalidIpamPoolId.Malformed", "InvalidAction"}),
+			},
+			Hydrate: getVpcIpamPool,
+			Tags:    map[string]string{"service": "ec2", "action": "DescribeIpamPools"},
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listVpcIpamPools,
+			Tags:    map[string]string{"service": "ec2", "action": "DescribeIpamPools"},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"InvalidAction", "UnsupportedOperation"}),
+			},
+		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{
+				Func: getVpcIpamPoolCidrs,
+				Tags: map[string]string{"service": "ec2", "action": "GetIpamPoolCidrs"},
+			},
+		},
+		GetMatrixItemFunc: SupportedRegionMatrix(ec2v1.EndpointsID),
+		Columns: awsRegionalColumns([]*plugin.Column{
+			{
+				Name:        "ipam_pool_id",
+				Description: "The ID of the IPAM pool.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "arn",
+				Description: "The Amazon Resource Name (ARN) of the IPAM pool.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("IpamPoolArn"),
+			},
+			{
+				Name:        "state",
+				Description: "The state of the IPAM pool.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "state_message",
+				Description: "A message related to the failed creation of an IPAM pool.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "description",
+				Description: "The description of the IPAM pool.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "address_family",
+				Description: "The address family of the pool.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "ipam_arn",
+				Description: "The ARN of the IPAM.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "ipam_region",
+				Description: "The AWS Region of the IPAM.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "ipam_scope_arn",
+				Description: "The ARN of the scope of the IPAM pool.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "ipam_scope_type",
+				Description: "The scope of the IPAM pool. Possible values are: public|private.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "locale",
+				Description: "The locale of the IPAM pool, the AWS Region where the pool is available for allocations.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "owner_id",
+				Description: "The AWS account ID of the owner of the IPAM pool.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "pool_depth",
+				Description: "The depth of pools in your IPAM pool.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "source_ipam_pool_id",
+				Description: "The ID of the source IPAM pool, for pools created from the CIDRs of another pool.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "aws_service",
+				Description: "Limits which service in AWS that the pool can be used in.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "auto_import",
+				Description: "If true, IPAM will continuously look for resources within the CIDR range of this pool and automatically import them as allocations into your IPAM.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "publicly_advertisable",
+				Description: "Determines if a pool is publicly advertisable.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "public_ip_source",
+				Description: "The IP address source for pools in the public scope.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "allocation_default_netmask_length",
+				Description: "The default netmask length for allocations added to this pool.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "allocation_min_netmask_length",
+				Description: "The minimum netmask length required for CIDR allocations in this IPAM pool.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "allocation_max_netmask_length",
+				Description: "The maximum netmask length possible for CIDR allocations in this IPAM pool.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "allocation_resource_tags",
+				Description: "Tags that are required for resources that use CIDRs from this IPAM pool.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "cidrs",
+				Description: "The CIDRs provisioned to the IPAM pool.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getVpcIpamPoolCidrs,
+				Transform:   transform.FromValue(),
+			},
+			{
+				Name:        "tags_src",
+				Description: "A list of tags assigned to the IPAM pool.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Tags"),
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.From(vpcIpamPoolTitle),
+			},
+			{
+				Name:        "tags",
+				Description: resourceInterfaceDescription("tags"),
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.From(vpcIpamPoolTurbotTags),
+			},
+			{
+				Name:        "akas",
+				Description: resourceInterfaceDescription("akas"),
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("IpamPoolArn").Transform(transform.EnsureStringArray),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listVpcIpamPools(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// Create session
+	svc, err := EC2Client(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_vpc_ipam_pool.listVpcIpamPools", "connection_error", err)
+		return nil, err
+	}
+
+	// Limiting the results
+	maxLimit := int32(1000)
+	if d.QueryContext.Limit != nil {
+		limit := int32(*d.QueryContext.Limit)
+		if limit < maxLimit {
+			if limit < 5 {
+				maxLimit = 5
+			} else {
+				maxLimit = limit
+			}
+		}
+	}
+
+	input := &ec2.DescribeIpamPoolsInput{
+		MaxResults: aws.Int32(maxLimit),
+	}
+
+	paginator := ec2.NewDescribeIpamPoolsPaginator(svc, input, func(o *ec2.DescribeIpamPoolsPaginatorOptions) {
+		o.Limit = maxLimit
+		o.StopOnDuplicateToken = true
+	})
+
+	// List call
+	for paginator.HasMorePages() {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_vpc_ipam_pool.listVpcIpamPools", "api_error", err)
+			return nil, err
+		}
+
+		for _, pool := range output.IpamPools {
+			d.StreamListItem(ctx, pool)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getVpcIpamPool(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	poolId := d.EqualsQualString("ipam_pool_id")
+
+	// Empty check
+	if poolId == "" {
+		return nil, nil
+	}
+
+	// Create session
+	svc, err := EC2Client(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_vpc_ipam_pool.getVpcIpamPool", "connection_error", err)
+		return nil, err
+	}
+
+	// Get call
+	op, err := svc.DescribeIpamPools(ctx, &ec2.DescribeIpamPoolsInput{
+		IpamPoolIds: []string{poolId},
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_vpc_ipam_pool.getVpcIpamPool", "api_error", err)
+		return nil, err
+	}
+
+	if len(op.IpamPools) > 0 {
+		return op.IpamPools[0], nil
+	}
+	return nil, nil
+}
+
+func getVpcIpamPoolCidrs(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	pool := h.Item.(types.IpamPool)
+
+	// Create session
+	svc, err := EC2Client(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_vpc_ipam_pool.getVpcIpamPoolCidrs", "connection_error", err)
+		return nil, err
+	}
+
+	var cidrs []types.IpamPoolCidr
+	paginator := ec2.NewGetIpamPoolCidrsPaginator(svc, &ec2.GetIpamPoolCidrsInput{
+		IpamPoolId: pool.IpamPoolId,
+		MaxResults: aws.Int32(1000),
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_vpc_ipam_pool.getVpcIpamPoolCidrs", "api_error", err)
+			return nil, err
+		}
+		cidrs = append(cidrs, output.IpamPoolCidrs...)
+	}
+
+	return cidrs, nil
+}
+
+//// TRANSFORM FUNCTIONS
+
+func vpcIpamPoolTurbotTags(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	pool := d.HydrateItem.(types.IpamPool)
+
+	var turbotTagsMap map[string]string
+	if pool.Tags != nil {
+		turbotTagsMap = map[string]string{}
+		for _, i := range pool.Tags {
+			turbotTagsMap[*i.Key] = *i.Value
+		}
+	}
+	return turbotTagsMap, nil
+}
+
+func vpcIpamPoolTitle(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	pool := d.HydrateItem.(types.IpamPool)
+
+	for _, tag := range pool.Tags {
+		if aws.ToString(tag.Key) == "Name" {
+			return tag.Value, nil
+		}
+	}
+	return pool.IpamPoolId, nil
+}
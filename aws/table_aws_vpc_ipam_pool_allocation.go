@@ -0,0 +1,168 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	ec2v1 "github.com/aws/aws-sdk-go/service/ec2"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsVpcIpamPoolAllocation(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_vpc_ipam_pool_allocation",
+		Description: "AWS VPC IPAM Pool Allocation",
+		List: &plugin.ListConfig{
+			ParentHydrate: listVpcIpamPools,
+			Hydrate:       listVpcIpamPoolAllocations,
+			Tags:          map[string]string{"service": "ec2", "action": "GetIpamPoolAllocations"},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"InvalidAction", "UnsupportedOperation", "InvalidIpamPoolId.NotFound"}),
+			},
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "ipam_pool_id", Require: plugin.Optional},
+				{Name: "ipam_pool_allocation_id", Require: plugin.Optional},
+			},
+		},
+		GetMatrixItemFunc: SupportedRegionMatrix(ec2v1.EndpointsID),
+		Columns: awsRegionalColumns([]*plugin.Column{
+			{
+				Name:        "ipam_pool_allocation_id",
+				Description: "The ID of the allocation.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "ipam_pool_id",
+				Description: "The ID of the IPAM pool of the allocation.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "cidr",
+				Description: "The CIDR of the allocation.",
+				Type:        proto.ColumnType_CIDR,
+			},
+			{
+				Name:        "description",
+				Description: "A description of the allocation.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "resource_id",
+				Description: "The ID of the resource the CIDR is allocated to.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "resource_type",
+				Description: "The type of the resource the CIDR is allocated to.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "resource_owner",
+				Description: "The owner of the resource the CIDR is allocated to.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "resource_region",
+				Description: "The AWS Region of the resource the CIDR is allocated to.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "ipam_pool_arn",
+				Description: "The ARN of the IPAM pool of the allocation.",
+				Type:        proto.ColumnType_STRING,
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("IpamPoolAllocationId"),
+			},
+		}),
+	}
+}
+
+type vpcIpamPoolAllocation struct {
+	types.IpamPoolAllocation
+	IpamPoolId  *string
+	IpamPoolArn *string
+}
+
+//// LIST FUNCTION
+
+func listVpcIpamPoolAllocations(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	pool := h.Item.(types.IpamPool)
+
+	if d.EqualsQualString("ipam_pool_id") != "" && d.EqualsQualString("ipam_pool_id") != aws.ToString(pool.IpamPoolId) {
+		return nil, nil
+	}
+
+	// Create session
+	svc, err := EC2Client(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_vpc_ipam_pool_allocation.listVpcIpamPoolAllocations", "connection_error", err)
+		return nil, err
+	}
+
+	// Limiting the results
+	maxLimit := int32(1000)
+	if d.QueryContext.Limit != nil {
+		limit := int32(*d.QueryContext.Limit)
+		if limit < maxLimit {
+			if limit < 5 {
+				maxLimit = 5
+			} else {
+				maxLimit = limit
+			}
+		}
+	}
+
+	input := &ec2.GetIpamPoolAllocationsInput{
+		IpamPoolId: pool.IpamPoolId,
+		MaxResults: aws.Int32(maxLimit),
+	}
+	if d.EqualsQualString("ipam_pool_allocation_id") != "" {
+		input.IpamPoolAllocationId = aws.String(d.EqualsQualString("ipam_pool_allocation_id"))
+	}
+
+	paginator := ec2.NewGetIpamPoolAllocationsPaginator(svc, input, func(o *ec2.GetIpamPoolAllocationsPaginatorOptions) {
+		o.Limit = maxLimit
+		o.StopOnDuplicateToken = true
+	})
+
+	// List call
+	for paginator.HasMorePages() {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_vpc_ipam_pool_allocation.listVpcIpamPoolAllocations", "api_error", err)
+			return nil, err
+		}
+
+		for _, allocation := range output.IpamPoolAllocations {
+			d.StreamListItem(ctx, vpcIpamPoolAllocation{
+				IpamPoolAllocation: allocation,
+				IpamPoolId:         pool.IpamPoolId,
+				IpamPoolArn:        pool.IpamPoolArn,
+			})
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
@@ -18,7 +18,8 @@ func tableAwsVpcNatGatewayMetricBytesOutToDestination(_ context.Context) *plugin
 		List: &plugin.ListConfig{
 			ParentHydrate: listVpcNatGateways,
 			Hydrate:       listVpcNatGatewayMetricBytesOutToDestination,
-			Tags:          map[string]string{"service": "cloudwatch", "action": "GetMetricStatistics"},
+			Tags:          map[string]string{"service": "cloudwatch", "action": "GetMetricData"},
+			KeyColumns:    cwMetricKeyColumns(),
 		},
 		GetMatrixItemFunc: CloudWatchRegionsMatrix,
 		Columns: awsRegionalColumns(cwMetricColumns(
@@ -0,0 +1,911 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+
+	ec2v1 "github.com/aws/aws-sdk-go/service/ec2"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// The number of hops after which a path is assumed to loop
+const vpcReachabilityMaxHops = 32
+
+// The component types traffic leaves the region's VPCs through, by the prefix
+// of the route target
+var vpcRouteTargetTypes = map[string]string{
+	"igw-":  "internet_gateway",
+	"eigw-": "egress_only_internet_gateway",
+	"vgw-":  "vpn_gateway",
+	"vpce-": "vpc_endpoint",
+	"cagw-": "carrier_gateway",
+	"lgw-":  "local_gateway",
+	"arn:":  "core_network",
+}
+
+//// TABLE DEFINITION
+
+func tableAwsVpcReachability(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_vpc_reachability",
+		Description: "AWS VPC Reachability",
+		List: &plugin.ListConfig{
+			Hydrate: listVpcReachability,
+			Tags:    map[string]string{"service": "ec2", "action": "DescribeNetworkInterfaces"},
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "source", Require: plugin.Required},
+				{Name: "destination", Require: plugin.Required},
+				{Name: "protocol", Require: plugin.Optional},
+				{Name: "port", Require: plugin.Optional},
+			},
+		},
+		GetMatrixItemFunc: SupportedRegionMatrix(ec2v1.EndpointsID),
+		Columns: awsRegionalColumns([]*plugin.Column{
+			{
+				Name:        "source",
+				Description: "The source of the traffic: a network interface ID, instance ID, the ARN of an EC2 instance, network interface, load balancer, Lambda function, RDS DB instance, ElastiCache cluster or OpenSearch domain, or IP address.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "destination",
+				Description: "The destination of the traffic: a network interface ID, instance ID, the ARN of an EC2 instance, network interface, load balancer, Lambda function, RDS DB instance, ElastiCache cluster or OpenSearch domain, or IP address.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "protocol",
+				Description: "The protocol of the traffic, e.g. tcp, udp, icmp, all or a protocol number. Defaults to tcp.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "port",
+				Description: "The destination port of the traffic. Required for tcp and udp traffic.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "hop",
+				Description: "The position of the hop in the path, starting at 1.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "component_type",
+				Description: "The type of the component of the hop, e.g. security_group, network_acl, route_table, transit_gateway_route_table or network_interface.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "component_id",
+				Description: "The ID of the component of the hop.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ComponentId").NullIfZero(),
+			},
+			{
+				Name:        "action",
+				Description: "What the component does with the traffic. Possible values are: allow|deny|forward|unknown. The path ends at a deny or unknown hop.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "detail",
+				Description: "A description of the outcome of the hop.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "rule",
+				Description: "The security group rule, network ACL entry or route the outcome of the hop is based on.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "vpc_id",
+				Description: "The ID of the VPC of the hop.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("VpcId").NullIfZero(),
+			},
+			{
+				Name:        "subnet_id",
+				Description: "The ID of the subnet of the hop.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("SubnetId").NullIfZero(),
+			},
+			{
+				Name:        "reachable",
+				Description: "True if the destination is reachable from the source.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "source_address",
+				Description: "The address of the source the traffic is evaluated for.",
+				Type:        proto.ColumnType_IPADDR,
+			},
+			{
+				Name:        "source_network_interface_id",
+				Description: "The ID of the network interface of the source, if the source is in the region.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("SourceNetworkInterfaceId").NullIfZero(),
+			},
+			{
+				Name:        "destination_address",
+				Description: "The address of the destination the traffic is evaluated for.",
+				Type:        proto.ColumnType_IPADDR,
+			},
+			{
+				Name:        "destination_network_interface_id",
+				Description: "The ID of the network interface of the destination, if the destination is in the region.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("DestinationNetworkInterfaceId").NullIfZero(),
+			},
+		}),
+	}
+}
+
+type vpcReachabilityHop struct {
+	Source                        string
+	Destination                   string
+	Protocol                      string
+	Port                          *int32
+	Hop                           int
+	ComponentType                 string
+	ComponentId                   string
+	Action                        string
+	Detail                        string
+	Rule                          interface{}
+	VpcId                         string
+	SubnetId                      string
+	Reachable                     bool
+	SourceAddress                 string
+	SourceNetworkInterfaceId      string
+	DestinationAddress            string
+	DestinationNetworkInterfaceId string
+}
+
+//// LIST FUNCTION
+
+func listVpcReachability(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	protocol := d.EqualsQualString("protocol")
+	if protocol == "" {
+		protocol = "tcp"
+	}
+	var port *int32
+	if d.EqualsQuals["port"] != nil {
+		port = aws.Int32(int32(d.EqualsQuals["port"].GetInt64Value()))
+	}
+	protocolNumber := vpcNetworkProtocol(protocol)
+	if port == nil && (protocolNumber == "6" || protocolNumber == "17") {
+		return nil, fmt.Errorf("port must be specified for %s traffic", protocol)
+	}
+
+	network, err := getVpcNetwork(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := resolveVpcReachabilityEndpoint(ctx, d, network, d.EqualsQualString("source"))
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_vpc_reachability.listVpcReachability", "source_error", err)
+		return nil, err
+	}
+	destination, err := resolveVpcReachabilityEndpoint(ctx, d, network, d.EqualsQualString("destination"))
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_vpc_reachability.listVpcReachability", "destination_error", err)
+		return nil, err
+	}
+
+	// The path is evaluated in the region of the source, or of the destination
+	// for traffic from outside the region's VPCs
+	if source == nil || destination == nil || (source.NetworkInterface == nil && destination.NetworkInterface == nil) {
+		return nil, nil
+	}
+
+	// Use an address of the network interface of the same IP version as the
+	// other endpoint
+	if source.NetworkInterface != nil && source.Address.Is6() != destination.Address.Is6() {
+		source.Address = networkInterfaceAddress(*source.NetworkInterface, destination.Address.Is6())
+	}
+	if destination.NetworkInterface != nil && destination.Address.Is6() != source.Address.Is6() {
+		destination.Address = networkInterfaceAddress(*destination.NetworkInterface, source.Address.Is6())
+	}
+	if !source.Address.IsValid() || !destination.Address.IsValid() || source.Address.Is6() != destination.Address.Is6() {
+		return nil, fmt.Errorf("the source and destination have no addresses of the same IP version")
+	}
+
+	trace := &vpcReachabilityTrace{
+		network:              network,
+		protocol:             protocolNumber,
+		port:                 -1,
+		source:               source.Address,
+		sourceInterface:      source.NetworkInterface,
+		destination:          destination.Address,
+		destinationInterface: destination.NetworkInterface,
+	}
+	if port != nil {
+		trace.port = *port
+	}
+	reachable := trace.run()
+
+	for i, hop := range trace.hops {
+		hop.Source = d.EqualsQualString("source")
+		hop.Destination = d.EqualsQualString("destination")
+		hop.Protocol = protocol
+		hop.Port = port
+		hop.Hop = i + 1
+		hop.Reachable = reachable
+		hop.SourceAddress = source.Address.String()
+		hop.DestinationAddress = destination.Address.String()
+		if source.NetworkInterface != nil {
+			hop.SourceNetworkInterfaceId = aws.ToString(source.NetworkInterface.NetworkInterfaceId)
+		}
+		if destination.NetworkInterface != nil {
+			hop.DestinationNetworkInterfaceId = aws.ToString(destination.NetworkInterface.NetworkInterfaceId)
+		}
+		d.StreamListItem(ctx, hop)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// resolveVpcReachabilityEndpoint resolves a source or destination. The
+// network interfaces of an RDS DB instance don't identify it, so the ARN of a
+// DB instance is resolved to the address of its endpoint instead.
+func resolveVpcReachabilityEndpoint(ctx context.Context, d *plugin.QueryData, network *vpcNetwork, value string) (*vpcNetworkEndpoint, error) {
+	resourceArn, err := arn.Parse(value)
+	if err != nil || resourceArn.Service != "rds" || resourceArn.Region != network.Region {
+		return network.resolveEndpoint(value)
+	}
+	dbInstanceIdentifier, ok := strings.CutPrefix(resourceArn.Resource, "db:")
+	if !ok {
+		return network.resolveEndpoint(value)
+	}
+
+	svc, err := RDSClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_vpc_reachability.resolveVpcReachabilityEndpoint", "connection_error", err)
+		return nil, err
+	}
+	output, err := svc.DescribeDBInstances(ctx, &rds.DescribeDBInstancesInput{
+		DBInstanceIdentifier: aws.String(dbInstanceIdentifier),
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_vpc_reachability.resolveVpcReachabilityEndpoint", "api_error", err)
+		return nil, err
+	}
+	if len(output.DBInstances) == 0 || output.DBInstances[0].Endpoint == nil {
+		return nil, fmt.Errorf("%s has no endpoint, use its IP address instead", value)
+	}
+
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", aws.ToString(output.DBInstances[0].Endpoint.Address))
+	if err != nil || len(addrs) == 0 {
+		return nil, fmt.Errorf("the endpoint of %s can't be resolved, use its IP address instead", value)
+	}
+	return network.resolveEndpoint(addrs[0].Unmap().String())
+}
+
+//// PATH EVALUATION
+
+// vpcReachabilityTrace follows traffic from its source, recording each
+// component it passes
+type vpcReachabilityTrace struct {
+	network  *vpcNetwork
+	protocol string
+	port     int32
+
+	// The source address changes when traffic passes a NAT gateway, after
+	// which the source network interface is unset
+	source               netip.Addr
+	sourceInterface      *types.NetworkInterface
+	destination          netip.Addr
+	destinationInterface *types.NetworkInterface
+
+	hops []*vpcReachabilityHop
+
+	// The network ACLs that allowed the request, which must also allow the
+	// responses
+	networkAcls []vpcReachabilityNetworkAcl
+}
+
+// vpcReachabilityNetworkAcl is a network ACL traffic passed, in the direction
+// of the request
+type vpcReachabilityNetworkAcl struct {
+	VpcId    string
+	SubnetId string
+	Egress   bool
+	Peer     netip.Addr
+}
+
+// add records a hop, and returns whether the traffic passed it
+func (t *vpcReachabilityTrace) add(hop *vpcReachabilityHop) bool {
+	t.hops = append(t.hops, hop)
+	return hop.Action != "deny" && hop.Action != "unknown"
+}
+
+// run follows the traffic and its responses, and returns whether the
+// destination is reachable
+func (t *vpcReachabilityTrace) run() bool {
+	return t.request() && t.respond()
+}
+
+// request follows the traffic, and returns whether it reaches the
+// destination
+func (t *vpcReachabilityTrace) request() bool {
+	if t.sourceInterface == nil {
+		return t.runFromInternet()
+	}
+
+	src := t.sourceInterface
+	vpcId, subnetId := aws.ToString(src.VpcId), aws.ToString(src.SubnetId)
+	dst := t.destinationInterface
+
+	var peerGroups []types.GroupIdentifier
+	if dst != nil && t.network.vpcsPeered(vpcId, aws.ToString(dst.VpcId)) {
+		peerGroups = dst.Groups
+	}
+	if !t.add(t.securityGroupHop(src, true, t.destination, peerGroups)) {
+		return false
+	}
+
+	// Traffic within a subnet doesn't pass its route table and network ACL
+	if dst != nil && aws.ToString(dst.SubnetId) == subnetId {
+		return t.deliver(vpcId, subnetId)
+	}
+	if !t.add(t.networkAclHop(vpcId, subnetId, true, t.destination)) {
+		return false
+	}
+
+	public := t.destination.Is6() || len(networkInterfacePublicAddresses(*src)) > 0
+	return t.route(vpcId, subnetId, public)
+}
+
+// runFromInternet follows traffic from outside the region's VPCs to a
+// network interface through the internet gateway of its VPC
+func (t *vpcReachabilityTrace) runFromInternet() bool {
+	dst := t.destinationInterface
+	vpcId, subnetId := aws.ToString(dst.VpcId), aws.ToString(dst.SubnetId)
+
+	if t.destination.Is4() && len(networkInterfacePublicAddresses(*dst)) == 0 {
+		return t.add(&vpcReachabilityHop{
+			ComponentType: "network_interface",
+			ComponentId:   aws.ToString(dst.NetworkInterfaceId),
+			Action:        "deny",
+			Detail:        "The destination has no public IPv4 address.",
+			VpcId:         vpcId,
+			SubnetId:      subnetId,
+		})
+	}
+
+	internetGateway := t.network.vpcInternetGateway(vpcId)
+	if internetGateway == nil {
+		return t.add(&vpcReachabilityHop{
+			ComponentType: "vpc",
+			ComponentId:   vpcId,
+			Action:        "deny",
+			Detail:        "The VPC has no internet gateway.",
+			VpcId:         vpcId,
+		})
+	}
+	internetGatewayId := aws.ToString(internetGateway.InternetGatewayId)
+	t.add(&vpcReachabilityHop{
+		ComponentType: "internet_gateway",
+		ComponentId:   internetGatewayId,
+		Action:        "forward",
+		Detail:        fmt.Sprintf("Forwarded to %s.", t.destination),
+		VpcId:         vpcId,
+	})
+
+	// Responses are only returned if the subnet routes them to the internet
+	// gateway
+	routeTable := t.network.subnetRouteTable(vpcId, subnetId)
+	if routeTable == nil {
+		return t.add(&vpcReachabilityHop{
+			ComponentType: "route_table",
+			Action:        "deny",
+			Detail:        "The subnet has no route table.",
+			VpcId:         vpcId,
+			SubnetId:      subnetId,
+		})
+	}
+	hop := &vpcReachabilityHop{
+		ComponentType: "route_table",
+		ComponentId:   aws.ToString(routeTable.RouteTableId),
+		Action:        "deny",
+		Detail:        fmt.Sprintf("Responses to %s are not routed to the internet gateway.", t.source),
+		VpcId:         vpcId,
+		SubnetId:      subnetId,
+	}
+	if route := t.network.selectRoute(routeTable, t.source); route != nil {
+		hop.Rule = route
+		if vpcRouteTarget(route) == internetGatewayId && route.State != types.RouteStateBlackhole {
+			hop.Action = "allow"
+			hop.Detail = fmt.Sprintf("Responses to %s are routed to the internet gateway.", t.source)
+		}
+	}
+	if !t.add(hop) {
+		return false
+	}
+
+	return t.deliver(vpcId, "")
+}
+
+// route follows traffic leaving a subnet through the subnet's route table,
+// and the components the routes lead to
+func (t *vpcReachabilityTrace) route(vpcId string, subnetId string, public bool) bool {
+	for len(t.hops) < vpcReachabilityMaxHops {
+		routeTable := t.network.subnetRouteTable(vpcId, subnetId)
+		if routeTable == nil {
+			return t.add(&vpcReachabilityHop{
+				ComponentType: "route_table",
+				Action:        "deny",
+				Detail:        "The subnet has no route table.",
+				VpcId:         vpcId,
+				SubnetId:      subnetId,
+			})
+		}
+
+		hop := &vpcReachabilityHop{
+			ComponentType: "route_table",
+			ComponentId:   aws.ToString(routeTable.RouteTableId),
+			Action:        "deny",
+			Detail:        fmt.Sprintf("No route matches %s.", t.destination),
+			VpcId:         vpcId,
+			SubnetId:      subnetId,
+		}
+		route := t.network.selectRoute(routeTable, t.destination)
+		if route == nil {
+			return t.add(hop)
+		}
+		target := vpcRouteTarget(route)
+		hop.Rule = route
+		hop.Detail = fmt.Sprintf("Route to %s via %s is a blackhole.", vpcRouteDestination(route), target)
+		if route.State == types.RouteStateBlackhole {
+			return t.add(hop)
+		}
+		hop.Action = "forward"
+		hop.Detail = fmt.Sprintf("Route to %s via %s.", vpcRouteDestination(route), target)
+		t.add(hop)
+
+		switch {
+		case target == "local":
+			return t.deliver(vpcId, subnetId)
+
+		case strings.HasPrefix(target, "igw-") && !public:
+			return t.add(&vpcReachabilityHop{
+				ComponentType: "internet_gateway",
+				ComponentId:   target,
+				Action:        "deny",
+				Detail:        "The source has no public IPv4 address.",
+				VpcId:         vpcId,
+			})
+
+		case strings.HasPrefix(target, "nat-"):
+			natInterface := t.network.natGatewayNetworkInterface(target)
+			if natInterface == nil {
+				return t.add(&vpcReachabilityHop{
+					ComponentType: "nat_gateway",
+					ComponentId:   target,
+					Action:        "unknown",
+					Detail:        "The network interface of the NAT gateway was not found.",
+					VpcId:         vpcId,
+				})
+			}
+
+			// The NAT gateway replaces the source address with its own, and
+			// sends the traffic on from its subnet
+			t.source = networkInterfaceAddress(*natInterface, t.destination.Is6())
+			t.sourceInterface = nil
+			vpcId, subnetId = aws.ToString(natInterface.VpcId), aws.ToString(natInterface.SubnetId)
+			public = len(networkInterfacePublicAddresses(*natInterface)) > 0
+			t.add(&vpcReachabilityHop{
+				ComponentType: "nat_gateway",
+				ComponentId:   target,
+				Action:        "forward",
+				Detail:        fmt.Sprintf("Source address translated to %s.", t.source),
+				VpcId:         vpcId,
+				SubnetId:      subnetId,
+			})
+			if !t.add(t.networkAclHop(vpcId, subnetId, true, t.destination)) {
+				return false
+			}
+
+		case strings.HasPrefix(target, "pcx-"):
+			peerVpcId := t.network.peeredVpcId(target, vpcId)
+			if peerVpcId == "" {
+				return t.add(&vpcReachabilityHop{
+					ComponentType: "vpc_peering_connection",
+					ComponentId:   target,
+					Action:        "unknown",
+					Detail:        "The peering connection is not active, or the peer VPC is in another region.",
+					VpcId:         vpcId,
+				})
+			}
+			t.add(&vpcReachabilityHop{
+				ComponentType: "vpc_peering_connection",
+				ComponentId:   target,
+				Action:        "forward",
+				Detail:        fmt.Sprintf("Forwarded to %s.", peerVpcId),
+				VpcId:         vpcId,
+			})
+
+			// Traffic from a peering connection is delivered within the peer VPC
+			return t.deliver(peerVpcId, "")
+
+		case strings.HasPrefix(target, "tgw-"):
+			attachment := t.routeTransitGateway(target, vpcId)
+			if attachment == nil {
+				return false
+			}
+			if attachment.ResourceType != types.TransitGatewayAttachmentResourceTypeVpc {
+				return t.leave("transit_gateway_attachment", aws.ToString(attachment.TransitGatewayAttachmentId), "")
+			}
+
+			// Security group rules don't match the groups of network
+			// interfaces across a transit gateway. Routing continues with the
+			// route table of the attachment's subnet in the VPC.
+			t.sourceInterface = nil
+			vpcId = aws.ToString(attachment.ResourceId)
+			subnetId = t.transitGatewaySubnet(aws.ToString(attachment.TransitGatewayAttachmentId))
+
+		case strings.HasPrefix(target, "eni-"), strings.HasPrefix(target, "i-"):
+			networkInterface := t.network.networkInterface(target)
+			if strings.HasPrefix(target, "i-") {
+				networkInterface = t.network.instanceNetworkInterface(target)
+			}
+			if networkInterface == nil {
+				return t.add(&vpcReachabilityHop{
+					ComponentType: "network_interface",
+					ComponentId:   target,
+					Action:        "unknown",
+					Detail:        "The network interface was not found.",
+					VpcId:         vpcId,
+				})
+			}
+
+			// Appliances, e.g. firewalls, send the traffic on from their subnet
+			vpcId, subnetId = aws.ToString(networkInterface.VpcId), aws.ToString(networkInterface.SubnetId)
+			public = t.destination.Is6() || len(networkInterfacePublicAddresses(*networkInterface)) > 0
+			t.add(&vpcReachabilityHop{
+				ComponentType: "network_interface",
+				ComponentId:   aws.ToString(networkInterface.NetworkInterfaceId),
+				Action:        "forward",
+				Detail:        "Forwarded to the appliance of the network interface.",
+				VpcId:         vpcId,
+				SubnetId:      subnetId,
+			})
+
+		default:
+			componentType := "gateway"
+			for prefix, targetType := range vpcRouteTargetTypes {
+				if strings.HasPrefix(target, prefix) {
+					componentType = targetType
+				}
+			}
+			return t.leave(componentType, target, vpcId)
+		}
+	}
+
+	return t.add(&vpcReachabilityHop{
+		ComponentType: "route_table",
+		Action:        "deny",
+		Detail:        fmt.Sprintf("The path exceeds %d hops, the routes may loop.", vpcReachabilityMaxHops),
+		VpcId:         vpcId,
+		SubnetId:      subnetId,
+	})
+}
+
+// routeTransitGateway follows traffic from a VPC through the route table its
+// transit gateway attachment is associated with, and returns the attachment
+// the traffic is sent to
+func (t *vpcReachabilityTrace) routeTransitGateway(transitGatewayId string, vpcId string) *types.TransitGatewayRouteAttachment {
+	attachment := t.network.transitGatewayAttachment(transitGatewayId, vpcId)
+	if attachment == nil {
+		t.add(&vpcReachabilityHop{
+			ComponentType: "transit_gateway",
+			ComponentId:   transitGatewayId,
+			Action:        "deny",
+			Detail:        "The VPC has no available attachment to the transit gateway.",
+			VpcId:         vpcId,
+		})
+		return nil
+	}
+	if attachment.Association == nil || attachment.Association.TransitGatewayRouteTableId == nil {
+		t.add(&vpcReachabilityHop{
+			ComponentType: "transit_gateway_attachment",
+			ComponentId:   aws.ToString(attachment.TransitGatewayAttachmentId),
+			Action:        "deny",
+			Detail:        "The attachment is not associated with a route table.",
+			VpcId:         vpcId,
+		})
+		return nil
+	}
+
+	routeTableId := aws.ToString(attachment.Association.TransitGatewayRouteTableId)
+	hop := &vpcReachabilityHop{
+		ComponentType: "transit_gateway_route_table",
+		ComponentId:   routeTableId,
+		Action:        "deny",
+		Detail:        fmt.Sprintf("No route matches %s.", t.destination),
+	}
+	route := t.network.selectTransitGatewayRoute(routeTableId, t.destination)
+	if route == nil {
+		t.add(hop)
+		return nil
+	}
+	hop.Rule = route
+	if t.network.IncompleteTransitGatewayRouteTables[routeTableId] {
+		hop.Action = "unknown"
+		hop.Detail = fmt.Sprintf("Route to %s, but the route table has more routes than a search returns, a more specific route may not be evaluated.", vpcTransitGatewayRouteDestination(route))
+		t.add(hop)
+		return nil
+	}
+	hop.Detail = fmt.Sprintf("Route to %s is a blackhole.", vpcTransitGatewayRouteDestination(route))
+	if route.State == types.TransitGatewayRouteStateBlackhole || len(route.TransitGatewayAttachments) == 0 {
+		t.add(hop)
+		return nil
+	}
+
+	// Of equal cost routes, the first is followed
+	target := route.TransitGatewayAttachments[0]
+	hop.Action = "forward"
+	hop.Detail = fmt.Sprintf("Route to %s via %s (%s %s).", vpcTransitGatewayRouteDestination(route), aws.ToString(target.TransitGatewayAttachmentId), target.ResourceType, aws.ToString(target.ResourceId))
+	t.add(hop)
+
+	if target.ResourceType == types.TransitGatewayAttachmentResourceTypePeering || target.ResourceType == types.TransitGatewayAttachmentResourceTypeTgwPeering {
+		t.add(&vpcReachabilityHop{
+			ComponentType: "transit_gateway_attachment",
+			ComponentId:   aws.ToString(target.TransitGatewayAttachmentId),
+			Action:        "unknown",
+			Detail:        "The route is to a transit gateway peering, paths across regions are not evaluated.",
+		})
+		return nil
+	}
+
+	return &target
+}
+
+// transitGatewaySubnet returns the subnet of a VPC attachment traffic enters
+// the VPC through, preferring the availability zone of the destination
+func (t *vpcReachabilityTrace) transitGatewaySubnet(attachmentId string) string {
+	subnetIds := t.network.TransitGatewayVpcAttachments[attachmentId].SubnetIds
+	if len(subnetIds) == 0 {
+		return ""
+	}
+	if t.destinationInterface != nil {
+		for _, subnetId := range subnetIds {
+			if aws.ToString(t.network.Subnets[subnetId].AvailabilityZone) == aws.ToString(t.destinationInterface.AvailabilityZone) {
+				return subnetId
+			}
+		}
+	}
+	return subnetIds[0]
+}
+
+// leave records traffic leaving the region's VPCs, which only reaches
+// destinations outside of them
+func (t *vpcReachabilityTrace) leave(componentType string, componentId string, vpcId string) bool {
+	if t.destinationInterface != nil {
+		return t.add(&vpcReachabilityHop{
+			ComponentType: componentType,
+			ComponentId:   componentId,
+			Action:        "unknown",
+			Detail:        "The traffic leaves the region's VPCs, paths back to the destination are not evaluated.",
+			VpcId:         vpcId,
+		})
+	}
+	return t.add(&vpcReachabilityHop{
+		ComponentType: componentType,
+		ComponentId:   componentId,
+		Action:        "allow",
+		Detail:        fmt.Sprintf("Forwarded to %s.", t.destination),
+		VpcId:         vpcId,
+	})
+}
+
+// deliver follows traffic within a VPC to the destination, through the
+// network ACL of its subnet unless the traffic is from the same subnet
+func (t *vpcReachabilityTrace) deliver(vpcId string, subnetId string) bool {
+	dst := t.destinationInterface
+	if dst == nil || aws.ToString(dst.VpcId) != vpcId {
+		return t.add(&vpcReachabilityHop{
+			ComponentType: "vpc",
+			ComponentId:   vpcId,
+			Action:        "deny",
+			Detail:        fmt.Sprintf("No network interface of the VPC has the address %s.", t.destination),
+			VpcId:         vpcId,
+		})
+	}
+
+	dstSubnetId := aws.ToString(dst.SubnetId)
+	if dstSubnetId != subnetId && !t.add(t.networkAclHop(vpcId, dstSubnetId, false, t.source)) {
+		return false
+	}
+
+	var peerGroups []types.GroupIdentifier
+	if src := t.sourceInterface; src != nil && t.network.vpcsPeered(vpcId, aws.ToString(src.VpcId)) {
+		peerGroups = src.Groups
+	}
+	if !t.add(t.securityGroupHop(dst, false, t.source, peerGroups)) {
+		return false
+	}
+
+	return t.add(&vpcReachabilityHop{
+		ComponentType: "network_interface",
+		ComponentId:   aws.ToString(dst.NetworkInterfaceId),
+		Action:        "allow",
+		Detail:        "Delivered to the destination.",
+		VpcId:         vpcId,
+		SubnetId:      dstSubnetId,
+	})
+}
+
+// securityGroupHop evaluates the security groups of a network interface for
+// traffic to or from a peer. Security groups are stateful, so only the
+// direction of the request is evaluated.
+func (t *vpcReachabilityTrace) securityGroupHop(networkInterface *types.NetworkInterface, egress bool, peer netip.Addr, peerGroups []types.GroupIdentifier) *vpcReachabilityHop {
+	direction := "ingress"
+	if egress {
+		direction = "egress"
+	}
+
+	hop := &vpcReachabilityHop{
+		ComponentType: "security_group",
+		VpcId:         aws.ToString(networkInterface.VpcId),
+		SubnetId:      aws.ToString(networkInterface.SubnetId),
+	}
+	rule := t.network.matchSecurityGroupRule(networkInterface.Groups, egress, t.protocol, t.port, peer, peerGroups)
+	if rule == nil {
+		groupIds := make([]string, 0, len(networkInterface.Groups))
+		for _, group := range networkInterface.Groups {
+			groupIds = append(groupIds, aws.ToString(group.GroupId))
+		}
+		hop.ComponentId = strings.Join(groupIds, ",")
+		hop.Action = "deny"
+		hop.Detail = fmt.Sprintf("No %s rule of the security groups of %s allows the traffic.", direction, aws.ToString(networkInterface.NetworkInterfaceId))
+		return hop
+	}
+
+	hop.ComponentId = aws.ToString(rule.GroupId)
+	hop.Action = "allow"
+	hop.Detail = fmt.Sprintf("The %s rule %s allows the traffic.", direction, aws.ToString(rule.SecurityGroupRuleId))
+	hop.Rule = rule
+	return hop
+}
+
+// networkAclHop evaluates the network ACL of a subnet for traffic to or from
+// a peer. Network ACLs are stateless, so the network ACLs that allow the
+// request are evaluated again for the responses.
+func (t *vpcReachabilityTrace) networkAclHop(vpcId string, subnetId string, egress bool, peer netip.Addr) *vpcReachabilityHop {
+	hop := &vpcReachabilityHop{
+		ComponentType: "network_acl",
+		VpcId:         vpcId,
+		SubnetId:      subnetId,
+	}
+	networkAcl := t.network.subnetNetworkAcl(subnetId)
+	if networkAcl == nil {
+		hop.Action = "unknown"
+		hop.Detail = "The network ACL of the subnet was not found."
+		return hop
+	}
+	hop.ComponentId = aws.ToString(networkAcl.NetworkAclId)
+
+	entry := t.network.matchNetworkAclEntry(networkAcl, egress, t.protocol, t.port, peer)
+	if entry == nil {
+		hop.Action = "deny"
+		hop.Detail = "No entry matches the traffic."
+		return hop
+	}
+	hop.Rule = entry
+	hop.Action = "deny"
+	hop.Detail = fmt.Sprintf("Entry %d denies the traffic.", aws.ToInt32(entry.RuleNumber))
+	if entry.RuleAction == types.RuleActionAllow {
+		hop.Action = "allow"
+		hop.Detail = fmt.Sprintf("Entry %d allows the traffic.", aws.ToInt32(entry.RuleNumber))
+		t.networkAcls = append(t.networkAcls, vpcReachabilityNetworkAcl{VpcId: vpcId, SubnetId: subnetId, Egress: egress, Peer: peer})
+	}
+	return hop
+}
+
+// respond follows the responses back through the network ACLs that allowed
+// the request, in the reverse order and direction, and returns whether they
+// reach the source
+func (t *vpcReachabilityTrace) respond() bool {
+	for i := len(t.networkAcls) - 1; i >= 0; i-- {
+		if !t.add(t.networkAclResponseHop(t.networkAcls[i])) {
+			return false
+		}
+	}
+	return true
+}
+
+// networkAclResponseHop evaluates a network ACL for the responses to traffic
+// it allowed. TCP and UDP responses are sent to the ephemeral port of the
+// client, which must be allowed for the whole ephemeral range.
+func (t *vpcReachabilityTrace) networkAclResponseHop(request vpcReachabilityNetworkAcl) *vpcReachabilityHop {
+	networkAcl := t.network.subnetNetworkAcl(request.SubnetId)
+	hop := &vpcReachabilityHop{
+		ComponentType: "network_acl",
+		ComponentId:   aws.ToString(networkAcl.NetworkAclId),
+		Action:        "deny",
+		VpcId:         request.VpcId,
+		SubnetId:      request.SubnetId,
+	}
+	egress := !request.Egress
+
+	if t.protocol != "6" && t.protocol != "17" {
+		entry := t.network.matchNetworkAclEntry(networkAcl, egress, t.protocol, -1, request.Peer)
+		hop.Detail = "No entry matches the responses."
+		if entry != nil {
+			hop.Rule = entry
+			hop.Detail = fmt.Sprintf("Entry %d denies the responses.", aws.ToInt32(entry.RuleNumber))
+			if entry.RuleAction == types.RuleActionAllow {
+				hop.Action = "allow"
+				hop.Detail = fmt.Sprintf("Entry %d allows the responses.", aws.ToInt32(entry.RuleNumber))
+			}
+		}
+		return hop
+	}
+
+	allowed := t.network.networkAclAllowedPorts(networkAcl, egress, t.protocol, request.Peer, vpcNetworkEphemeralFromPort, vpcNetworkEphemeralToPort)
+	if !portRangesCover(allowed, vpcNetworkEphemeralFromPort, vpcNetworkEphemeralToPort) {
+		ports := make([]string, 0, len(allowed))
+		for _, portRange := range allowed {
+			ports = append(ports, fmt.Sprintf("%d-%d", portRange[0], portRange[1]))
+		}
+		hop.Detail = fmt.Sprintf("No entries allow the responses to the ephemeral ports %d-%d.", vpcNetworkEphemeralFromPort, vpcNetworkEphemeralToPort)
+		if len(ports) > 0 {
+			hop.Detail = fmt.Sprintf("The entries only allow the responses to the ephemeral ports %s of %d-%d.", strings.Join(ports, ", "), vpcNetworkEphemeralFromPort, vpcNetworkEphemeralToPort)
+		}
+		return hop
+	}
+	hop.Action = "allow"
+	hop.Detail = fmt.Sprintf("The entries allow the responses to the ephemeral ports %d-%d.", vpcNetworkEphemeralFromPort, vpcNetworkEphemeralToPort)
+	return hop
+}
+
+//// UTILITY FUNCTIONS
+
+// vpcRouteTarget returns the ID of the target of a route
+func vpcRouteTarget(route *types.Route) string {
+	for _, target := range []*string{
+		route.GatewayId,
+		route.NatGatewayId,
+		route.TransitGatewayId,
+		route.VpcPeeringConnectionId,
+		route.NetworkInterfaceId,
+		route.InstanceId,
+		route.EgressOnlyInternetGatewayId,
+		route.CarrierGatewayId,
+		route.LocalGatewayId,
+		route.CoreNetworkArn,
+	} {
+		if target != nil {
+			return *target
+		}
+	}
+	return ""
+}
+
+func vpcRouteDestination(route *types.Route) string {
+	for _, destination := range []*string{route.DestinationCidrBlock, route.DestinationIpv6CidrBlock, route.DestinationPrefixListId} {
+		if destination != nil {
+			return *destination
+		}
+	}
+	return ""
+}
+
+func vpcTransitGatewayRouteDestination(route *types.TransitGatewayRoute) string {
+	if route.PrefixListId != nil {
+		return *route.PrefixListId
+	}
+	return aws.ToString(route.DestinationCidrBlock)
+}
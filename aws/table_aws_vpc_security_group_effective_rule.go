@@ -0,0 +1,311 @@
+package aws
+
+import (
+	"context"
+	"net/netip"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	ec2v1 "github.com/aws/aws-sdk-go/service/ec2"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsVpcSecurityGroupEffectiveRule(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_vpc_security_group_effective_rule",
+		Description: "AWS VPC Security Group Effective Rule",
+		List: &plugin.ListConfig{
+			Hydrate: listVpcSecurityGroupEffectiveRules,
+			Tags:    map[string]string{"service": "ec2", "action": "DescribeSecurityGroupRules"},
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "group_id", Require: plugin.Optional},
+				{Name: "security_group_rule_id", Require: plugin.Optional},
+			},
+		},
+		GetMatrixItemFunc: SupportedRegionMatrix(ec2v1.EndpointsID),
+		Columns: awsRegionalColumns([]*plugin.Column{
+			{
+				Name:        "security_group_rule_id",
+				Description: "The ID of the security group rule.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "group_id",
+				Description: "The ID of the security group of the rule.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "type",
+				Description: "Type of the rule (ingress | egress).",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("IsEgress").Transform(setRuleType),
+			},
+			{
+				Name:        "ip_protocol",
+				Description: "The IP protocol name (tcp, udp, icmp, icmpv6) or number. A value of -1 indicates all protocols.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "from_port",
+				Description: "The start of the port range for the TCP and UDP protocols, or an ICMP/ICMPv6 type number. A value of -1 indicates all ICMP/ICMPv6 types.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "to_port",
+				Description: "The end of the port range for the TCP and UDP protocols, or an ICMP/ICMPv6 code. A value of -1 indicates all ICMP/ICMPv6 codes.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "cidr",
+				Description: "The CIDR the rule allows traffic from or to: the CIDR of the rule, a CIDR of its prefix list, or an address of a network interface of its referenced security group. Null for referenced security groups without network interfaces.",
+				Type:        proto.ColumnType_CIDR,
+				Transform:   transform.FromField("Cidr").NullIfZero(),
+			},
+			{
+				Name:        "peer_type",
+				Description: "How the rule specifies the traffic it allows. Possible values are: cidr|prefix_list|security_group.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "prefix_list_id",
+				Description: "The ID of the prefix list of the rule.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "referenced_group_id",
+				Description: "The ID of the security group referenced by the rule.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ReferencedGroupInfo.GroupId"),
+			},
+			{
+				Name:        "network_interface_id",
+				Description: "The ID of the network interface of the referenced security group the address of the row is of.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("NetworkInterfaceId").NullIfZero(),
+			},
+			{
+				Name:        "redundant",
+				Description: "True if the traffic the rule allows is all allowed by another rule of the security group with the same peer type.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "covering_rule_id",
+				Description: "The ID of the rule of the security group that allows all of the traffic of the rule, if the rule is redundant.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("CoveringRuleId").NullIfZero(),
+			},
+			{
+				Name:        "duplicate",
+				Description: "True if the rule and its covering rule allow exactly the same traffic.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "group_in_use",
+				Description: "True if the security group is attached to a network interface.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "group_network_interface_count",
+				Description: "The number of network interfaces the security group is attached to.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "description",
+				Description: "The description of the rule.",
+				Type:        proto.ColumnType_STRING,
+			},
+		}),
+	}
+}
+
+type vpcSecurityGroupEffectiveRule struct {
+	types.SecurityGroupRule
+	Cidr                       string
+	PeerType                   string
+	NetworkInterfaceId         string
+	Redundant                  bool
+	CoveringRuleId             string
+	Duplicate                  bool
+	GroupInUse                 bool
+	GroupNetworkInterfaceCount int
+}
+
+// securityGroupRulePeer is a CIDR a rule allows traffic from or to
+type securityGroupRulePeer struct {
+	Prefix             netip.Prefix
+	NetworkInterfaceId string
+}
+
+//// LIST FUNCTION
+
+func listVpcSecurityGroupEffectiveRules(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	network, err := getVpcNetwork(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	for groupId, rules := range network.SecurityGroupRules {
+		if d.EqualsQualString("group_id") != "" && d.EqualsQualString("group_id") != groupId {
+			continue
+		}
+		networkInterfaceCount := len(network.securityGroupNetworkInterfaces(groupId))
+
+		peers := make([][]securityGroupRulePeer, len(rules))
+		for i, rule := range rules {
+			peers[i] = network.securityGroupRulePeers(rule)
+		}
+
+		for i, rule := range rules {
+			if d.EqualsQualString("security_group_rule_id") != "" && d.EqualsQualString("security_group_rule_id") != aws.ToString(rule.SecurityGroupRuleId) {
+				continue
+			}
+
+			item := vpcSecurityGroupEffectiveRule{
+				SecurityGroupRule:          rule,
+				PeerType:                   securityGroupRulePeerType(rule),
+				GroupInUse:                 networkInterfaceCount > 0,
+				GroupNetworkInterfaceCount: networkInterfaceCount,
+			}
+
+			// Of rules allowing the same traffic, the first is not redundant
+			for j, other := range rules {
+				if i == j || !securityGroupRuleCovers(other, peers[j], rule, peers[i]) {
+					continue
+				}
+				duplicate := securityGroupRuleCovers(rule, peers[i], other, peers[j])
+				if duplicate && j > i {
+					continue
+				}
+				item.Redundant = true
+				item.CoveringRuleId = aws.ToString(other.SecurityGroupRuleId)
+				item.Duplicate = duplicate
+				break
+			}
+
+			if len(peers[i]) == 0 {
+				d.StreamListItem(ctx, item)
+			}
+			for _, peer := range peers[i] {
+				item.Cidr = peer.Prefix.String()
+				item.NetworkInterfaceId = peer.NetworkInterfaceId
+				d.StreamListItem(ctx, item)
+			}
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// securityGroupRulePeers returns the CIDRs a rule allows traffic from or to,
+// expanding prefix lists to their CIDRs, and referenced security groups to
+// the addresses of their network interfaces
+func (n *vpcNetwork) securityGroupRulePeers(rule types.SecurityGroupRule) []securityGroupRulePeer {
+	var peers []securityGroupRulePeer
+	if rule.ReferencedGroupInfo != nil {
+		for _, networkInterface := range n.securityGroupNetworkInterfaces(aws.ToString(rule.ReferencedGroupInfo.GroupId)) {
+			for _, addr := range networkInterfaceAddresses(networkInterface) {
+				peers = append(peers, securityGroupRulePeer{
+					Prefix:             netip.PrefixFrom(addr, addr.BitLen()),
+					NetworkInterfaceId: aws.ToString(networkInterface.NetworkInterfaceId),
+				})
+			}
+		}
+		return peers
+	}
+	for _, prefix := range n.prefixes(rule.PrefixListId, rule.CidrIpv4, rule.CidrIpv6) {
+		peers = append(peers, securityGroupRulePeer{Prefix: prefix})
+	}
+	return peers
+}
+
+// securityGroupRulePeerType returns whether a rule's peer is a CIDR, a prefix
+// list or a security group
+func securityGroupRulePeerType(rule types.SecurityGroupRule) string {
+	switch {
+	case rule.ReferencedGroupInfo != nil:
+		return "security_group"
+	case rule.PrefixListId != nil:
+		return "prefix_list"
+	}
+	return "cidr"
+}
+
+// securityGroupRuleCovers returns whether a rule allows all of the traffic
+// another rule of the same direction and peer type allows. Rules of other
+// peer types aren't compared, and rules referencing groups or prefix lists
+// only cover rules referencing the same one, as prefix lists and the network
+// interfaces of referenced groups change independently of the rules.
+func securityGroupRuleCovers(rule types.SecurityGroupRule, rulePeers []securityGroupRulePeer, other types.SecurityGroupRule, otherPeers []securityGroupRulePeer) bool {
+	if aws.ToBool(rule.IsEgress) != aws.ToBool(other.IsEgress) || securityGroupRulePeerType(rule) != securityGroupRulePeerType(other) {
+		return false
+	}
+
+	protocol, otherProtocol := vpcNetworkProtocol(aws.ToString(rule.IpProtocol)), vpcNetworkProtocol(aws.ToString(other.IpProtocol))
+	if protocol != "-1" {
+		if protocol != otherProtocol {
+			return false
+		}
+		switch protocol {
+		case "6", "17":
+			if !securityGroupRulePortsCover(rule.FromPort, rule.ToPort, other.FromPort, other.ToPort) {
+				return false
+			}
+		case "1", "58":
+			// The ports of ICMP rules are a type and a code, -1 for any
+			if !securityGroupRuleIcmpCovers(rule.FromPort, other.FromPort) || !securityGroupRuleIcmpCovers(rule.ToPort, other.ToPort) {
+				return false
+			}
+		}
+	}
+
+	// Rules referencing groups or prefix lists only cover rules referencing the
+	// same group or prefix list, as their current addresses can change
+	switch securityGroupRulePeerType(rule) {
+	case "security_group":
+		return aws.ToString(rule.ReferencedGroupInfo.GroupId) == aws.ToString(other.ReferencedGroupInfo.GroupId)
+	case "prefix_list":
+		return aws.ToString(rule.PrefixListId) == aws.ToString(other.PrefixListId)
+	}
+
+	if len(otherPeers) == 0 {
+		return false
+	}
+	for _, otherPeer := range otherPeers {
+		covered := false
+		for _, peer := range rulePeers {
+			if peer.Prefix.Bits() <= otherPeer.Prefix.Bits() && peer.Prefix.Contains(otherPeer.Prefix.Masked().Addr()) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			return false
+		}
+	}
+	return true
+}
+
+func securityGroupRulePortsCover(fromPort *int32, toPort *int32, otherFromPort *int32, otherToPort *int32) bool {
+	if fromPort == nil || aws.ToInt32(fromPort) == -1 {
+		return true
+	}
+	if otherFromPort == nil || aws.ToInt32(otherFromPort) == -1 {
+		return false
+	}
+	return aws.ToInt32(fromPort) <= aws.ToInt32(otherFromPort) && aws.ToInt32(otherToPort) <= aws.ToInt32(toPort)
+}
+
+func securityGroupRuleIcmpCovers(value *int32, otherValue *int32) bool {
+	return value == nil || aws.ToInt32(value) == -1 || aws.ToInt32(value) == aws.ToInt32(otherValue)
+}
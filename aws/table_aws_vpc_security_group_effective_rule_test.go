@@ -0,0 +1,76 @@
+package aws
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+func TestSecurityGroupRuleCovers(t *testing.T) {
+	network := testVpcNetwork()
+	network.PrefixLists["pl-1"] = []netip.Prefix{netip.MustParsePrefix("10.0.1.0/24")}
+	network.PrefixLists["pl-2"] = []netip.Prefix{netip.MustParsePrefix("10.0.1.0/24")}
+
+	// Both groups are only used by the same network interface
+	network.NetworkInterfaces = append(network.NetworkInterfaces, testNetworkInterface("eni-shared", "vpc-a", "subnet-a1", "10.0.1.50", "sg-shared-1", "sg-shared-2"))
+
+	rules := map[string]types.SecurityGroupRule{
+		"all-tcp-vpc":      {IsEgress: aws.Bool(false), IpProtocol: aws.String("tcp"), FromPort: aws.Int32(0), ToPort: aws.Int32(65535), CidrIpv4: aws.String("10.0.0.0/16")},
+		"https-subnet":     {IsEgress: aws.Bool(false), IpProtocol: aws.String("tcp"), FromPort: aws.Int32(443), ToPort: aws.Int32(443), CidrIpv4: aws.String("10.0.1.0/24")},
+		"https-subnet-2":   {IsEgress: aws.Bool(false), IpProtocol: aws.String("tcp"), FromPort: aws.Int32(443), ToPort: aws.Int32(443), CidrIpv4: aws.String("10.0.1.0/24")},
+		"https-other":      {IsEgress: aws.Bool(false), IpProtocol: aws.String("tcp"), FromPort: aws.Int32(443), ToPort: aws.Int32(443), CidrIpv4: aws.String("10.1.0.0/16")},
+		"https-egress":     {IsEgress: aws.Bool(true), IpProtocol: aws.String("tcp"), FromPort: aws.Int32(443), ToPort: aws.Int32(443), CidrIpv4: aws.String("10.0.1.0/24")},
+		"udp-vpc":          {IsEgress: aws.Bool(false), IpProtocol: aws.String("udp"), FromPort: aws.Int32(0), ToPort: aws.Int32(65535), CidrIpv4: aws.String("10.0.0.0/16")},
+		"all-vpc":          {IsEgress: aws.Bool(false), IpProtocol: aws.String("-1"), CidrIpv4: aws.String("10.0.0.0/16")},
+		"https-pl":         {IsEgress: aws.Bool(false), IpProtocol: aws.String("tcp"), FromPort: aws.Int32(443), ToPort: aws.Int32(443), PrefixListId: aws.String("pl-1")},
+		"all-tcp-pl":       {IsEgress: aws.Bool(false), IpProtocol: aws.String("tcp"), FromPort: aws.Int32(0), ToPort: aws.Int32(65535), PrefixListId: aws.String("pl-1")},
+		"https-group":      {IsEgress: aws.Bool(false), IpProtocol: aws.String("tcp"), FromPort: aws.Int32(443), ToPort: aws.Int32(443), ReferencedGroupInfo: &types.ReferencedSecurityGroup{GroupId: aws.String("sg-src")}},
+		"all-tcp-group":    {IsEgress: aws.Bool(false), IpProtocol: aws.String("tcp"), FromPort: aws.Int32(0), ToPort: aws.Int32(65535), ReferencedGroupInfo: &types.ReferencedSecurityGroup{GroupId: aws.String("sg-src")}},
+		"https-shared-1":   {IsEgress: aws.Bool(false), IpProtocol: aws.String("tcp"), FromPort: aws.Int32(443), ToPort: aws.Int32(443), ReferencedGroupInfo: &types.ReferencedSecurityGroup{GroupId: aws.String("sg-shared-1")}},
+		"all-tcp-shared-2": {IsEgress: aws.Bool(false), IpProtocol: aws.String("tcp"), FromPort: aws.Int32(0), ToPort: aws.Int32(65535), ReferencedGroupInfo: &types.ReferencedSecurityGroup{GroupId: aws.String("sg-shared-2")}},
+		"https-pl-2":       {IsEgress: aws.Bool(false), IpProtocol: aws.String("tcp"), FromPort: aws.Int32(443), ToPort: aws.Int32(443), PrefixListId: aws.String("pl-2")},
+		"all-tcp-group-c":  {IsEgress: aws.Bool(false), IpProtocol: aws.String("tcp"), FromPort: aws.Int32(0), ToPort: aws.Int32(65535), ReferencedGroupInfo: &types.ReferencedSecurityGroup{GroupId: aws.String("sg-c")}},
+		"echo-request-vpc": {IsEgress: aws.Bool(false), IpProtocol: aws.String("icmp"), FromPort: aws.Int32(8), ToPort: aws.Int32(-1), CidrIpv4: aws.String("10.0.0.0/16")},
+		"icmp-vpc":         {IsEgress: aws.Bool(false), IpProtocol: aws.String("icmp"), FromPort: aws.Int32(-1), ToPort: aws.Int32(-1), CidrIpv4: aws.String("10.0.0.0/16")},
+	}
+
+	tests := []struct {
+		rule     string
+		other    string
+		expected bool
+	}{
+		{"all-tcp-vpc", "https-subnet", true},
+		{"https-subnet", "all-tcp-vpc", false},
+		{"https-subnet", "https-subnet-2", true},
+		{"all-tcp-vpc", "https-other", false},
+		{"all-tcp-vpc", "https-egress", false},
+		{"udp-vpc", "https-subnet", false},
+		{"all-vpc", "https-subnet", true},
+		{"icmp-vpc", "echo-request-vpc", true},
+		{"echo-request-vpc", "icmp-vpc", false},
+		{"all-tcp-pl", "https-pl", true},
+		{"all-tcp-group", "https-group", true},
+		{"all-tcp-group-c", "https-group", false},
+
+		// Rules referencing different groups or prefix lists don't cover each
+		// other, even if they currently resolve to the same addresses
+		{"all-tcp-shared-2", "https-shared-1", false},
+		{"all-tcp-pl", "https-pl-2", false},
+
+		// Rules of other peer types are not compared, even if their current
+		// addresses are covered
+		{"all-tcp-vpc", "https-pl", false},
+		{"all-tcp-vpc", "https-group", false},
+		{"all-tcp-pl", "https-subnet", false},
+		{"all-tcp-group", "https-subnet", false},
+	}
+	for _, test := range tests {
+		rule, other := rules[test.rule], rules[test.other]
+		covers := securityGroupRuleCovers(rule, network.securityGroupRulePeers(rule), other, network.securityGroupRulePeers(other))
+		if covers != test.expected {
+			t.Errorf("securityGroupRuleCovers(%s, %s) = %v, expected %v", test.rule, test.other, covers, test.expected)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/vpclattice"
+	"github.com/aws/aws-sdk-go-v2/service/vpclattice/types"
+
+	vpclatticev1 "github.com/aws/aws-sdk-go/service/vpclattice"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+type vpcLatticeListener struct {
+	types.ListenerSummary
+	ServiceId  *string
+	ServiceArn *string
+}
+
+//// TABLE DEFINITION
+
+func tableAwsVpcLatticeListener(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_vpclattice_listener",
+		Description: "AWS VPC Lattice Listener",
+		List: &plugin.ListConfig{
+			ParentHydrate: listVpcLatticeServices,
+			Hydrate:       listVpcLatticeListeners,
+			Tags:          map[string]string{"service": "vpc-lattice", "action": "ListListeners"},
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "service_id", Require: plugin.Optional},
+			},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"ResourceNotFoundException"}),
+			},
+		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{
+				Func: getVpcLatticeListener,
+				Tags: map[string]string{"service": "vpc-lattice", "action": "GetListener"},
+			},
+			{
+				Func: getVpcLatticeResourceTags,
+				Tags: map[string]string{"service": "vpc-lattice", "action": "ListTagsForResource"},
+			},
+		},
+		GetMatrixItemFunc: SupportedRegionMatrix(vpclatticev1.EndpointsID),
+		Columns: awsRegionalColumns([]*plugin.Column{
+			{
+				Name:        "id",
+				Description: "The ID of the listener.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "name",
+				Description: "The name of the listener.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "arn",
+				Description: "The Amazon Resource Name (ARN) of the listener.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "service_id",
+				Description: "The ID of the service of the listener.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "service_arn",
+				Description: "The Amazon Resource Name (ARN) of the service of the listener.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "port",
+				Description: "The listener port.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "protocol",
+				Description: "The listener protocol, i.e. HTTP, HTTPS or TLS_PASSTHROUGH.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "default_action",
+				Description: "The action of the listener for requests not matching any rule, i.e. a forward action to weighted target groups or a fixed response.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getVpcLatticeListener,
+				Transform:   transform.FromField("DefaultAction").Transform(vpcLatticeRuleActionToMap),
+			},
+			{
+				Name:        "created_at",
+				Description: "The date and time that the listener was created.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "last_updated_at",
+				Description: "The date and time that the listener was last updated.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Name"),
+			},
+			{
+				Name:        "tags",
+				Description: resourceInterfaceDescription("tags"),
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getVpcLatticeResourceTags,
+				Transform:   transform.FromField("Tags"),
+			},
+			{
+				Name:        "akas",
+				Description: resourceInterfaceDescription("akas"),
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Arn").Transform(transform.EnsureStringArray),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listVpcLatticeListeners(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	service := h.Item.(types.ServiceSummary)
+
+	// check if the provided service_id is not matching with the parentHydrate
+	if d.EqualsQualString("service_id") != "" && d.EqualsQualString("service_id") != aws.ToString(service.Id) {
+		return nil, nil
+	}
+
+	// Create Session
+	svc, err := VpcLatticeClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_vpclattice_listener.listVpcLatticeListeners", "connection_error", err)
+		return nil, err
+	}
+	if svc == nil {
+		// Unsupported region, return no data
+		return nil, nil
+	}
+
+	// Limiting the results
+	maxLimit := int32(100)
+	if d.QueryContext.Limit != nil {
+		limit := int32(*d.QueryContext.Limit)
+		if limit < maxLimit {
+			maxLimit = limit
+		}
+	}
+
+	input := &vpclattice.ListListenersInput{
+		ServiceIdentifier: service.Id,
+		MaxResults:        aws.Int32(maxLimit),
+	}
+
+	paginator := vpclattice.NewListListenersPaginator(svc, input, func(o *vpclattice.ListListenersPaginatorOptions) {
+		o.Limit = maxLimit
+		o.StopOnDuplicateToken = true
+	})
+
+	// List call
+	for paginator.HasMorePages() {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_vpclattice_listener.listVpcLatticeListeners", "api_error", err)
+			return nil, err
+		}
+
+		for _, item := range output.Items {
+			d.StreamListItem(ctx, vpcLatticeListener{item, service.Id, service.Arn})
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getVpcLatticeListener(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	listener := h.Item.(vpcLatticeListener)
+
+	// Create Session
+	svc, err := VpcLatticeClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_vpclattice_listener.getVpcLatticeListener", "connection_error", err)
+		return nil, err
+	}
+	if svc == nil {
+		// Unsupported region, return no data
+		return nil, nil
+	}
+
+	params := &vpclattice.GetListenerInput{
+		ServiceIdentifier:  listener.ServiceId,
+		ListenerIdentifier: listener.Id,
+	}
+
+	op, err := svc.GetListener(ctx, params)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_vpclattice_listener.getVpcLatticeListener", "api_error", err)
+		return nil, err
+	}
+
+	return op, nil
+}
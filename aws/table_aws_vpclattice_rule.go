@@ -0,0 +1,321 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/vpclattice"
+	"github.com/aws/aws-sdk-go-v2/service/vpclattice/types"
+
+	vpclatticev1 "github.com/aws/aws-sdk-go/service/vpclattice"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+type vpcLatticeRule struct {
+	types.RuleSummary
+	ServiceId   *string
+	ListenerId  *string
+	ListenerArn *string
+}
+
+//// TABLE DEFINITION
+
+func tableAwsVpcLatticeRule(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_vpclattice_rule",
+		Description: "AWS VPC Lattice Rule",
+		List: &plugin.ListConfig{
+			ParentHydrate: listVpcLatticeServices,
+			Hydrate:       listVpcLatticeRules,
+			Tags:          map[string]string{"service": "vpc-lattice", "action": "ListRules"},
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "service_id", Require: plugin.Optional},
+				{Name: "listener_id", Require: plugin.Optional},
+			},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"ResourceNotFoundException"}),
+			},
+		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{
+				Func: getVpcLatticeRule,
+				Tags: map[string]string{"service": "vpc-lattice", "action": "GetRule"},
+			},
+			{
+				Func: getVpcLatticeResourceTags,
+				Tags: map[string]string{"service": "vpc-lattice", "action": "ListTagsForResource"},
+			},
+		},
+		GetMatrixItemFunc: SupportedRegionMatrix(vpclatticev1.EndpointsID),
+		Columns: awsRegionalColumns([]*plugin.Column{
+			{
+				Name:        "id",
+				Description: "The ID of the rule.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "name",
+				Description: "The name of the rule.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "arn",
+				Description: "The Amazon Resource Name (ARN) of the rule.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "service_id",
+				Description: "The ID of the service of the rule.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "listener_id",
+				Description: "The ID of the listener of the rule.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "listener_arn",
+				Description: "The Amazon Resource Name (ARN) of the listener of the rule.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "is_default",
+				Description: "Indicates whether this is the default listener rule.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "priority",
+				Description: "The priority of the rule.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "match",
+				Description: "The criteria the requests must match for the rule to apply, i.e. the HTTP method, path and headers.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getVpcLatticeRule,
+				Transform:   transform.FromField("Match").Transform(vpcLatticeRuleMatchToMap),
+			},
+			{
+				Name:        "action",
+				Description: "The action of the rule, i.e. a forward action to weighted target groups or a fixed response.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getVpcLatticeRule,
+				Transform:   transform.FromField("Action").Transform(vpcLatticeRuleActionToMap),
+			},
+			{
+				Name:        "created_at",
+				Description: "The date and time that the rule was created.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "last_updated_at",
+				Description: "The date and time that the rule was last updated.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Name"),
+			},
+			{
+				Name:        "tags",
+				Description: resourceInterfaceDescription("tags"),
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getVpcLatticeResourceTags,
+				Transform:   transform.FromField("Tags"),
+			},
+			{
+				Name:        "akas",
+				Description: resourceInterfaceDescription("akas"),
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Arn").Transform(transform.EnsureStringArray),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listVpcLatticeRules(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	service := h.Item.(types.ServiceSummary)
+
+	// check if the provided service_id is not matching with the parentHydrate
+	if d.EqualsQualString("service_id") != "" && d.EqualsQualString("service_id") != aws.ToString(service.Id) {
+		return nil, nil
+	}
+
+	// Create Session
+	svc, err := VpcLatticeClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_vpclattice_rule.listVpcLatticeRules", "connection_error", err)
+		return nil, err
+	}
+	if svc == nil {
+		// Unsupported region, return no data
+		return nil, nil
+	}
+
+	// Rules are listed per listener, so the listeners of the service are
+	// listed first
+	var listeners []types.ListenerSummary
+	listenerPaginator := vpclattice.NewListListenersPaginator(svc, &vpclattice.ListListenersInput{
+		ServiceIdentifier: service.Id,
+	})
+	for listenerPaginator.HasMorePages() {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		output, err := listenerPaginator.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_vpclattice_rule.listVpcLatticeRules", "api_error", err)
+			return nil, err
+		}
+		listeners = append(listeners, output.Items...)
+	}
+
+	// Limiting the results
+	maxLimit := int32(100)
+	if d.QueryContext.Limit != nil {
+		limit := int32(*d.QueryContext.Limit)
+		if limit < maxLimit {
+			maxLimit = limit
+		}
+	}
+
+	for _, listener := range listeners {
+		if d.EqualsQualString("listener_id") != "" && d.EqualsQualString("listener_id") != aws.ToString(listener.Id) {
+			continue
+		}
+
+		input := &vpclattice.ListRulesInput{
+			ServiceIdentifier:  service.Id,
+			ListenerIdentifier: listener.Id,
+			MaxResults:         aws.Int32(maxLimit),
+		}
+
+		paginator := vpclattice.NewListRulesPaginator(svc, input, func(o *vpclattice.ListRulesPaginatorOptions) {
+			o.Limit = maxLimit
+			o.StopOnDuplicateToken = true
+		})
+
+		// List call
+		for paginator.HasMorePages() {
+			// apply rate limiting
+			d.WaitForListRateLimit(ctx)
+
+			output, err := paginator.NextPage(ctx)
+			if err != nil {
+				plugin.Logger(ctx).Error("aws_vpclattice_rule.listVpcLatticeRules", "api_error", err)
+				return nil, err
+			}
+
+			for _, item := range output.Items {
+				d.StreamListItem(ctx, vpcLatticeRule{item, service.Id, listener.Id, listener.Arn})
+
+				// Context can be cancelled due to manual cancellation or the limit has been hit
+				if d.RowsRemaining(ctx) == 0 {
+					return nil, nil
+				}
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getVpcLatticeRule(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	rule := h.Item.(vpcLatticeRule)
+
+	// Create Session
+	svc, err := VpcLatticeClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_vpclattice_rule.getVpcLatticeRule", "connection_error", err)
+		return nil, err
+	}
+	if svc == nil {
+		// Unsupported region, return no data
+		return nil, nil
+	}
+
+	params := &vpclattice.GetRuleInput{
+		ServiceIdentifier:  rule.ServiceId,
+		ListenerIdentifier: rule.ListenerId,
+		RuleIdentifier:     rule.Id,
+	}
+
+	op, err := svc.GetRule(ctx, params)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_vpclattice_rule.getVpcLatticeRule", "api_error", err)
+		return nil, err
+	}
+
+	return op, nil
+}
+
+//// TRANSFORM FUNCTIONS
+
+// vpcLatticeRuleActionToMap converts the rule action union of a rule or of the
+// default action of a listener to a map keyed by the type of action
+func vpcLatticeRuleActionToMap(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	switch action := d.Value.(type) {
+	case *types.RuleActionMemberForward:
+		return map[string]interface{}{"Forward": action.Value}, nil
+	case *types.RuleActionMemberFixedResponse:
+		return map[string]interface{}{"FixedResponse": action.Value}, nil
+	}
+	return nil, nil
+}
+
+// vpcLatticeRuleMatchToMap converts the rule match union of a rule to a map,
+// with the path and header match unions converted to their type of match
+func vpcLatticeRuleMatchToMap(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	match, ok := d.Value.(*types.RuleMatchMemberHttpMatch)
+	if !ok {
+		return nil, nil
+	}
+
+	httpMatch := map[string]interface{}{
+		"Method": match.Value.Method,
+	}
+
+	if pathMatch := match.Value.PathMatch; pathMatch != nil {
+		path := map[string]interface{}{
+			"CaseSensitive": pathMatch.CaseSensitive,
+		}
+		switch pathMatchType := pathMatch.Match.(type) {
+		case *types.PathMatchTypeMemberExact:
+			path["Exact"] = pathMatchType.Value
+		case *types.PathMatchTypeMemberPrefix:
+			path["Prefix"] = pathMatchType.Value
+		}
+		httpMatch["PathMatch"] = path
+	}
+
+	headerMatches := []map[string]interface{}{}
+	for _, headerMatch := range match.Value.HeaderMatches {
+		header := map[string]interface{}{
+			"Name":          headerMatch.Name,
+			"CaseSensitive": headerMatch.CaseSensitive,
+		}
+		switch headerMatchType := headerMatch.Match.(type) {
+		case *types.HeaderMatchTypeMemberExact:
+			header["Exact"] = headerMatchType.Value
+		case *types.HeaderMatchTypeMemberPrefix:
+			header["Prefix"] = headerMatchType.Value
+		case *types.HeaderMatchTypeMemberContains:
+			header["Contains"] = headerMatchType.Value
+		}
+		headerMatches = append(headerMatches, header)
+	}
+	httpMatch["HeaderMatches"] = headerMatches
+
+	return map[string]interface{}{"HttpMatch": httpMatch}, nil
+}
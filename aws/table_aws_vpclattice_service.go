@@ -0,0 +1,256 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/vpclattice"
+	"github.com/aws/aws-sdk-go-v2/service/vpclattice/types"
+
+	vpclatticev1 "github.com/aws/aws-sdk-go/service/vpclattice"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsVpcLatticeService(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_vpclattice_service",
+		Description: "AWS VPC Lattice Service",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"ResourceNotFoundException", "ValidationException"}),
+			},
+			Hydrate: getVpcLatticeService,
+			Tags:    map[string]string{"service": "vpc-lattice", "action": "GetService"},
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listVpcLatticeServices,
+			Tags:    map[string]string{"service": "vpc-lattice", "action": "ListServices"},
+		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{
+				Func: getVpcLatticeService,
+				Tags: map[string]string{"service": "vpc-lattice", "action": "GetService"},
+			},
+			{
+				Func: getVpcLatticeAuthPolicy,
+				Tags: map[string]string{"service": "vpc-lattice", "action": "GetAuthPolicy"},
+			},
+			{
+				Func: getVpcLatticeResourceTags,
+				Tags: map[string]string{"service": "vpc-lattice", "action": "ListTagsForResource"},
+			},
+		},
+		GetMatrixItemFunc: SupportedRegionMatrix(vpclatticev1.EndpointsID),
+		Columns: awsRegionalColumns([]*plugin.Column{
+			{
+				Name:        "id",
+				Description: "The ID of the service.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "name",
+				Description: "The name of the service.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "arn",
+				Description: "The Amazon Resource Name (ARN) of the service.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "status",
+				Description: "The status of the service, i.e. ACTIVE, CREATE_IN_PROGRESS, DELETE_IN_PROGRESS, CREATE_FAILED or DELETE_FAILED.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "auth_type",
+				Description: "The type of IAM policy, i.e. NONE or AWS_IAM.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getVpcLatticeService,
+			},
+			{
+				Name:        "custom_domain_name",
+				Description: "The custom domain name of the service.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "dns_entry",
+				Description: "The DNS name of the service.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "certificate_arn",
+				Description: "The Amazon Resource Name (ARN) of the certificate.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getVpcLatticeService,
+			},
+			{
+				Name:        "failure_code",
+				Description: "The failure code, if the service failed to be created or deleted.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getVpcLatticeService,
+			},
+			{
+				Name:        "failure_message",
+				Description: "The failure message, if the service failed to be created or deleted.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getVpcLatticeService,
+			},
+			{
+				Name:        "created_at",
+				Description: "The date and time that the service was created.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "last_updated_at",
+				Description: "The date and time that the service was last updated.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "auth_policy",
+				Description: "The auth policy of the service.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getVpcLatticeAuthPolicy,
+				Transform:   transform.FromField("Policy"),
+			},
+			{
+				Name:        "auth_policy_std",
+				Description: "Contains the auth policy in a canonical form for easier searching.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getVpcLatticeAuthPolicy,
+				Transform:   transform.FromField("Policy").Transform(unescape).Transform(policyToCanonical),
+			},
+			{
+				Name:        "auth_policy_state",
+				Description: "The state of the auth policy, i.e. Active or Inactive. The auth policy is only active when the auth type is AWS_IAM.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getVpcLatticeAuthPolicy,
+				Transform:   transform.FromField("State"),
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Name"),
+			},
+			{
+				Name:        "tags",
+				Description: resourceInterfaceDescription("tags"),
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getVpcLatticeResourceTags,
+				Transform:   transform.FromField("Tags"),
+			},
+			{
+				Name:        "akas",
+				Description: resourceInterfaceDescription("akas"),
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Arn").Transform(transform.EnsureStringArray),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listVpcLatticeServices(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// Create Session
+	svc, err := VpcLatticeClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_vpclattice_service.listVpcLatticeServices", "connection_error", err)
+		return nil, err
+	}
+	if svc == nil {
+		// Unsupported region, return no data
+		return nil, nil
+	}
+
+	// Limiting the results
+	maxLimit := int32(100)
+	if d.QueryContext.Limit != nil {
+		limit := int32(*d.QueryContext.Limit)
+		if limit < maxLimit {
+			maxLimit = limit
+		}
+	}
+
+	input := &vpclattice.ListServicesInput{
+		MaxResults: aws.Int32(maxLimit),
+	}
+
+	paginator := vpclattice.NewListServicesPaginator(svc, input, func(o *vpclattice.ListServicesPaginatorOptions) {
+		o.Limit = maxLimit
+		o.StopOnDuplicateToken = true
+	})
+
+	// List call
+	for paginator.HasMorePages() {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_vpclattice_service.listVpcLatticeServices", "api_error", err)
+			return nil, err
+		}
+
+		for _, item := range output.Items {
+			d.StreamListItem(ctx, item)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getVpcLatticeService(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	var id string
+	switch item := h.Item.(type) {
+	case types.ServiceSummary:
+		id = aws.ToString(item.Id)
+	case *vpclattice.GetServiceOutput:
+		// Already fetched by the get call
+		return item, nil
+	default:
+		id = d.EqualsQualString("id")
+	}
+	if id == "" {
+		return nil, nil
+	}
+
+	// Create Session
+	svc, err := VpcLatticeClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_vpclattice_service.getVpcLatticeService", "connection_error", err)
+		return nil, err
+	}
+	if svc == nil {
+		// Unsupported region, return no data
+		return nil, nil
+	}
+
+	params := &vpclattice.GetServiceInput{
+		ServiceIdentifier: aws.String(id),
+	}
+
+	op, err := svc.GetService(ctx, params)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_vpclattice_service.getVpcLatticeService", "api_error", err)
+		return nil, err
+	}
+
+	return op, nil
+}
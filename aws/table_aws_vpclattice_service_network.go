@@ -0,0 +1,343 @@
+package aws
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/vpclattice"
+	"github.com/aws/aws-sdk-go-v2/service/vpclattice/types"
+
+	vpclatticev1 "github.com/aws/aws-sdk-go/service/vpclattice"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsVpcLatticeServiceNetwork(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_vpclattice_service_network",
+		Description: "AWS VPC Lattice Service Network",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"ResourceNotFoundException", "ValidationException"}),
+			},
+			Hydrate: getVpcLatticeServiceNetwork,
+			Tags:    map[string]string{"service": "vpc-lattice", "action": "GetServiceNetwork"},
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listVpcLatticeServiceNetworks,
+			Tags:    map[string]string{"service": "vpc-lattice", "action": "ListServiceNetworks"},
+		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{
+				Func: getVpcLatticeServiceNetwork,
+				Tags: map[string]string{"service": "vpc-lattice", "action": "GetServiceNetwork"},
+			},
+			{
+				Func: getVpcLatticeAuthPolicy,
+				Tags: map[string]string{"service": "vpc-lattice", "action": "GetAuthPolicy"},
+			},
+			{
+				Func: getVpcLatticeResourceTags,
+				Tags: map[string]string{"service": "vpc-lattice", "action": "ListTagsForResource"},
+			},
+		},
+		GetMatrixItemFunc: SupportedRegionMatrix(vpclatticev1.EndpointsID),
+		Columns: awsRegionalColumns([]*plugin.Column{
+			{
+				Name:        "id",
+				Description: "The ID of the service network.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "name",
+				Description: "The name of the service network.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "arn",
+				Description: "The Amazon Resource Name (ARN) of the service network.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "auth_type",
+				Description: "The type of IAM policy, i.e. NONE or AWS_IAM.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getVpcLatticeServiceNetwork,
+			},
+			{
+				Name:        "created_at",
+				Description: "The date and time that the service network was created.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "last_updated_at",
+				Description: "The date and time of the last update.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "number_of_associated_services",
+				Description: "The number of services associated with the service network.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "number_of_associated_vpcs",
+				Description: "The number of VPCs associated with the service network.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("NumberOfAssociatedVPCs"),
+			},
+			{
+				Name:        "sharing_config",
+				Description: "The sharing configuration of the service network.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getVpcLatticeServiceNetwork,
+			},
+			{
+				Name:        "auth_policy",
+				Description: "The auth policy of the service network.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getVpcLatticeAuthPolicy,
+				Transform:   transform.FromField("Policy"),
+			},
+			{
+				Name:        "auth_policy_std",
+				Description: "Contains the auth policy in a canonical form for easier searching.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getVpcLatticeAuthPolicy,
+				Transform:   transform.FromField("Policy").Transform(unescape).Transform(policyToCanonical),
+			},
+			{
+				Name:        "auth_policy_state",
+				Description: "The state of the auth policy, i.e. Active or Inactive. The auth policy is only active when the auth type is AWS_IAM.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getVpcLatticeAuthPolicy,
+				Transform:   transform.FromField("State"),
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Name"),
+			},
+			{
+				Name:        "tags",
+				Description: resourceInterfaceDescription("tags"),
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getVpcLatticeResourceTags,
+				Transform:   transform.FromField("Tags"),
+			},
+			{
+				Name:        "akas",
+				Description: resourceInterfaceDescription("akas"),
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Arn").Transform(transform.EnsureStringArray),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listVpcLatticeServiceNetworks(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// Create Session
+	svc, err := VpcLatticeClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_vpclattice_service_network.listVpcLatticeServiceNetworks", "connection_error", err)
+		return nil, err
+	}
+	if svc == nil {
+		// Unsupported region, return no data
+		return nil, nil
+	}
+
+	// Limiting the results
+	maxLimit := int32(100)
+	if d.QueryContext.Limit != nil {
+		limit := int32(*d.QueryContext.Limit)
+		if limit < maxLimit {
+			maxLimit = limit
+		}
+	}
+
+	input := &vpclattice.ListServiceNetworksInput{
+		MaxResults: aws.Int32(maxLimit),
+	}
+
+	paginator := vpclattice.NewListServiceNetworksPaginator(svc, input, func(o *vpclattice.ListServiceNetworksPaginatorOptions) {
+		o.Limit = maxLimit
+		o.StopOnDuplicateToken = true
+	})
+
+	// List call
+	for paginator.HasMorePages() {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_vpclattice_service_network.listVpcLatticeServiceNetworks", "api_error", err)
+			return nil, err
+		}
+
+		for _, item := range output.Items {
+			d.StreamListItem(ctx, item)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getVpcLatticeServiceNetwork(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	var id string
+	switch item := h.Item.(type) {
+	case types.ServiceNetworkSummary:
+		id = aws.ToString(item.Id)
+	case *vpclattice.GetServiceNetworkOutput:
+		// Already fetched by the get call
+		return item, nil
+	default:
+		id = d.EqualsQualString("id")
+	}
+	if id == "" {
+		return nil, nil
+	}
+
+	// Create Session
+	svc, err := VpcLatticeClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_vpclattice_service_network.getVpcLatticeServiceNetwork", "connection_error", err)
+		return nil, err
+	}
+	if svc == nil {
+		// Unsupported region, return no data
+		return nil, nil
+	}
+
+	params := &vpclattice.GetServiceNetworkInput{
+		ServiceNetworkIdentifier: aws.String(id),
+	}
+
+	op, err := svc.GetServiceNetwork(ctx, params)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_vpclattice_service_network.getVpcLatticeServiceNetwork", "api_error", err)
+		return nil, err
+	}
+
+	return op, nil
+}
+
+// getVpcLatticeAuthPolicy returns the auth policy of a service network or a
+// service
+func getVpcLatticeAuthPolicy(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	arn := vpcLatticeResourceArn(h.Item)
+	if arn == "" {
+		return nil, nil
+	}
+
+	// Create Session
+	svc, err := VpcLatticeClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("getVpcLatticeAuthPolicy", "connection_error", err)
+		return nil, err
+	}
+	if svc == nil {
+		// Unsupported region, return no data
+		return nil, nil
+	}
+
+	params := &vpclattice.GetAuthPolicyInput{
+		ResourceIdentifier: aws.String(arn),
+	}
+
+	op, err := svc.GetAuthPolicy(ctx, params)
+	if err != nil {
+		// No auth policy has been attached to the resource
+		if strings.Contains(err.Error(), "ResourceNotFoundException") {
+			return nil, nil
+		}
+		plugin.Logger(ctx).Error("getVpcLatticeAuthPolicy", "api_error", err)
+		return nil, err
+	}
+
+	return op, nil
+}
+
+// getVpcLatticeResourceTags returns the tags of any VPC Lattice resource
+func getVpcLatticeResourceTags(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	arn := vpcLatticeResourceArn(h.Item)
+	if arn == "" {
+		return nil, nil
+	}
+
+	// Create Session
+	svc, err := VpcLatticeClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("getVpcLatticeResourceTags", "connection_error", err)
+		return nil, err
+	}
+	if svc == nil {
+		// Unsupported region, return no data
+		return nil, nil
+	}
+
+	params := &vpclattice.ListTagsForResourceInput{
+		ResourceArn: aws.String(arn),
+	}
+
+	op, err := svc.ListTagsForResource(ctx, params)
+	if err != nil {
+		plugin.Logger(ctx).Error("getVpcLatticeResourceTags", "api_error", err)
+		return nil, err
+	}
+
+	return op, nil
+}
+
+//// UTILITY FUNCTIONS
+
+// vpcLatticeResourceArn returns the ARN of the VPC Lattice resource of a row,
+// which is either the summary returned by the list call or the output of the
+// get call
+func vpcLatticeResourceArn(item interface{}) string {
+	switch item := item.(type) {
+	case types.ServiceNetworkSummary:
+		return aws.ToString(item.Arn)
+	case *vpclattice.GetServiceNetworkOutput:
+		return aws.ToString(item.Arn)
+	case types.ServiceSummary:
+		return aws.ToString(item.Arn)
+	case *vpclattice.GetServiceOutput:
+		return aws.ToString(item.Arn)
+	case vpcLatticeListener:
+		return aws.ToString(item.Arn)
+	case vpcLatticeRule:
+		return aws.ToString(item.Arn)
+	case types.TargetGroupSummary:
+		return aws.ToString(item.Arn)
+	case *vpclattice.GetTargetGroupOutput:
+		return aws.ToString(item.Arn)
+	case types.ServiceNetworkVpcAssociationSummary:
+		return aws.ToString(item.Arn)
+	case *vpclattice.GetServiceNetworkVpcAssociationOutput:
+		return aws.ToString(item.Arn)
+	case types.ServiceNetworkServiceAssociationSummary:
+		return aws.ToString(item.Arn)
+	case *vpclattice.GetServiceNetworkServiceAssociationOutput:
+		return aws.ToString(item.Arn)
+	}
+	return ""
+}
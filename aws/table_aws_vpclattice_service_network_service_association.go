@@ -0,0 +1,243 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/vpclattice"
+	"github.com/aws/aws-sdk-go-v2/service/vpclattice/types"
+
+	vpclatticev1 "github.com/aws/aws-sdk-go/service/vpclattice"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsVpcLatticeServiceNetworkServiceAssociation(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_vpclattice_service_network_service_association",
+		Description: "AWS VPC Lattice Service Network Service Association",
+		List: &plugin.ListConfig{
+			ParentHydrate: listVpcLatticeServiceNetworks,
+			Hydrate:       listVpcLatticeServiceNetworkServiceAssociations,
+			Tags:          map[string]string{"service": "vpc-lattice", "action": "ListServiceNetworkServiceAssociations"},
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "service_network_id", Require: plugin.Optional},
+				{Name: "service_id", Require: plugin.Optional},
+			},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"ResourceNotFoundException"}),
+			},
+		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{
+				Func: getVpcLatticeServiceNetworkServiceAssociation,
+				Tags: map[string]string{"service": "vpc-lattice", "action": "GetServiceNetworkServiceAssociation"},
+			},
+			{
+				Func: getVpcLatticeResourceTags,
+				Tags: map[string]string{"service": "vpc-lattice", "action": "ListTagsForResource"},
+			},
+		},
+		GetMatrixItemFunc: SupportedRegionMatrix(vpclatticev1.EndpointsID),
+		Columns: awsRegionalColumns([]*plugin.Column{
+			{
+				Name:        "id",
+				Description: "The ID of the association.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "arn",
+				Description: "The Amazon Resource Name (ARN) of the association.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "status",
+				Description: "The status of the association, i.e. CREATE_IN_PROGRESS, ACTIVE, DELETE_IN_PROGRESS, CREATE_FAILED or DELETE_FAILED.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "service_network_id",
+				Description: "The ID of the service network.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "service_network_name",
+				Description: "The name of the service network.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "service_network_arn",
+				Description: "The Amazon Resource Name (ARN) of the service network.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "service_id",
+				Description: "The ID of the service.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "service_name",
+				Description: "The name of the service.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "service_arn",
+				Description: "The Amazon Resource Name (ARN) of the service.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "custom_domain_name",
+				Description: "The custom domain name of the service.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "dns_entry",
+				Description: "The DNS name of the service in the service network.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "created_by",
+				Description: "The account that created the association.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "failure_code",
+				Description: "The failure code, if the association failed to be created or deleted.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getVpcLatticeServiceNetworkServiceAssociation,
+			},
+			{
+				Name:        "failure_message",
+				Description: "The failure message, if the association failed to be created or deleted.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getVpcLatticeServiceNetworkServiceAssociation,
+			},
+			{
+				Name:        "created_at",
+				Description: "The date and time that the association was created.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Id"),
+			},
+			{
+				Name:        "tags",
+				Description: resourceInterfaceDescription("tags"),
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getVpcLatticeResourceTags,
+				Transform:   transform.FromField("Tags"),
+			},
+			{
+				Name:        "akas",
+				Description: resourceInterfaceDescription("akas"),
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Arn").Transform(transform.EnsureStringArray),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listVpcLatticeServiceNetworkServiceAssociations(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	serviceNetwork := h.Item.(types.ServiceNetworkSummary)
+
+	// check if the provided service_network_id is not matching with the parentHydrate
+	if d.EqualsQualString("service_network_id") != "" && d.EqualsQualString("service_network_id") != aws.ToString(serviceNetwork.Id) {
+		return nil, nil
+	}
+
+	// Create Session
+	svc, err := VpcLatticeClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_vpclattice_service_network_service_association.listVpcLatticeServiceNetworkServiceAssociations", "connection_error", err)
+		return nil, err
+	}
+	if svc == nil {
+		// Unsupported region, return no data
+		return nil, nil
+	}
+
+	// Limiting the results
+	maxLimit := int32(100)
+	if d.QueryContext.Limit != nil {
+		limit := int32(*d.QueryContext.Limit)
+		if limit < maxLimit {
+			maxLimit = limit
+		}
+	}
+
+	input := &vpclattice.ListServiceNetworkServiceAssociationsInput{
+		ServiceNetworkIdentifier: serviceNetwork.Id,
+		MaxResults:               aws.Int32(maxLimit),
+	}
+	if d.EqualsQualString("service_id") != "" {
+		input.ServiceIdentifier = aws.String(d.EqualsQualString("service_id"))
+	}
+
+	paginator := vpclattice.NewListServiceNetworkServiceAssociationsPaginator(svc, input, func(o *vpclattice.ListServiceNetworkServiceAssociationsPaginatorOptions) {
+		o.Limit = maxLimit
+		o.StopOnDuplicateToken = true
+	})
+
+	// List call
+	for paginator.HasMorePages() {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_vpclattice_service_network_service_association.listVpcLatticeServiceNetworkServiceAssociations", "api_error", err)
+			return nil, err
+		}
+
+		for _, item := range output.Items {
+			d.StreamListItem(ctx, item)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getVpcLatticeServiceNetworkServiceAssociation(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	association := h.Item.(types.ServiceNetworkServiceAssociationSummary)
+
+	// Create Session
+	svc, err := VpcLatticeClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_vpclattice_service_network_service_association.getVpcLatticeServiceNetworkServiceAssociation", "connection_error", err)
+		return nil, err
+	}
+	if svc == nil {
+		// Unsupported region, return no data
+		return nil, nil
+	}
+
+	params := &vpclattice.GetServiceNetworkServiceAssociationInput{
+		ServiceNetworkServiceAssociationIdentifier: association.Id,
+	}
+
+	op, err := svc.GetServiceNetworkServiceAssociation(ctx, params)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_vpclattice_service_network_service_association.getVpcLatticeServiceNetworkServiceAssociation", "api_error", err)
+		return nil, err
+	}
+
+	return op, nil
+}
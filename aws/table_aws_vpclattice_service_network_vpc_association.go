@@ -0,0 +1,234 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/vpclattice"
+	"github.com/aws/aws-sdk-go-v2/service/vpclattice/types"
+
+	vpclatticev1 "github.com/aws/aws-sdk-go/service/vpclattice"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsVpcLatticeServiceNetworkVpcAssociation(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_vpclattice_service_network_vpc_association",
+		Description: "AWS VPC Lattice Service Network VPC Association",
+		List: &plugin.ListConfig{
+			ParentHydrate: listVpcLatticeServiceNetworks,
+			Hydrate:       listVpcLatticeServiceNetworkVpcAssociations,
+			Tags:          map[string]string{"service": "vpc-lattice", "action": "ListServiceNetworkVpcAssociations"},
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "service_network_id", Require: plugin.Optional},
+				{Name: "vpc_id", Require: plugin.Optional},
+			},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"ResourceNotFoundException"}),
+			},
+		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{
+				Func: getVpcLatticeServiceNetworkVpcAssociation,
+				Tags: map[string]string{"service": "vpc-lattice", "action": "GetServiceNetworkVpcAssociation"},
+			},
+			{
+				Func: getVpcLatticeResourceTags,
+				Tags: map[string]string{"service": "vpc-lattice", "action": "ListTagsForResource"},
+			},
+		},
+		GetMatrixItemFunc: SupportedRegionMatrix(vpclatticev1.EndpointsID),
+		Columns: awsRegionalColumns([]*plugin.Column{
+			{
+				Name:        "id",
+				Description: "The ID of the association.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "arn",
+				Description: "The Amazon Resource Name (ARN) of the association.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "status",
+				Description: "The status of the association, i.e. CREATE_IN_PROGRESS, ACTIVE, UPDATE_IN_PROGRESS, DELETE_IN_PROGRESS, CREATE_FAILED, DELETE_FAILED or UPDATE_FAILED.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "service_network_id",
+				Description: "The ID of the service network.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "service_network_name",
+				Description: "The name of the service network.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "service_network_arn",
+				Description: "The Amazon Resource Name (ARN) of the service network.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "vpc_id",
+				Description: "The ID of the VPC.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "security_group_ids",
+				Description: "The IDs of the security groups applied to the traffic between the VPC and the service network.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getVpcLatticeServiceNetworkVpcAssociation,
+			},
+			{
+				Name:        "created_by",
+				Description: "The account that created the association.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "failure_code",
+				Description: "The failure code, if the association failed to be created or deleted.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getVpcLatticeServiceNetworkVpcAssociation,
+			},
+			{
+				Name:        "failure_message",
+				Description: "The failure message, if the association failed to be created or deleted.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getVpcLatticeServiceNetworkVpcAssociation,
+			},
+			{
+				Name:        "created_at",
+				Description: "The date and time that the association was created.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "last_updated_at",
+				Description: "The date and time that the association was last updated.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Id"),
+			},
+			{
+				Name:        "tags",
+				Description: resourceInterfaceDescription("tags"),
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getVpcLatticeResourceTags,
+				Transform:   transform.FromField("Tags"),
+			},
+			{
+				Name:        "akas",
+				Description: resourceInterfaceDescription("akas"),
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Arn").Transform(transform.EnsureStringArray),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listVpcLatticeServiceNetworkVpcAssociations(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	serviceNetwork := h.Item.(types.ServiceNetworkSummary)
+
+	// check if the provided service_network_id is not matching with the parentHydrate
+	if d.EqualsQualString("service_network_id") != "" && d.EqualsQualString("service_network_id") != aws.ToString(serviceNetwork.Id) {
+		return nil, nil
+	}
+
+	// Create Session
+	svc, err := VpcLatticeClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_vpclattice_service_network_vpc_association.listVpcLatticeServiceNetworkVpcAssociations", "connection_error", err)
+		return nil, err
+	}
+	if svc == nil {
+		// Unsupported region, return no data
+		return nil, nil
+	}
+
+	// Limiting the results
+	maxLimit := int32(100)
+	if d.QueryContext.Limit != nil {
+		limit := int32(*d.QueryContext.Limit)
+		if limit < maxLimit {
+			maxLimit = limit
+		}
+	}
+
+	input := &vpclattice.ListServiceNetworkVpcAssociationsInput{
+		ServiceNetworkIdentifier: serviceNetwork.Id,
+		MaxResults:               aws.Int32(maxLimit),
+	}
+	if d.EqualsQualString("vpc_id") != "" {
+		input.VpcIdentifier = aws.String(d.EqualsQualString("vpc_id"))
+	}
+
+	paginator := vpclattice.NewListServiceNetworkVpcAssociationsPaginator(svc, input, func(o *vpclattice.ListServiceNetworkVpcAssociationsPaginatorOptions) {
+		o.Limit = maxLimit
+		o.StopOnDuplicateToken = true
+	})
+
+	// List call
+	for paginator.HasMorePages() {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_vpclattice_service_network_vpc_association.listVpcLatticeServiceNetworkVpcAssociations", "api_error", err)
+			return nil, err
+		}
+
+		for _, item := range output.Items {
+			d.StreamListItem(ctx, item)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getVpcLatticeServiceNetworkVpcAssociation(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	association := h.Item.(types.ServiceNetworkVpcAssociationSummary)
+
+	// Create Session
+	svc, err := VpcLatticeClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_vpclattice_service_network_vpc_association.getVpcLatticeServiceNetworkVpcAssociation", "connection_error", err)
+		return nil, err
+	}
+	if svc == nil {
+		// Unsupported region, return no data
+		return nil, nil
+	}
+
+	params := &vpclattice.GetServiceNetworkVpcAssociationInput{
+		ServiceNetworkVpcAssociationIdentifier: association.Id,
+	}
+
+	op, err := svc.GetServiceNetworkVpcAssociation(ctx, params)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_vpclattice_service_network_vpc_association.getVpcLatticeServiceNetworkVpcAssociation", "api_error", err)
+		return nil, err
+	}
+
+	return op, nil
+}
@@ -0,0 +1,265 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/vpclattice"
+	"github.com/aws/aws-sdk-go-v2/service/vpclattice/types"
+
+	vpclatticev1 "github.com/aws/aws-sdk-go/service/vpclattice"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsVpcLatticeTargetGroup(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_vpclattice_target_group",
+		Description: "AWS VPC Lattice Target Group",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("id"),
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"ResourceNotFoundException", "ValidationException"}),
+			},
+			Hydrate: getVpcLatticeTargetGroup,
+			Tags:    map[string]string{"service": "vpc-lattice", "action": "GetTargetGroup"},
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listVpcLatticeTargetGroups,
+			Tags:    map[string]string{"service": "vpc-lattice", "action": "ListTargetGroups"},
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "vpc_id", Require: plugin.Optional},
+				{Name: "type", Require: plugin.Optional},
+			},
+		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{
+				Func: getVpcLatticeTargetGroup,
+				Tags: map[string]string{"service": "vpc-lattice", "action": "GetTargetGroup"},
+			},
+			{
+				Func: getVpcLatticeResourceTags,
+				Tags: map[string]string{"service": "vpc-lattice", "action": "ListTagsForResource"},
+			},
+		},
+		GetMatrixItemFunc: SupportedRegionMatrix(vpclatticev1.EndpointsID),
+		Columns: awsRegionalColumns([]*plugin.Column{
+			{
+				Name:        "id",
+				Description: "The ID of the target group.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "name",
+				Description: "The name of the target group.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "arn",
+				Description: "The Amazon Resource Name (ARN) of the target group.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "type",
+				Description: "The type of target group, i.e. IP, LAMBDA, INSTANCE or ALB.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "status",
+				Description: "The status of the target group, i.e. CREATE_IN_PROGRESS, ACTIVE, DELETE_IN_PROGRESS, CREATE_FAILED or DELETE_FAILED.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "vpc_id",
+				Description: "The ID of the VPC of the target group.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("VpcIdentifier", "Config.VpcIdentifier"),
+			},
+			{
+				Name:        "port",
+				Description: "The port of the target group.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("Port", "Config.Port"),
+			},
+			{
+				Name:        "protocol",
+				Description: "The protocol of the target group.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Protocol", "Config.Protocol"),
+			},
+			{
+				Name:        "ip_address_type",
+				Description: "The type of IP address used for the target group, i.e. IPV4 or IPV6.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("IpAddressType", "Config.IpAddressType"),
+			},
+			{
+				Name:        "lambda_event_structure_version",
+				Description: "The version of the event structure that the Lambda function receives, for a target group of type LAMBDA.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("LambdaEventStructureVersion", "Config.LambdaEventStructureVersion"),
+			},
+			{
+				Name:        "service_arns",
+				Description: "The Amazon Resource Names (ARNs) of the services the target group is used by.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "config",
+				Description: "The target group configuration, including the health check configuration.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getVpcLatticeTargetGroup,
+			},
+			{
+				Name:        "failure_code",
+				Description: "The failure code, if the target group failed to be created or deleted.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getVpcLatticeTargetGroup,
+			},
+			{
+				Name:        "failure_message",
+				Description: "The failure message, if the target group failed to be created or deleted.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getVpcLatticeTargetGroup,
+			},
+			{
+				Name:        "created_at",
+				Description: "The date and time that the target group was created.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "last_updated_at",
+				Description: "The date and time that the target group was last updated.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Name"),
+			},
+			{
+				Name:        "tags",
+				Description: resourceInterfaceDescription("tags"),
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getVpcLatticeResourceTags,
+				Transform:   transform.FromField("Tags"),
+			},
+			{
+				Name:        "akas",
+				Description: resourceInterfaceDescription("akas"),
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Arn").Transform(transform.EnsureStringArray),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listVpcLatticeTargetGroups(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// Create Session
+	svc, err := VpcLatticeClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_vpclattice_target_group.listVpcLatticeTargetGroups", "connection_error", err)
+		return nil, err
+	}
+	if svc == nil {
+		// Unsupported region, return no data
+		return nil, nil
+	}
+
+	// Limiting the results
+	maxLimit := int32(100)
+	if d.QueryContext.Limit != nil {
+		limit := int32(*d.QueryContext.Limit)
+		if limit < maxLimit {
+			maxLimit = limit
+		}
+	}
+
+	input := &vpclattice.ListTargetGroupsInput{
+		MaxResults: aws.Int32(maxLimit),
+	}
+	if d.EqualsQualString("vpc_id") != "" {
+		input.VpcIdentifier = aws.String(d.EqualsQualString("vpc_id"))
+	}
+	if d.EqualsQualString("type") != "" {
+		input.TargetGroupType = types.TargetGroupType(d.EqualsQualString("type"))
+	}
+
+	paginator := vpclattice.NewListTargetGroupsPaginator(svc, input, func(o *vpclattice.ListTargetGroupsPaginatorOptions) {
+		o.Limit = maxLimit
+		o.StopOnDuplicateToken = true
+	})
+
+	// List call
+	for paginator.HasMorePages() {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_vpclattice_target_group.listVpcLatticeTargetGroups", "api_error", err)
+			return nil, err
+		}
+
+		for _, item := range output.Items {
+			d.StreamListItem(ctx, item)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getVpcLatticeTargetGroup(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	var id string
+	switch item := h.Item.(type) {
+	case types.TargetGroupSummary:
+		id = aws.ToString(item.Id)
+	case *vpclattice.GetTargetGroupOutput:
+		// Already fetched by the get call
+		return item, nil
+	default:
+		id = d.EqualsQualString("id")
+	}
+	if id == "" {
+		return nil, nil
+	}
+
+	// Create Session
+	svc, err := VpcLatticeClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_vpclattice_target_group.getVpcLatticeTargetGroup", "connection_error", err)
+		return nil, err
+	}
+	if svc == nil {
+		// Unsupported region, return no data
+		return nil, nil
+	}
+
+	params := &vpclattice.GetTargetGroupInput{
+		TargetGroupIdentifier: aws.String(id),
+	}
+
+	op, err := svc.GetTargetGroup(ctx, params)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_vpclattice_target_group.getVpcLatticeTargetGroup", "api_error", err)
+		return nil, err
+	}
+
+	return op, nil
+}
@@ -0,0 +1,839 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+// The ports clients send requests from, which responses are sent to. Network
+// ACLs are stateless, so responses must be allowed to the whole range.
+const (
+	vpcNetworkEphemeralFromPort = 1024
+	vpcNetworkEphemeralToPort   = 65535
+)
+
+// vpcNetwork is the network configuration of the VPCs of a region, used to
+// evaluate traffic between network interfaces and addresses offline
+type vpcNetwork struct {
+	Region                       string
+	NetworkInterfaces            []types.NetworkInterface
+	Subnets                      map[string]types.Subnet
+	RouteTables                  []types.RouteTable
+	NetworkAcls                  []types.NetworkAcl
+	SecurityGroupRules           map[string][]types.SecurityGroupRule
+	PrefixLists                  map[string][]netip.Prefix
+	PeeringConnections           map[string]types.VpcPeeringConnection
+	InternetGateways             []types.InternetGateway
+	TransitGatewayAttachments    []types.TransitGatewayAttachment
+	TransitGatewayVpcAttachments map[string]types.TransitGatewayVpcAttachment
+	TransitGatewayRoutes         map[string][]types.TransitGatewayRoute
+	TransitGatewayPeerings       map[string]types.TransitGatewayPeeringAttachment
+
+	// Transit gateway route tables with more routes than a search returns,
+	// whose routes can't be selected with certainty
+	IncompleteTransitGatewayRouteTables map[string]bool
+}
+
+// getVpcNetwork returns the network configuration of the query region. It is
+// cached per connection and region, as a query evaluating traffic usually
+// needs most of it.
+func getVpcNetwork(ctx context.Context, d *plugin.QueryData) (*vpcNetwork, error) {
+	return getVpcNetworkForRegion(ctx, d, d.EqualsQualString(matrixKeyRegion))
+}
+
+// getVpcNetworkForRegion returns the network configuration of a region other
+// than the query region, e.g. the region of a transit gateway peer
+func getVpcNetworkForRegion(ctx context.Context, d *plugin.QueryData, region string) (*vpcNetwork, error) {
+	cacheKey := "getVpcNetwork-" + d.Connection.Name + "-" + region
+	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
+		return cachedData.(*vpcNetwork), nil
+	}
+
+	svc, err := EC2ClientForRegion(ctx, d, region)
+	if err != nil {
+		plugin.Logger(ctx).Error("getVpcNetworkForRegion", "client_error", err)
+		return nil, err
+	}
+
+	network, err := loadVpcNetwork(ctx, d, svc, region)
+	if err != nil {
+		plugin.Logger(ctx).Error("getVpcNetworkForRegion", "api_error", err, "region", region)
+		return nil, err
+	}
+
+	d.ConnectionManager.Cache.Set(cacheKey, network)
+	return network, nil
+}
+
+// getTransitGatewayNetworkForRegion returns the transit gateway configuration
+// of a region, without its VPCs, for queries that only follow transit gateway
+// routes. The network configuration of the region is used if already loaded.
+func getTransitGatewayNetworkForRegion(ctx context.Context, d *plugin.QueryData, region string) (*vpcNetwork, error) {
+	if cachedData, ok := d.ConnectionManager.Cache.Get("getVpcNetwork-" + d.Connection.Name + "-" + region); ok {
+		return cachedData.(*vpcNetwork), nil
+	}
+	cacheKey := "getTransitGatewayNetwork-" + d.Connection.Name + "-" + region
+	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
+		return cachedData.(*vpcNetwork), nil
+	}
+
+	svc, err := EC2ClientForRegion(ctx, d, region)
+	if err != nil {
+		plugin.Logger(ctx).Error("getTransitGatewayNetworkForRegion", "client_error", err)
+		return nil, err
+	}
+
+	network := newVpcNetwork(region)
+	if err := loadTransitGatewayNetwork(ctx, d, svc, network); err != nil {
+		plugin.Logger(ctx).Error("getTransitGatewayNetworkForRegion", "api_error", err, "region", region)
+		return nil, err
+	}
+
+	d.ConnectionManager.Cache.Set(cacheKey, network)
+	return network, nil
+}
+
+func newVpcNetwork(region string) *vpcNetwork {
+	return &vpcNetwork{
+		Region:                       region,
+		Subnets:                      map[string]types.Subnet{},
+		SecurityGroupRules:           map[string][]types.SecurityGroupRule{},
+		PrefixLists:                  map[string][]netip.Prefix{},
+		PeeringConnections:           map[string]types.VpcPeeringConnection{},
+		TransitGatewayVpcAttachments: map[string]types.TransitGatewayVpcAttachment{},
+		TransitGatewayRoutes:         map[string][]types.TransitGatewayRoute{},
+		TransitGatewayPeerings:       map[string]types.TransitGatewayPeeringAttachment{},
+
+		IncompleteTransitGatewayRouteTables: map[string]bool{},
+	}
+}
+
+func loadVpcNetwork(ctx context.Context, d *plugin.QueryData, svc *ec2.Client, region string) (*vpcNetwork, error) {
+	network := newVpcNetwork(region)
+
+	networkInterfaces := ec2.NewDescribeNetworkInterfacesPaginator(svc, &ec2.DescribeNetworkInterfacesInput{})
+	for networkInterfaces.HasMorePages() {
+		d.WaitForListRateLimit(ctx)
+		output, err := networkInterfaces.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		network.NetworkInterfaces = append(network.NetworkInterfaces, output.NetworkInterfaces...)
+	}
+
+	subnets := ec2.NewDescribeSubnetsPaginator(svc, &ec2.DescribeSubnetsInput{})
+	for subnets.HasMorePages() {
+		d.WaitForListRateLimit(ctx)
+		output, err := subnets.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, subnet := range output.Subnets {
+			network.Subnets[aws.ToString(subnet.SubnetId)] = subnet
+		}
+	}
+
+	routeTables := ec2.NewDescribeRouteTablesPaginator(svc, &ec2.DescribeRouteTablesInput{})
+	for routeTables.HasMorePages() {
+		d.WaitForListRateLimit(ctx)
+		output, err := routeTables.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		network.RouteTables = append(network.RouteTables, output.RouteTables...)
+	}
+
+	networkAcls := ec2.NewDescribeNetworkAclsPaginator(svc, &ec2.DescribeNetworkAclsInput{})
+	for networkAcls.HasMorePages() {
+		d.WaitForListRateLimit(ctx)
+		output, err := networkAcls.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, networkAcl := range output.NetworkAcls {
+			// Entries are evaluated in order of their rule numbers
+			sort.SliceStable(networkAcl.Entries, func(i, j int) bool {
+				return aws.ToInt32(networkAcl.Entries[i].RuleNumber) < aws.ToInt32(networkAcl.Entries[j].RuleNumber)
+			})
+			network.NetworkAcls = append(network.NetworkAcls, networkAcl)
+		}
+	}
+
+	securityGroupRules := ec2.NewDescribeSecurityGroupRulesPaginator(svc, &ec2.DescribeSecurityGroupRulesInput{})
+	for securityGroupRules.HasMorePages() {
+		d.WaitForListRateLimit(ctx)
+		output, err := securityGroupRules.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, rule := range output.SecurityGroupRules {
+			groupId := aws.ToString(rule.GroupId)
+			network.SecurityGroupRules[groupId] = append(network.SecurityGroupRules[groupId], rule)
+		}
+	}
+
+	peeringConnections := ec2.NewDescribeVpcPeeringConnectionsPaginator(svc, &ec2.DescribeVpcPeeringConnectionsInput{})
+	for peeringConnections.HasMorePages() {
+		d.WaitForListRateLimit(ctx)
+		output, err := peeringConnections.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, peeringConnection := range output.VpcPeeringConnections {
+			network.PeeringConnections[aws.ToString(peeringConnection.VpcPeeringConnectionId)] = peeringConnection
+		}
+	}
+
+	internetGateways := ec2.NewDescribeInternetGatewaysPaginator(svc, &ec2.DescribeInternetGatewaysInput{})
+	for internetGateways.HasMorePages() {
+		d.WaitForListRateLimit(ctx)
+		output, err := internetGateways.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		network.InternetGateways = append(network.InternetGateways, output.InternetGateways...)
+	}
+
+	if err := loadTransitGatewayNetwork(ctx, d, svc, network); err != nil {
+		return nil, err
+	}
+
+	return network, nil
+}
+
+// loadTransitGatewayNetwork loads the transit gateways of a region into a
+// network, with the prefix lists their routes can refer to
+func loadTransitGatewayNetwork(ctx context.Context, d *plugin.QueryData, svc *ec2.Client, network *vpcNetwork) error {
+	prefixLists := ec2.NewDescribeManagedPrefixListsPaginator(svc, &ec2.DescribeManagedPrefixListsInput{})
+	for prefixLists.HasMorePages() {
+		d.WaitForListRateLimit(ctx)
+		output, err := prefixLists.NextPage(ctx)
+		if err != nil {
+			return err
+		}
+		for _, prefixList := range output.PrefixLists {
+			entries := ec2.NewGetManagedPrefixListEntriesPaginator(svc, &ec2.GetManagedPrefixListEntriesInput{PrefixListId: prefixList.PrefixListId})
+			for entries.HasMorePages() {
+				d.WaitForListRateLimit(ctx)
+				entriesOutput, err := entries.NextPage(ctx)
+				if err != nil {
+					return err
+				}
+				for _, entry := range entriesOutput.Entries {
+					if prefix, err := netip.ParsePrefix(aws.ToString(entry.Cidr)); err == nil {
+						prefixListId := aws.ToString(prefixList.PrefixListId)
+						network.PrefixLists[prefixListId] = append(network.PrefixLists[prefixListId], prefix)
+					}
+				}
+			}
+		}
+	}
+
+	attachments := ec2.NewDescribeTransitGatewayAttachmentsPaginator(svc, &ec2.DescribeTransitGatewayAttachmentsInput{})
+	for attachments.HasMorePages() {
+		d.WaitForListRateLimit(ctx)
+		output, err := attachments.NextPage(ctx)
+		if err != nil {
+			return err
+		}
+		network.TransitGatewayAttachments = append(network.TransitGatewayAttachments, output.TransitGatewayAttachments...)
+	}
+
+	vpcAttachments := ec2.NewDescribeTransitGatewayVpcAttachmentsPaginator(svc, &ec2.DescribeTransitGatewayVpcAttachmentsInput{})
+	for vpcAttachments.HasMorePages() {
+		d.WaitForListRateLimit(ctx)
+		output, err := vpcAttachments.NextPage(ctx)
+		if err != nil {
+			return err
+		}
+		for _, attachment := range output.TransitGatewayVpcAttachments {
+			network.TransitGatewayVpcAttachments[aws.ToString(attachment.TransitGatewayAttachmentId)] = attachment
+		}
+	}
+
+	peeringAttachments := ec2.NewDescribeTransitGatewayPeeringAttachmentsPaginator(svc, &ec2.DescribeTransitGatewayPeeringAttachmentsInput{})
+	for peeringAttachments.HasMorePages() {
+		d.WaitForListRateLimit(ctx)
+		output, err := peeringAttachments.NextPage(ctx)
+		if err != nil {
+			return err
+		}
+		for _, attachment := range output.TransitGatewayPeeringAttachments {
+			network.TransitGatewayPeerings[aws.ToString(attachment.TransitGatewayAttachmentId)] = attachment
+		}
+	}
+
+	transitGatewayRouteTables := ec2.NewDescribeTransitGatewayRouteTablesPaginator(svc, &ec2.DescribeTransitGatewayRouteTablesInput{})
+	for transitGatewayRouteTables.HasMorePages() {
+		d.WaitForListRateLimit(ctx)
+		output, err := transitGatewayRouteTables.NextPage(ctx)
+		if err != nil {
+			return err
+		}
+		for _, routeTable := range output.TransitGatewayRouteTables {
+			d.WaitForListRateLimit(ctx)
+
+			// The state filter is required by the API
+			routes, err := svc.SearchTransitGatewayRoutes(ctx, &ec2.SearchTransitGatewayRoutesInput{
+				TransitGatewayRouteTableId: routeTable.TransitGatewayRouteTableId,
+				Filters:                    []types.Filter{{Name: aws.String("state"), Values: []string{"active", "blackhole"}}},
+				MaxResults:                 aws.Int32(1000),
+			})
+			if err != nil {
+				return err
+			}
+			routeTableId := aws.ToString(routeTable.TransitGatewayRouteTableId)
+			network.TransitGatewayRoutes[routeTableId] = routes.Routes
+			if aws.ToBool(routes.AdditionalRoutesAvailable) {
+				network.IncompleteTransitGatewayRouteTables[routeTableId] = true
+			}
+		}
+	}
+
+	return nil
+}
+
+//// NETWORK LOOKUPS
+
+// vpcNetworkEndpoint is the source or destination of traffic, either a
+// network interface of the region and one of its private addresses, or an
+// address outside of the region's VPCs
+type vpcNetworkEndpoint struct {
+	Address          netip.Addr
+	NetworkInterface *types.NetworkInterface
+}
+
+// resolveEndpoint resolves a network interface ID, instance ID, resource ARN
+// or IP address. Public addresses of network interfaces are resolved to their
+// private address. A nil endpoint is a resource that isn't in the region.
+func (n *vpcNetwork) resolveEndpoint(value string) (*vpcNetworkEndpoint, error) {
+	var networkInterface *types.NetworkInterface
+	switch {
+	case strings.HasPrefix(value, "arn:"):
+		resourceArn, err := arn.Parse(value)
+		if err != nil {
+			return nil, err
+		}
+		if resourceArn.Region != n.Region {
+			return nil, nil
+		}
+		networkInterface, err = n.arnNetworkInterface(resourceArn)
+		if err != nil {
+			return nil, err
+		}
+	case strings.HasPrefix(value, "eni-"):
+		networkInterface = n.networkInterface(value)
+	case strings.HasPrefix(value, "i-"):
+		networkInterface = n.instanceNetworkInterface(value)
+	default:
+		addr, err := netip.ParseAddr(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s is not a network interface ID, instance ID, resource ARN or IP address", value)
+		}
+		networkInterface = n.addressNetworkInterface(addr)
+		if networkInterface != nil && !slices.Contains(networkInterfaceAddresses(*networkInterface), addr) {
+			addr = networkInterfaceAddress(*networkInterface, false)
+		}
+		return &vpcNetworkEndpoint{Address: addr, NetworkInterface: networkInterface}, nil
+	}
+
+	if networkInterface == nil {
+		return nil, nil
+	}
+	return &vpcNetworkEndpoint{Address: networkInterfaceAddress(*networkInterface, false), NetworkInterface: networkInterface}, nil
+}
+
+// arnNetworkInterface returns the network interface of a resource, for the
+// resource types whose network interfaces can be identified
+func (n *vpcNetwork) arnNetworkInterface(resourceArn arn.ARN) (*types.NetworkInterface, error) {
+	switch resourceArn.Service {
+	case "ec2":
+		resourceType, resourceId, _ := strings.Cut(resourceArn.Resource, "/")
+		switch resourceType {
+		case "instance":
+			return n.instanceNetworkInterface(resourceId), nil
+		case "network-interface":
+			return n.networkInterface(resourceId), nil
+		}
+	case "elasticloadbalancing":
+		// The network interfaces of a load balancer are described as ELB
+		// followed by its name, e.g. ELB app/my-alb/50dc6c495c0c9188
+		if name, ok := strings.CutPrefix(resourceArn.Resource, "loadbalancer/"); ok {
+			return n.describedNetworkInterface("ELB "+name, false), nil
+		}
+	case "lambda":
+		// The network interfaces of a function are described as AWS Lambda
+		// VPC ENI- followed by the function name and an ID
+		if name, ok := strings.CutPrefix(resourceArn.Resource, "function:"); ok {
+			name, _, _ = strings.Cut(name, ":")
+			return n.describedNetworkInterface("AWS Lambda VPC ENI-"+name+"-", true), nil
+		}
+	case "elasticache":
+		// The network interfaces of a cache node are described as ElastiCache
+		// followed by the cluster ID. The clusters of a replication group are
+		// named after the group, e.g. my-group-0001-001.
+		resourceType, resourceId, _ := strings.Cut(resourceArn.Resource, ":")
+		switch resourceType {
+		case "cluster":
+			return n.describedNetworkInterface("ElastiCache "+resourceId, false), nil
+		case "replicationgroup":
+			return n.describedNetworkInterface("ElastiCache "+resourceId+"-", true), nil
+		}
+	case "es":
+		// The network interfaces of an OpenSearch domain are described as ES
+		// followed by the domain name
+		if name, ok := strings.CutPrefix(resourceArn.Resource, "domain/"); ok {
+			return n.describedNetworkInterface("ES "+name, false), nil
+		}
+	}
+	return nil, fmt.Errorf("the network interface of %s can't be resolved, use its IP address instead", resourceArn.String())
+}
+
+// describedNetworkInterface returns the network interface with a description,
+// or a description prefix
+func (n *vpcNetwork) describedNetworkInterface(description string, prefix bool) *types.NetworkInterface {
+	for i, networkInterface := range n.NetworkInterfaces {
+		if aws.ToString(networkInterface.Description) == description || (prefix && strings.HasPrefix(aws.ToString(networkInterface.Description), description)) {
+			return &n.NetworkInterfaces[i]
+		}
+	}
+	return nil
+}
+
+func (n *vpcNetwork) networkInterface(networkInterfaceId string) *types.NetworkInterface {
+	for i, networkInterface := range n.NetworkInterfaces {
+		if aws.ToString(networkInterface.NetworkInterfaceId) == networkInterfaceId {
+			return &n.NetworkInterfaces[i]
+		}
+	}
+	return nil
+}
+
+// instanceNetworkInterface returns the primary network interface of an
+// instance
+func (n *vpcNetwork) instanceNetworkInterface(instanceId string) *types.NetworkInterface {
+	var found *types.NetworkInterface
+	for i, networkInterface := range n.NetworkInterfaces {
+		attachment := networkInterface.Attachment
+		if attachment == nil || aws.ToString(attachment.InstanceId) != instanceId {
+			continue
+		}
+		if aws.ToInt32(attachment.DeviceIndex) == 0 {
+			return &n.NetworkInterfaces[i]
+		}
+		if found == nil {
+			found = &n.NetworkInterfaces[i]
+		}
+	}
+	return found
+}
+
+// addressNetworkInterface returns the network interface with a private or
+// public address
+func (n *vpcNetwork) addressNetworkInterface(addr netip.Addr) *types.NetworkInterface {
+	for i, networkInterface := range n.NetworkInterfaces {
+		for _, address := range networkInterfaceAddresses(networkInterface) {
+			if address == addr {
+				return &n.NetworkInterfaces[i]
+			}
+		}
+		for _, address := range networkInterfacePublicAddresses(networkInterface) {
+			if address == addr {
+				return &n.NetworkInterfaces[i]
+			}
+		}
+	}
+	return nil
+}
+
+// natGatewayNetworkInterface returns the network interface of a NAT gateway
+func (n *vpcNetwork) natGatewayNetworkInterface(natGatewayId string) *types.NetworkInterface {
+	for i, networkInterface := range n.NetworkInterfaces {
+		if networkInterface.InterfaceType == types.NetworkInterfaceTypeNatGateway && strings.HasSuffix(aws.ToString(networkInterface.Description), natGatewayId) {
+			return &n.NetworkInterfaces[i]
+		}
+	}
+	return nil
+}
+
+// securityGroupNetworkInterfaces returns the network interfaces a security
+// group is attached to
+func (n *vpcNetwork) securityGroupNetworkInterfaces(groupId string) []types.NetworkInterface {
+	var networkInterfaces []types.NetworkInterface
+	for _, networkInterface := range n.NetworkInterfaces {
+		for _, group := range networkInterface.Groups {
+			if aws.ToString(group.GroupId) == groupId {
+				networkInterfaces = append(networkInterfaces, networkInterface)
+				break
+			}
+		}
+	}
+	return networkInterfaces
+}
+
+// subnetRouteTable returns the route table associated with a subnet, or the
+// main route table of its VPC
+func (n *vpcNetwork) subnetRouteTable(vpcId string, subnetId string) *types.RouteTable {
+	var main *types.RouteTable
+	for i, routeTable := range n.RouteTables {
+		for _, association := range routeTable.Associations {
+			if subnetId != "" && aws.ToString(association.SubnetId) == subnetId {
+				return &n.RouteTables[i]
+			}
+			if aws.ToBool(association.Main) && aws.ToString(routeTable.VpcId) == vpcId {
+				main = &n.RouteTables[i]
+			}
+		}
+	}
+	return main
+}
+
+func (n *vpcNetwork) subnetNetworkAcl(subnetId string) *types.NetworkAcl {
+	for i, networkAcl := range n.NetworkAcls {
+		for _, association := range networkAcl.Associations {
+			if aws.ToString(association.SubnetId) == subnetId {
+				return &n.NetworkAcls[i]
+			}
+		}
+	}
+	return nil
+}
+
+// vpcInternetGateway returns the internet gateway attached to a VPC
+func (n *vpcNetwork) vpcInternetGateway(vpcId string) *types.InternetGateway {
+	for i, internetGateway := range n.InternetGateways {
+		for _, attachment := range internetGateway.Attachments {
+			// Attached internet gateways are in the available state
+			if aws.ToString(attachment.VpcId) == vpcId && attachment.State != types.AttachmentStatusDetaching && attachment.State != types.AttachmentStatusDetached {
+				return &n.InternetGateways[i]
+			}
+		}
+	}
+	return nil
+}
+
+// peeredVpcId returns the VPC on the other side of an active peering
+// connection, if it is in the region
+func (n *vpcNetwork) peeredVpcId(peeringConnectionId string, vpcId string) string {
+	peeringConnection, ok := n.PeeringConnections[peeringConnectionId]
+	if !ok || peeringConnection.Status == nil || peeringConnection.Status.Code != types.VpcPeeringConnectionStateReasonCodeActive {
+		return ""
+	}
+	accepter, requester := peeringConnection.AccepterVpcInfo, peeringConnection.RequesterVpcInfo
+	if accepter == nil || requester == nil {
+		return ""
+	}
+	peer := accepter
+	if aws.ToString(accepter.VpcId) == vpcId {
+		peer = requester
+	}
+	if aws.ToString(peer.Region) != "" && aws.ToString(peer.Region) != n.Region {
+		return ""
+	}
+	return aws.ToString(peer.VpcId)
+}
+
+// vpcsPeered returns whether two VPCs are the same, or peered, which are the
+// cases where security group rules can reference the groups of the other
+func (n *vpcNetwork) vpcsPeered(vpcId string, otherVpcId string) bool {
+	if vpcId == otherVpcId {
+		return true
+	}
+	for peeringConnectionId := range n.PeeringConnections {
+		if n.peeredVpcId(peeringConnectionId, vpcId) == otherVpcId {
+			return true
+		}
+	}
+	return false
+}
+
+// transitGatewayAttachment returns the attachment of a resource, e.g. a VPC,
+// to a transit gateway
+func (n *vpcNetwork) transitGatewayAttachment(transitGatewayId string, resourceId string) *types.TransitGatewayAttachment {
+	for i, attachment := range n.TransitGatewayAttachments {
+		if aws.ToString(attachment.TransitGatewayId) == transitGatewayId && aws.ToString(attachment.ResourceId) == resourceId && attachment.State == types.TransitGatewayAttachmentStateAvailable {
+			return &n.TransitGatewayAttachments[i]
+		}
+	}
+	return nil
+}
+
+// transitGatewayAttachmentById returns a transit gateway attachment of the
+// region
+func (n *vpcNetwork) transitGatewayAttachmentById(attachmentId string) *types.TransitGatewayAttachment {
+	for i, attachment := range n.TransitGatewayAttachments {
+		if aws.ToString(attachment.TransitGatewayAttachmentId) == attachmentId {
+			return &n.TransitGatewayAttachments[i]
+		}
+	}
+	return nil
+}
+
+//// RULE EVALUATION
+
+// prefixes parses CIDRs, and expands a prefix list into its CIDRs
+func (n *vpcNetwork) prefixes(prefixListId *string, cidrs ...*string) []netip.Prefix {
+	var prefixes []netip.Prefix
+	for _, cidr := range cidrs {
+		if prefix, err := netip.ParsePrefix(aws.ToString(cidr)); err == nil {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	if prefixListId != nil {
+		prefixes = append(prefixes, n.PrefixLists[*prefixListId]...)
+	}
+	return prefixes
+}
+
+// longestPrefixMatch returns the length of the longest of the prefixes
+// containing the address, or -1 if none does
+func longestPrefixMatch(addr netip.Addr, prefixes []netip.Prefix) int {
+	bits := -1
+	for _, prefix := range prefixes {
+		if prefix.Contains(addr) && prefix.Bits() > bits {
+			bits = prefix.Bits()
+		}
+	}
+	return bits
+}
+
+// longestPrefixCover returns the length of the longest of the prefixes
+// containing the whole destination prefix, or -1 if none does
+func longestPrefixCover(destination netip.Prefix, prefixes []netip.Prefix) int {
+	bits := -1
+	for _, prefix := range prefixes {
+		if prefix.Bits() <= destination.Bits() && prefix.Contains(destination.Addr()) && prefix.Bits() > bits {
+			bits = prefix.Bits()
+		}
+	}
+	return bits
+}
+
+// selectRoute returns the route of a route table with the longest prefix
+// matching the address
+func (n *vpcNetwork) selectRoute(routeTable *types.RouteTable, addr netip.Addr) *types.Route {
+	var selected *types.Route
+	selectedBits := -1
+	for i, route := range routeTable.Routes {
+		bits := longestPrefixMatch(addr, n.prefixes(route.DestinationPrefixListId, route.DestinationCidrBlock, route.DestinationIpv6CidrBlock))
+		if bits > selectedBits {
+			selected, selectedBits = &routeTable.Routes[i], bits
+		}
+	}
+	return selected
+}
+
+// selectTransitGatewayRoute returns the route of a transit gateway route
+// table with the longest prefix matching the address
+func (n *vpcNetwork) selectTransitGatewayRoute(routeTableId string, addr netip.Addr) *types.TransitGatewayRoute {
+	return n.selectTransitGatewayRouteForPrefix(routeTableId, netip.PrefixFrom(addr, addr.BitLen()))
+}
+
+// selectTransitGatewayRouteForPrefix returns the route of a transit gateway
+// route table with the longest prefix containing the whole destination prefix
+func (n *vpcNetwork) selectTransitGatewayRouteForPrefix(routeTableId string, destination netip.Prefix) *types.TransitGatewayRoute {
+	routes := n.TransitGatewayRoutes[routeTableId]
+	var selected *types.TransitGatewayRoute
+	selectedBits := -1
+	for i, route := range routes {
+		bits := longestPrefixCover(destination, n.prefixes(route.PrefixListId, route.DestinationCidrBlock))
+		if bits > selectedBits {
+			selected, selectedBits = &routes[i], bits
+		}
+	}
+	return selected
+}
+
+// matchNetworkAclEntry returns the first entry, in rule number order, of the
+// network ACL of a subnet matching the traffic. A nil entry means the traffic
+// is denied as no entry matches.
+func (n *vpcNetwork) matchNetworkAclEntry(networkAcl *types.NetworkAcl, egress bool, protocol string, port int32, peer netip.Addr) *types.NetworkAclEntry {
+	for i, entry := range networkAcl.Entries {
+		if aws.ToBool(entry.Egress) != egress || !vpcNetworkProtocolMatches(aws.ToString(entry.Protocol), protocol) {
+			continue
+		}
+		if longestPrefixMatch(peer, n.prefixes(nil, entry.CidrBlock, entry.Ipv6CidrBlock)) < 0 {
+			continue
+		}
+		if entry.PortRange != nil && !vpcNetworkPortMatches(protocol, entry.PortRange.From, entry.PortRange.To, port) {
+			continue
+		}
+		return &networkAcl.Entries[i]
+	}
+	return nil
+}
+
+// networkAclAllowedPorts returns the parts of a port range the entries of a
+// network ACL allow for traffic to or from a peer
+func (n *vpcNetwork) networkAclAllowedPorts(networkAcl *types.NetworkAcl, egress bool, protocol string, peer netip.Addr, fromPort int32, toPort int32) [][2]int32 {
+	return networkAclPortRanges(networkAcl, func(entry types.NetworkAclEntry) bool {
+		return aws.ToBool(entry.Egress) == egress && vpcNetworkProtocolMatches(aws.ToString(entry.Protocol), protocol) && longestPrefixMatch(peer, n.prefixes(nil, entry.CidrBlock, entry.Ipv6CidrBlock)) >= 0
+	}, fromPort, toPort)
+}
+
+// networkAclPortRanges returns the parts of a port range allowed by the
+// entries of a network ACL that match. In rule number order, each entry
+// decides the ports of its range no earlier entry decided.
+func networkAclPortRanges(networkAcl *types.NetworkAcl, matches func(types.NetworkAclEntry) bool, fromPort int32, toPort int32) [][2]int32 {
+	var allowed [][2]int32
+	undecided := [][2]int32{{fromPort, toPort}}
+	for _, entry := range networkAcl.Entries {
+		if !matches(entry) {
+			continue
+		}
+		entryFrom, entryTo := int32(0), int32(65535)
+		if entry.PortRange != nil {
+			entryFrom, entryTo = aws.ToInt32(entry.PortRange.From), aws.ToInt32(entry.PortRange.To)
+		}
+
+		var remaining [][2]int32
+		for _, portRange := range undecided {
+			// The part of the range the entry decides
+			if from, to := max(portRange[0], entryFrom), min(portRange[1], entryTo); from <= to {
+				if entry.RuleAction == types.RuleActionAllow {
+					allowed = append(allowed, [2]int32{from, to})
+				}
+			}
+			// The parts below and above the entry's range stay undecided
+			if portRange[0] < entryFrom {
+				remaining = append(remaining, [2]int32{portRange[0], min(portRange[1], entryFrom-1)})
+			}
+			if portRange[1] > entryTo {
+				remaining = append(remaining, [2]int32{max(portRange[0], entryTo+1), portRange[1]})
+			}
+		}
+		undecided = remaining
+	}
+	return allowed
+}
+
+// matchSecurityGroupRule returns the first rule of the security groups
+// allowing the traffic, or nil if no rule does. Peer groups are the groups of
+// the network interface on the other side, matched against rules
+// referencing groups.
+func (n *vpcNetwork) matchSecurityGroupRule(groups []types.GroupIdentifier, egress bool, protocol string, port int32, peer netip.Addr, peerGroups []types.GroupIdentifier) *types.SecurityGroupRule {
+	for _, group := range groups {
+		rules := n.SecurityGroupRules[aws.ToString(group.GroupId)]
+		for i, rule := range rules {
+			if aws.ToBool(rule.IsEgress) == egress && n.securityGroupRuleMatches(rule, protocol, port, peer, peerGroups) {
+				return &rules[i]
+			}
+		}
+	}
+	return nil
+}
+
+func (n *vpcNetwork) securityGroupRuleMatches(rule types.SecurityGroupRule, protocol string, port int32, peer netip.Addr, peerGroups []types.GroupIdentifier) bool {
+	if !vpcNetworkProtocolMatches(aws.ToString(rule.IpProtocol), protocol) || !vpcNetworkPortMatches(protocol, rule.FromPort, rule.ToPort, port) {
+		return false
+	}
+	if rule.ReferencedGroupInfo != nil {
+		for _, group := range peerGroups {
+			if aws.ToString(group.GroupId) == aws.ToString(rule.ReferencedGroupInfo.GroupId) {
+				return true
+			}
+		}
+		return false
+	}
+	return peer.IsValid() && longestPrefixMatch(peer, n.prefixes(rule.PrefixListId, rule.CidrIpv4, rule.CidrIpv6)) >= 0
+}
+
+//// UTILITY FUNCTIONS
+
+// vpcNetworkProtocol returns the protocol number of a protocol name, as used
+// by security group rules and network ACL entries, with -1 for all protocols
+func vpcNetworkProtocol(protocol string) string {
+	switch strings.ToLower(protocol) {
+	case "all", "-1":
+		return "-1"
+	case "tcp":
+		return "6"
+	case "udp":
+		return "17"
+	case "icmp":
+		return "1"
+	case "icmpv6":
+		return "58"
+	}
+	return protocol
+}
+
+func vpcNetworkProtocolMatches(ruleProtocol string, protocol string) bool {
+	ruleProtocol = vpcNetworkProtocol(ruleProtocol)
+	return ruleProtocol == "-1" || ruleProtocol == protocol
+}
+
+// vpcNetworkPortMatches returns whether the port is in a rule's port range.
+// Ports only apply to TCP and UDP, a port of -1 matches any range.
+func vpcNetworkPortMatches(protocol string, fromPort *int32, toPort *int32, port int32) bool {
+	if (protocol != "6" && protocol != "17") || port < 0 || fromPort == nil || aws.ToInt32(fromPort) == -1 {
+		return true
+	}
+	return aws.ToInt32(fromPort) <= port && port <= aws.ToInt32(toPort)
+}
+
+// portRangesCover returns whether port ranges that don't overlap, e.g. the
+// ranges a network ACL allows, cover a whole port range
+func portRangesCover(portRanges [][2]int32, fromPort int32, toPort int32) bool {
+	covered := 0
+	for _, portRange := range portRanges {
+		if from, to := max(portRange[0], fromPort), min(portRange[1], toPort); from <= to {
+			covered += int(to-from) + 1
+		}
+	}
+	return covered == int(toPort-fromPort)+1
+}
+
+// networkInterfaceAddresses returns the private IPv4 and the IPv6 addresses
+// of a network interface
+func networkInterfaceAddresses(networkInterface types.NetworkInterface) []netip.Addr {
+	var addresses []netip.Addr
+	for _, address := range networkInterface.PrivateIpAddresses {
+		if addr, err := netip.ParseAddr(aws.ToString(address.PrivateIpAddress)); err == nil {
+			addresses = append(addresses, addr)
+		}
+	}
+	for _, address := range networkInterface.Ipv6Addresses {
+		if addr, err := netip.ParseAddr(aws.ToString(address.Ipv6Address)); err == nil {
+			addresses = append(addresses, addr)
+		}
+	}
+	return addresses
+}
+
+// networkInterfaceAddress returns the primary private IPv4 address of a
+// network interface, or its first IPv6 address
+func networkInterfaceAddress(networkInterface types.NetworkInterface, ipv6 bool) netip.Addr {
+	for _, addr := range networkInterfaceAddresses(networkInterface) {
+		if addr.Is6() == ipv6 {
+			return addr
+		}
+	}
+	return netip.Addr{}
+}
+
+// networkInterfacePublicAddresses returns the public IPv4 addresses of a
+// network interface, including Elastic IP addresses
+func networkInterfacePublicAddresses(networkInterface types.NetworkInterface) []netip.Addr {
+	var addresses []netip.Addr
+	for _, address := range networkInterface.PrivateIpAddresses {
+		if address.Association == nil {
+			continue
+		}
+		if addr, err := netip.ParseAddr(aws.ToString(address.Association.PublicIp)); err == nil {
+			addresses = append(addresses, addr)
+		}
+	}
+	return addresses
+}
@@ -0,0 +1,521 @@
+package aws
+
+import (
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+func testNetworkAclEntry(ruleNumber int32, egress bool, protocol string, cidr string, fromPort int32, toPort int32, action types.RuleAction) types.NetworkAclEntry {
+	entry := types.NetworkAclEntry{
+		RuleNumber: aws.Int32(ruleNumber),
+		Egress:     aws.Bool(egress),
+		Protocol:   aws.String(protocol),
+		RuleAction: action,
+	}
+	if strings.Contains(cidr, ":") {
+		entry.Ipv6CidrBlock = aws.String(cidr)
+	} else {
+		entry.CidrBlock = aws.String(cidr)
+	}
+	if fromPort >= 0 {
+		entry.PortRange = &types.PortRange{From: aws.Int32(fromPort), To: aws.Int32(toPort)}
+	}
+	return entry
+}
+
+// testNetworkAcl returns a network ACL allowing all traffic, with the entries
+// given evaluated first
+func testNetworkAcl(networkAclId string, subnetIds []string, entries ...types.NetworkAclEntry) types.NetworkAcl {
+	entries = append(entries,
+		testNetworkAclEntry(100, false, "-1", "0.0.0.0/0", -1, -1, types.RuleActionAllow),
+		testNetworkAclEntry(100, true, "-1", "0.0.0.0/0", -1, -1, types.RuleActionAllow),
+		testNetworkAclEntry(32767, false, "-1", "0.0.0.0/0", -1, -1, types.RuleActionDeny),
+		testNetworkAclEntry(32767, true, "-1", "0.0.0.0/0", -1, -1, types.RuleActionDeny),
+	)
+	networkAcl := types.NetworkAcl{NetworkAclId: aws.String(networkAclId), Entries: entries}
+	for _, subnetId := range subnetIds {
+		networkAcl.Associations = append(networkAcl.Associations, types.NetworkAclAssociation{SubnetId: aws.String(subnetId)})
+	}
+	return networkAcl
+}
+
+func testNetworkInterface(networkInterfaceId string, vpcId string, subnetId string, address string, groupIds ...string) types.NetworkInterface {
+	networkInterface := types.NetworkInterface{
+		NetworkInterfaceId: aws.String(networkInterfaceId),
+		InterfaceType:      types.NetworkInterfaceTypeInterface,
+		VpcId:              aws.String(vpcId),
+		SubnetId:           aws.String(subnetId),
+		AvailabilityZone:   aws.String("us-east-1a"),
+		PrivateIpAddress:   aws.String(address),
+		PrivateIpAddresses: []types.NetworkInterfacePrivateIpAddress{{PrivateIpAddress: aws.String(address), Primary: aws.Bool(true)}},
+	}
+	for _, groupId := range groupIds {
+		networkInterface.Groups = append(networkInterface.Groups, types.GroupIdentifier{GroupId: aws.String(groupId)})
+	}
+	return networkInterface
+}
+
+func testRoute(destination string, target string) types.Route {
+	route := types.Route{State: types.RouteStateActive}
+	switch {
+	case strings.HasPrefix(destination, "pl-"):
+		route.DestinationPrefixListId = aws.String(destination)
+	case strings.Contains(destination, ":"):
+		route.DestinationIpv6CidrBlock = aws.String(destination)
+	default:
+		route.DestinationCidrBlock = aws.String(destination)
+	}
+	switch {
+	case strings.HasPrefix(target, "nat-"):
+		route.NatGatewayId = aws.String(target)
+	case strings.HasPrefix(target, "pcx-"):
+		route.VpcPeeringConnectionId = aws.String(target)
+	case strings.HasPrefix(target, "tgw-"):
+		route.TransitGatewayId = aws.String(target)
+	case strings.HasPrefix(target, "eni-"):
+		route.NetworkInterfaceId = aws.String(target)
+	case strings.HasPrefix(target, "eigw-"):
+		route.EgressOnlyInternetGatewayId = aws.String(target)
+	default:
+		route.GatewayId = aws.String(target)
+	}
+	return route
+}
+
+func testRouteTable(routeTableId string, vpcId string, subnetId string, routes ...types.Route) types.RouteTable {
+	return types.RouteTable{
+		RouteTableId: aws.String(routeTableId),
+		VpcId:        aws.String(vpcId),
+		Associations: []types.RouteTableAssociation{{SubnetId: aws.String(subnetId)}},
+		Routes:       routes,
+	}
+}
+
+// testVpcNetwork returns a network of three VPCs:
+//   - vpc-a (10.0.0.0/16) with a private subnet, subnet-a1, and a public
+//     subnet, subnet-a2, with a NAT gateway and a firewall appliance
+//   - vpc-b (10.1.0.0/16), peered with vpc-a
+//   - vpc-c (10.2.0.0/16), attached with vpc-a to a transit gateway
+func testVpcNetwork() *vpcNetwork {
+	natInterface := testNetworkInterface("eni-nat", "vpc-a", "subnet-a2", "10.0.2.10")
+	natInterface.InterfaceType = types.NetworkInterfaceTypeNatGateway
+	natInterface.Description = aws.String("Interface for NAT Gateway nat-1")
+	natInterface.PrivateIpAddresses[0].Association = &types.NetworkInterfaceAssociation{PublicIp: aws.String("203.0.113.10")}
+
+	return &vpcNetwork{
+		Region: "us-east-1",
+		NetworkInterfaces: []types.NetworkInterface{
+			testNetworkInterface("eni-src", "vpc-a", "subnet-a1", "10.0.1.10", "sg-src"),
+			testNetworkInterface("eni-dst", "vpc-b", "subnet-b1", "10.1.1.10", "sg-dst"),
+			testNetworkInterface("eni-c", "vpc-c", "subnet-c1", "10.2.1.10", "sg-c"),
+			testNetworkInterface("eni-fw", "vpc-a", "subnet-a2", "10.0.2.20"),
+			natInterface,
+		},
+		Subnets: map[string]types.Subnet{
+			"subnet-c1": {SubnetId: aws.String("subnet-c1"), AvailabilityZone: aws.String("us-east-1a")},
+		},
+		RouteTables: []types.RouteTable{
+			testRouteTable("rtb-a1", "vpc-a", "subnet-a1",
+				testRoute("10.0.0.0/16", "local"),
+				testRoute("10.1.0.0/16", "pcx-1"),
+				testRoute("10.2.0.0/16", "tgw-1"),
+				testRoute("10.3.0.0/16", "eni-fw"),
+				testRoute("0.0.0.0/0", "nat-1"),
+			),
+			// The appliance routes traffic to itself, which loops
+			testRouteTable("rtb-a2", "vpc-a", "subnet-a2",
+				testRoute("10.0.0.0/16", "local"),
+				testRoute("10.3.0.0/16", "eni-fw"),
+				testRoute("0.0.0.0/0", "igw-1"),
+			),
+			testRouteTable("rtb-b1", "vpc-b", "subnet-b1",
+				testRoute("10.1.0.0/16", "local"),
+				testRoute("10.0.0.0/16", "pcx-1"),
+			),
+			testRouteTable("rtb-c1", "vpc-c", "subnet-c1",
+				testRoute("10.2.0.0/16", "local"),
+				testRoute("10.0.0.0/16", "tgw-1"),
+			),
+		},
+		NetworkAcls: []types.NetworkAcl{
+			testNetworkAcl("acl-a", []string{"subnet-a1", "subnet-a2"}),
+			testNetworkAcl("acl-b", []string{"subnet-b1"}),
+			testNetworkAcl("acl-c", []string{"subnet-c1"}),
+		},
+		SecurityGroupRules: map[string][]types.SecurityGroupRule{
+			"sg-src": {
+				{GroupId: aws.String("sg-src"), SecurityGroupRuleId: aws.String("sgr-src-egress"), IsEgress: aws.Bool(true), IpProtocol: aws.String("-1"), FromPort: aws.Int32(-1), ToPort: aws.Int32(-1), CidrIpv4: aws.String("0.0.0.0/0")},
+			},
+			"sg-dst": {
+				{GroupId: aws.String("sg-dst"), SecurityGroupRuleId: aws.String("sgr-dst-https"), IsEgress: aws.Bool(false), IpProtocol: aws.String("tcp"), FromPort: aws.Int32(443), ToPort: aws.Int32(443), ReferencedGroupInfo: &types.ReferencedSecurityGroup{GroupId: aws.String("sg-src")}},
+			},
+			"sg-c": {
+				{GroupId: aws.String("sg-c"), SecurityGroupRuleId: aws.String("sgr-c-https"), IsEgress: aws.Bool(false), IpProtocol: aws.String("tcp"), FromPort: aws.Int32(443), ToPort: aws.Int32(443), CidrIpv4: aws.String("10.0.0.0/8")},
+			},
+		},
+		PrefixLists: map[string][]netip.Prefix{},
+		PeeringConnections: map[string]types.VpcPeeringConnection{
+			"pcx-1": {
+				VpcPeeringConnectionId: aws.String("pcx-1"),
+				Status:                 &types.VpcPeeringConnectionStateReason{Code: types.VpcPeeringConnectionStateReasonCodeActive},
+				RequesterVpcInfo:       &types.VpcPeeringConnectionVpcInfo{VpcId: aws.String("vpc-a"), Region: aws.String("us-east-1")},
+				AccepterVpcInfo:        &types.VpcPeeringConnectionVpcInfo{VpcId: aws.String("vpc-b"), Region: aws.String("us-east-1")},
+			},
+		},
+		InternetGateways: []types.InternetGateway{
+			{InternetGatewayId: aws.String("igw-1"), Attachments: []types.InternetGatewayAttachment{{VpcId: aws.String("vpc-a"), State: types.AttachmentStatus("available")}}},
+		},
+		TransitGatewayAttachments: []types.TransitGatewayAttachment{
+			{
+				TransitGatewayAttachmentId: aws.String("tgw-attach-a"),
+				TransitGatewayId:           aws.String("tgw-1"),
+				ResourceId:                 aws.String("vpc-a"),
+				ResourceType:               types.TransitGatewayAttachmentResourceTypeVpc,
+				State:                      types.TransitGatewayAttachmentStateAvailable,
+				Association:                &types.TransitGatewayAttachmentAssociation{TransitGatewayRouteTableId: aws.String("tgw-rtb-1"), State: types.TransitGatewayAssociationStateAssociated},
+			},
+			{
+				TransitGatewayAttachmentId: aws.String("tgw-attach-c"),
+				TransitGatewayId:           aws.String("tgw-1"),
+				ResourceId:                 aws.String("vpc-c"),
+				ResourceType:               types.TransitGatewayAttachmentResourceTypeVpc,
+				State:                      types.TransitGatewayAttachmentStateAvailable,
+				Association:                &types.TransitGatewayAttachmentAssociation{TransitGatewayRouteTableId: aws.String("tgw-rtb-1"), State: types.TransitGatewayAssociationStateAssociated},
+			},
+		},
+		TransitGatewayVpcAttachments: map[string]types.TransitGatewayVpcAttachment{
+			"tgw-attach-c": {TransitGatewayAttachmentId: aws.String("tgw-attach-c"), SubnetIds: []string{"subnet-c1"}},
+		},
+		TransitGatewayRoutes: map[string][]types.TransitGatewayRoute{
+			"tgw-rtb-1": {
+				{
+					DestinationCidrBlock: aws.String("10.2.0.0/16"),
+					State:                types.TransitGatewayRouteStateActive,
+					TransitGatewayAttachments: []types.TransitGatewayRouteAttachment{
+						{TransitGatewayAttachmentId: aws.String("tgw-attach-c"), ResourceId: aws.String("vpc-c"), ResourceType: types.TransitGatewayAttachmentResourceTypeVpc},
+					},
+				},
+			},
+		},
+		TransitGatewayPeerings:              map[string]types.TransitGatewayPeeringAttachment{},
+		IncompleteTransitGatewayRouteTables: map[string]bool{},
+	}
+}
+
+func TestLongestPrefixMatch(t *testing.T) {
+	prefixes := []netip.Prefix{
+		netip.MustParsePrefix("0.0.0.0/0"),
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("10.0.1.0/24"),
+		netip.MustParsePrefix("2001:db8::/32"),
+	}
+	tests := []struct {
+		addr     string
+		expected int
+	}{
+		{"10.0.1.5", 24},
+		{"10.2.0.1", 8},
+		{"192.168.0.1", 0},
+		{"2001:db8::1", 32},
+		{"2001:db9::1", -1},
+	}
+	for _, test := range tests {
+		if bits := longestPrefixMatch(netip.MustParseAddr(test.addr), prefixes); bits != test.expected {
+			t.Errorf("longestPrefixMatch(%s) = %d, expected %d", test.addr, bits, test.expected)
+		}
+	}
+}
+
+func TestVpcNetworkSelectRoute(t *testing.T) {
+	network := &vpcNetwork{
+		PrefixLists: map[string][]netip.Prefix{
+			"pl-1": {netip.MustParsePrefix("192.168.0.0/24")},
+		},
+	}
+	blackhole := testRoute("10.9.0.0/16", "nat-2")
+	blackhole.State = types.RouteStateBlackhole
+	routeTable := testRouteTable("rtb-1", "vpc-a", "subnet-a1",
+		testRoute("10.0.0.0/16", "local"),
+		testRoute("0.0.0.0/0", "igw-1"),
+		testRoute("10.1.0.0/16", "pcx-1"),
+		testRoute("10.1.2.0/24", "eni-fw"),
+		testRoute("pl-1", "tgw-1"),
+		testRoute("::/0", "eigw-1"),
+		blackhole,
+	)
+	tests := []struct {
+		addr     string
+		expected string
+	}{
+		{"10.0.3.4", "local"},
+		{"10.1.3.4", "pcx-1"},
+		{"10.1.2.3", "eni-fw"},
+		{"192.168.0.10", "tgw-1"},
+		{"192.168.1.10", "igw-1"},
+		{"2001:db8::1", "eigw-1"},
+		{"10.9.0.1", "nat-2"},
+	}
+	for _, test := range tests {
+		route := network.selectRoute(&routeTable, netip.MustParseAddr(test.addr))
+		if route == nil || vpcRouteTarget(route) != test.expected {
+			t.Errorf("selectRoute(%s) = %v, expected %s", test.addr, route, test.expected)
+		}
+	}
+
+	// No route matches IPv6 traffic without an IPv6 route
+	routeTable.Routes = routeTable.Routes[:1]
+	if route := network.selectRoute(&routeTable, netip.MustParseAddr("2001:db8::1")); route != nil {
+		t.Errorf("expected no route, got %s", vpcRouteTarget(route))
+	}
+}
+
+func TestMatchNetworkAclEntry(t *testing.T) {
+	network := &vpcNetwork{}
+	networkAcl := testNetworkAcl("acl-1", nil,
+		testNetworkAclEntry(80, false, "6", "0.0.0.0/0", 22, 22, types.RuleActionDeny),
+		testNetworkAclEntry(90, false, "6", "10.0.0.0/8", 0, 65535, types.RuleActionAllow),
+		testNetworkAclEntry(95, false, "17", "0.0.0.0/0", 53, 53, types.RuleActionAllow),
+		testNetworkAclEntry(96, true, "6", "10.0.0.0/8", 5432, 5432, types.RuleActionDeny),
+	)
+
+	tests := []struct {
+		egress     bool
+		protocol   string
+		port       int32
+		peer       string
+		ruleNumber int32
+	}{
+		{false, "6", 22, "10.1.2.3", 80},
+		{false, "6", 443, "10.1.2.3", 90},
+		{false, "6", 443, "8.8.8.8", 100},
+		{false, "17", 53, "8.8.8.8", 95},
+		{false, "1", -1, "8.8.8.8", 100},
+		{true, "6", 5432, "10.1.2.3", 96},
+		{true, "6", 5432, "8.8.8.8", 100},
+		{false, "6", 443, "2001:db8::1", -1},
+	}
+	for _, test := range tests {
+		entry := network.matchNetworkAclEntry(&networkAcl, test.egress, test.protocol, test.port, netip.MustParseAddr(test.peer))
+		ruleNumber := int32(-1)
+		if entry != nil {
+			ruleNumber = aws.ToInt32(entry.RuleNumber)
+		}
+		if ruleNumber != test.ruleNumber {
+			t.Errorf("matchNetworkAclEntry(egress %v, %s/%d, %s) = entry %d, expected %d", test.egress, test.protocol, test.port, test.peer, ruleNumber, test.ruleNumber)
+		}
+	}
+}
+
+func TestMatchSecurityGroupRule(t *testing.T) {
+	network := &vpcNetwork{
+		SecurityGroupRules: map[string][]types.SecurityGroupRule{
+			"sg-1": {
+				{GroupId: aws.String("sg-1"), SecurityGroupRuleId: aws.String("sgr-https"), IsEgress: aws.Bool(false), IpProtocol: aws.String("tcp"), FromPort: aws.Int32(443), ToPort: aws.Int32(443), CidrIpv4: aws.String("0.0.0.0/0")},
+				{GroupId: aws.String("sg-1"), SecurityGroupRuleId: aws.String("sgr-group"), IsEgress: aws.Bool(false), IpProtocol: aws.String("-1"), ReferencedGroupInfo: &types.ReferencedSecurityGroup{GroupId: aws.String("sg-2")}},
+				{GroupId: aws.String("sg-1"), SecurityGroupRuleId: aws.String("sgr-prefix-list"), IsEgress: aws.Bool(false), IpProtocol: aws.String("tcp"), FromPort: aws.Int32(5432), ToPort: aws.Int32(5432), PrefixListId: aws.String("pl-1")},
+				{GroupId: aws.String("sg-1"), SecurityGroupRuleId: aws.String("sgr-egress"), IsEgress: aws.Bool(true), IpProtocol: aws.String("udp"), FromPort: aws.Int32(53), ToPort: aws.Int32(53), CidrIpv6: aws.String("::/0")},
+			},
+		},
+		PrefixLists: map[string][]netip.Prefix{
+			"pl-1": {netip.MustParsePrefix("192.168.0.0/24")},
+		},
+	}
+	groups := []types.GroupIdentifier{{GroupId: aws.String("sg-1")}}
+	peerGroups := []types.GroupIdentifier{{GroupId: aws.String("sg-2")}}
+
+	tests := []struct {
+		egress     bool
+		protocol   string
+		port       int32
+		peer       string
+		peerGroups []types.GroupIdentifier
+		expected   string
+	}{
+		{false, "6", 443, "8.8.8.8", nil, "sgr-https"},
+		{false, "6", 22, "8.8.8.8", nil, ""},
+		{false, "6", 22, "10.0.1.10", peerGroups, "sgr-group"},
+		{false, "6", 5432, "192.168.0.10", nil, "sgr-prefix-list"},
+		{false, "6", 5432, "192.168.1.10", nil, ""},
+		{true, "17", 53, "2001:db8::1", nil, "sgr-egress"},
+		{true, "17", 53, "8.8.8.8", nil, ""},
+	}
+	for _, test := range tests {
+		rule := network.matchSecurityGroupRule(groups, test.egress, test.protocol, test.port, netip.MustParseAddr(test.peer), test.peerGroups)
+		ruleId := ""
+		if rule != nil {
+			ruleId = aws.ToString(rule.SecurityGroupRuleId)
+		}
+		if ruleId != test.expected {
+			t.Errorf("matchSecurityGroupRule(egress %v, %s/%d, %s) = %q, expected %q", test.egress, test.protocol, test.port, test.peer, ruleId, test.expected)
+		}
+	}
+}
+
+func TestVpcNetworkPortMatches(t *testing.T) {
+	tests := []struct {
+		protocol string
+		fromPort *int32
+		toPort   *int32
+		port     int32
+		expected bool
+	}{
+		{"6", aws.Int32(80), aws.Int32(443), 80, true},
+		{"6", aws.Int32(80), aws.Int32(443), 443, true},
+		{"6", aws.Int32(80), aws.Int32(443), 8080, false},
+		{"17", aws.Int32(53), aws.Int32(53), 53, true},
+		{"6", aws.Int32(-1), aws.Int32(-1), 22, true},
+		{"6", nil, nil, 22, true},
+		{"6", aws.Int32(80), aws.Int32(80), -1, true},
+		{"1", aws.Int32(8), aws.Int32(0), 22, true},
+	}
+	for _, test := range tests {
+		if matches := vpcNetworkPortMatches(test.protocol, test.fromPort, test.toPort, test.port); matches != test.expected {
+			t.Errorf("vpcNetworkPortMatches(%s, %v-%v, %d) = %v, expected %v", test.protocol, aws.ToInt32(test.fromPort), aws.ToInt32(test.toPort), test.port, matches, test.expected)
+		}
+	}
+}
+
+func TestVpcReachabilityTrace(t *testing.T) {
+	tests := []struct {
+		name        string
+		destination string
+		change      func(*vpcNetwork)
+		reachable   bool
+		components  []string
+		lastDetail  string
+	}{
+		{
+			name:        "peering",
+			destination: "eni-dst",
+			reachable:   true,
+			components:  []string{"vpc_peering_connection"},
+		},
+		{
+			name:        "nat gateway",
+			destination: "8.8.8.8",
+			reachable:   true,
+			components:  []string{"nat_gateway", "internet_gateway"},
+		},
+		{
+			name:        "transit gateway",
+			destination: "eni-c",
+			reachable:   true,
+			components:  []string{"transit_gateway_route_table"},
+		},
+		{
+			name:        "incomplete transit gateway route table",
+			destination: "eni-c",
+			change: func(network *vpcNetwork) {
+				network.IncompleteTransitGatewayRouteTables["tgw-rtb-1"] = true
+			},
+			components: []string{"transit_gateway_route_table"},
+			lastDetail: "more routes than a search returns",
+		},
+		{
+			name:        "appliance",
+			destination: "10.3.0.5",
+			components:  []string{"network_interface"},
+			lastDetail:  "exceeds 32 hops",
+		},
+		{
+			name:        "responses denied by the destination network ACL",
+			destination: "eni-dst",
+			change: func(network *vpcNetwork) {
+				network.NetworkAcls[1] = testNetworkAcl("acl-b", []string{"subnet-b1"},
+					testNetworkAclEntry(90, true, "6", "0.0.0.0/0", 32768, 65535, types.RuleActionAllow),
+					testNetworkAclEntry(95, true, "6", "0.0.0.0/0", 0, 65535, types.RuleActionDeny),
+				)
+			},
+			lastDetail: "only allow the responses to the ephemeral ports 32768-65535",
+		},
+		{
+			name:        "responses denied by the source network ACL",
+			destination: "8.8.8.8",
+			change: func(network *vpcNetwork) {
+				network.NetworkAcls[0] = testNetworkAcl("acl-a", []string{"subnet-a1", "subnet-a2"},
+					testNetworkAclEntry(90, false, "6", "8.8.8.8/32", 0, 65535, types.RuleActionDeny),
+				)
+			},
+			components: []string{"nat_gateway"},
+			lastDetail: "No entries allow the responses",
+		},
+	}
+
+	for _, test := range tests {
+		network := testVpcNetwork()
+		if test.change != nil {
+			test.change(network)
+		}
+		source, err := network.resolveEndpoint("eni-src")
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		destination, err := network.resolveEndpoint(test.destination)
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		trace := &vpcReachabilityTrace{
+			network:              network,
+			protocol:             "6",
+			port:                 443,
+			source:               source.Address,
+			sourceInterface:      source.NetworkInterface,
+			destination:          destination.Address,
+			destinationInterface: destination.NetworkInterface,
+		}
+
+		reachable := trace.run()
+		if reachable != test.reachable {
+			t.Errorf("%s: reachable = %v, expected %v", test.name, reachable, test.reachable)
+		}
+		componentTypes := map[string]bool{}
+		for _, hop := range trace.hops {
+			componentTypes[hop.ComponentType] = true
+		}
+		for _, component := range test.components {
+			if !componentTypes[component] {
+				t.Errorf("%s: expected a %s hop", test.name, component)
+			}
+		}
+		last := trace.hops[len(trace.hops)-1]
+		if reachable && (last.ComponentType != "network_acl" || last.Action != "allow") {
+			t.Errorf("%s: expected the path to end with the responses allowed, got %s %s", test.name, last.ComponentType, last.Action)
+		}
+		if test.lastDetail != "" && !strings.Contains(last.Detail, test.lastDetail) {
+			t.Errorf("%s: last hop detail %q, expected it to contain %q", test.name, last.Detail, test.lastDetail)
+		}
+	}
+}
+
+func TestVpcNetworkResolveEndpoint(t *testing.T) {
+	network := testVpcNetwork()
+	network.NetworkInterfaces = append(network.NetworkInterfaces,
+		types.NetworkInterface{NetworkInterfaceId: aws.String("eni-cache"), Description: aws.String("ElastiCache my-group-0001-001")},
+		types.NetworkInterface{NetworkInterfaceId: aws.String("eni-search"), Description: aws.String("ES my-domain")},
+	)
+
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{"arn:aws:elasticache:us-east-1:123456789012:cluster:my-group-0001-001", "eni-cache"},
+		{"arn:aws:elasticache:us-east-1:123456789012:replicationgroup:my-group", "eni-cache"},
+		{"arn:aws:es:us-east-1:123456789012:domain/my-domain", "eni-search"},
+		{"arn:aws:ec2:us-east-1:123456789012:network-interface/eni-src", "eni-src"},
+		{"10.1.1.10", "eni-dst"},
+	}
+	for _, test := range tests {
+		endpoint, err := network.resolveEndpoint(test.value)
+		if err != nil || endpoint == nil || endpoint.NetworkInterface == nil || aws.ToString(endpoint.NetworkInterface.NetworkInterfaceId) != test.expected {
+			t.Errorf("resolveEndpoint(%s) = %v, %v, expected %s", test.value, endpoint, err, test.expected)
+		}
+	}
+
+	if _, err := network.resolveEndpoint("arn:aws:sqs:us-east-1:123456789012:my-queue"); err == nil {
+		t.Errorf("expected an error for a resource without a network interface")
+	}
+}